@@ -0,0 +1,43 @@
+// Command docgen writes docs/rules from the metadata of every registered rule.
+// Run it through `go generate` from the repository root.
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/rules"
+)
+
+const docsDir = "docs/rules"
+
+func main() {
+	if err := os.MkdirAll(docsDir, 0755); err != nil {
+		log.Fatal(err)
+	}
+
+	// Remove pages for rules that no longer exist
+	stale, err := filepath.Glob(filepath.Join(docsDir, "*.md"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	all := rules.All()
+	for _, rule := range all {
+		write(filepath.Join(docsDir, rule.Name()+".md"), rules.Documentation(rule))
+	}
+	write(filepath.Join(docsDir, "README.md"), rules.DocumentationIndex(all))
+}
+
+func write(path string, content string) {
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
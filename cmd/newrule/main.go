@@ -0,0 +1,181 @@
+// Command newrule scaffolds a rule: its source and test files under rules/, an integration fixture
+// under integration/testdata and its docs page. Run it from the repository root:
+//
+//	go run ./cmd/newrule -category security kb4_aws_example
+package main
+
+import (
+	"bytes"
+	"embed"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/rules"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// ruleNamePattern is the naming convention for new rules
+var ruleNamePattern = regexp.MustCompile(`^kb4(_[a-z0-9]+)+$`)
+
+// rule is the data the templates are rendered with
+type rule struct {
+	Name          string
+	Type          string
+	Description   string
+	CategoryConst string
+	Anchor        string
+}
+
+// scaffoldFile maps a template to the path it's rendered to
+type scaffoldFile struct {
+	Template string
+	Path     string
+}
+
+func main() {
+	category := flag.String("category", rules.CategoryStructure, "rule category: "+strings.Join(rules.Categories, ", "))
+	description := flag.String("description", "TODO: describe what the rule enforces.", "one-line description for the rule docs")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: go run ./cmd/newrule [flags] <name>\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	r, err := newRule(flag.Arg(0), *category, *description)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	files, err := scaffold(".", r)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, path := range files {
+		fmt.Println("created", path)
+	}
+
+	// Render the docs page from the new rule's metadata
+	cmd := exec.Command("go", "generate", ".")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		log.Fatalf("failed to generate docs: %s", err)
+	}
+	fmt.Println("created", filepath.Join("docs", "rules", r.Name+".md"))
+}
+
+// newRule validates the name and category against the ruleset's conventions
+func newRule(name string, category string, description string) (*rule, error) {
+	if !ruleNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("rule name %q must be snake_case and start with kb4_", name)
+	}
+	for _, existing := range rules.All() {
+		if existing.Name() == name {
+			return nil, fmt.Errorf("rule %q already exists", name)
+		}
+	}
+
+	categoryConst := ""
+	for _, c := range rules.Categories {
+		if c == category {
+			categoryConst = "Category" + strings.Title(c)
+		}
+	}
+	if categoryConst == "" {
+		return nil, fmt.Errorf("category %q must be one of %s", category, strings.Join(rules.Categories, ", "))
+	}
+
+	return &rule{
+		Name:          name,
+		Type:          ruleType(name),
+		Description:   description,
+		CategoryConst: categoryConst,
+		Anchor:        strings.ReplaceAll(name, "_", "-"),
+	}, nil
+}
+
+// ruleType returns the Go type name for a rule, e.g. kb4_aws_example becomes Kb4AwsExampleRule
+func ruleType(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		b.WriteString(strings.Title(part))
+	}
+	b.WriteString("Rule")
+	return b.String()
+}
+
+// scaffold renders every template under root and returns the paths written.
+// Nothing is written when any of the files already exists.
+func scaffold(root string, r *rule) ([]string, error) {
+	fixture := filepath.Join("integration", "testdata", r.Name)
+	files := []scaffoldFile{
+		{Template: "rule.go.tmpl", Path: filepath.Join("rules", r.Name+".go")},
+		{Template: "rule_test.go.tmpl", Path: filepath.Join("rules", r.Name+"_test.go")},
+		{Template: "tflint.hcl.tmpl", Path: filepath.Join(fixture, ".tflint.hcl")},
+		{Template: "main.tf.tmpl", Path: filepath.Join(fixture, "main.tf")},
+		{Template: "issues.json.tmpl", Path: filepath.Join(fixture, "issues.json")},
+	}
+
+	for _, file := range files {
+		if _, err := os.Stat(filepath.Join(root, file.Path)); err == nil {
+			return nil, fmt.Errorf("%s already exists", file.Path)
+		}
+	}
+
+	written := []string{}
+	for _, file := range files {
+		content, err := render(file.Template, r)
+		if err != nil {
+			return written, err
+		}
+
+		path := filepath.Join(root, file.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return written, err
+		}
+		if err := ioutil.WriteFile(path, content, 0644); err != nil {
+			return written, err
+		}
+		written = append(written, file.Path)
+	}
+
+	return written, nil
+}
+
+// render executes a template, formatting Go output so the scaffold passes gofmt
+func render(name string, r *rule) ([]byte, error) {
+	tmpl, err := template.ParseFS(templates, "templates/"+name)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, r); err != nil {
+		return nil, err
+	}
+
+	if strings.HasSuffix(name, ".go.tmpl") {
+		formatted, err := format.Source(b.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("failed to format %s: %s", name, err)
+		}
+		return formatted, nil
+	}
+	return b.Bytes(), nil
+}
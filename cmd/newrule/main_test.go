@@ -0,0 +1,87 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_ruleType(t *testing.T) {
+	cases := map[string]string{
+		"kb4_example":      "Kb4ExampleRule",
+		"kb4_aws_s3_names": "Kb4AwsS3NamesRule",
+	}
+
+	for name, expected := range cases {
+		if got := ruleType(name); got != expected {
+			t.Errorf("ruleType(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+}
+
+func Test_newRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Category string
+		Error    string
+	}{
+		{Name: "kb4_example", Category: "security"},
+		{Name: "aws_example", Category: "security", Error: `rule name "aws_example" must be snake_case and start with kb4_`},
+		{Name: "kb4_Example", Category: "security", Error: `rule name "kb4_Example" must be snake_case and start with kb4_`},
+		{Name: "kb4_custom_check", Category: "style", Error: `rule "kb4_custom_check" already exists`},
+		{Name: "kb4_example", Category: "speed", Error: `category "speed" must be one of structure, naming, security, cost, style`},
+	}
+
+	for _, tc := range cases {
+		_, err := newRule(tc.Name, tc.Category, "")
+		if tc.Error == "" && err != nil {
+			t.Errorf("newRule(%q, %q): unexpected error %s", tc.Name, tc.Category, err)
+		}
+		if tc.Error != "" && (err == nil || err.Error() != tc.Error) {
+			t.Errorf("newRule(%q, %q): expected error %q, got %v", tc.Name, tc.Category, tc.Error, err)
+		}
+	}
+}
+
+func Test_scaffold(t *testing.T) {
+	root := t.TempDir()
+	r, err := newRule("kb4_example", "security", `Checks "examples".`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := scaffold(root, r)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(files) != 5 {
+		t.Fatalf("Expected 5 files, got %v", files)
+	}
+
+	for _, path := range files {
+		raw, err := ioutil.ReadFile(filepath.Join(root, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.HasSuffix(path, ".go") {
+			if _, err := parser.ParseFile(token.NewFileSet(), path, raw, 0); err != nil {
+				t.Errorf("%s doesn't parse: %s", path, err)
+			}
+		}
+	}
+
+	src, _ := ioutil.ReadFile(filepath.Join(root, "rules", "kb4_example.go"))
+	for _, expected := range []string{"type Kb4ExampleRule struct", `return "kb4_example"`, `Description: "Checks \"examples\".",`, "[]string{CategorySecurity}"} {
+		if !strings.Contains(string(src), expected) {
+			t.Errorf("Expected the rule to contain %q", expected)
+		}
+	}
+
+	// Existing files are never overwritten
+	if _, err := scaffold(root, r); err == nil || err.Error() != filepath.Join("rules", "kb4_example.go")+" already exists" {
+		t.Fatalf("Expected an already exists error, got %v", err)
+	}
+}
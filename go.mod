@@ -3,6 +3,9 @@ module github.com/knowbe4/tflint-ruleset-kb4
 go 1.16
 
 require (
+	github.com/google/go-cmp v0.5.7
 	github.com/hashicorp/hcl/v2 v2.11.1
 	github.com/terraform-linters/tflint-plugin-sdk v0.10.1
+	github.com/zclconf/go-cty v1.10.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,133 @@
+// Package integration runs the built plugin through tflint itself against the fixture modules in testdata.
+// Each fixture directory holds a module, its .tflint.hcl and the issues.json tflint is expected to report.
+// The suite is skipped when tflint isn't on the PATH.
+package integration
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// issue is the subset of tflint's JSON output the fixtures assert on
+type issue struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+}
+
+type tflintOutput struct {
+	Issues []struct {
+		Rule struct {
+			Name     string `json:"name"`
+			Severity string `json:"severity"`
+		} `json:"rule"`
+		Message string `json:"message"`
+		Range   struct {
+			Filename string `json:"filename"`
+			Start    struct {
+				Line int `json:"line"`
+			} `json:"start"`
+		} `json:"range"`
+	} `json:"issues"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func TestIntegration(t *testing.T) {
+	tflint, err := exec.LookPath("tflint")
+	if err != nil {
+		t.Skip("tflint is not installed")
+	}
+
+	pluginDir := buildPlugin(t)
+
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dir := range fixtures {
+		dir := dir
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			expected := []issue{}
+			raw, err := ioutil.ReadFile(filepath.Join(dir, "issues.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(raw, &expected); err != nil {
+				t.Fatal(err)
+			}
+
+			cmd := exec.Command(tflint, "--format", "json", "--force")
+			cmd.Dir = dir
+			cmd.Env = append(os.Environ(), "TFLINT_PLUGIN_DIR="+pluginDir)
+			stdout, err := cmd.Output()
+			if err != nil {
+				if exit, ok := err.(*exec.ExitError); ok {
+					t.Fatalf("tflint failed: %s\n%s", err, exit.Stderr)
+				}
+				t.Fatal(err)
+			}
+
+			var out tflintOutput
+			if err := json.Unmarshal(stdout, &out); err != nil {
+				t.Fatalf("Unexpected tflint output: %s\n%s", err, stdout)
+			}
+			for _, e := range out.Errors {
+				t.Errorf("tflint reported an error: %s", e.Message)
+			}
+
+			actual := []issue{}
+			for _, i := range out.Issues {
+				actual = append(actual, issue{
+					Rule:     i.Rule.Name,
+					Severity: i.Rule.Severity,
+					Message:  i.Message,
+					Filename: filepath.ToSlash(i.Range.Filename),
+					Line:     i.Range.Start.Line,
+				})
+			}
+
+			sortIssues(expected)
+			sortIssues(actual)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Fatalf("Unexpected issues (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+// buildPlugin compiles the plugin into a temporary plugin directory for tflint to load
+func buildPlugin(t *testing.T) string {
+	dir := t.TempDir()
+
+	cmd := exec.Command("go", "build", "-o", filepath.Join(dir, "tflint-ruleset-kb4"), ".")
+	cmd.Dir = ".."
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build the plugin: %s\n%s", err, out)
+	}
+
+	return dir
+}
+
+func sortIssues(issues []issue) {
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Filename != issues[j].Filename {
+			return issues[i].Filename < issues[j].Filename
+		}
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Message < issues[j].Message
+	})
+}
@@ -0,0 +1,12 @@
+variable "name" {
+  type = string
+
+  validation {
+    condition     = length(var.name) > 0
+    error_message = "The name must not be empty."
+  }
+}
+
+variable "enabled" {
+  type = bool
+}
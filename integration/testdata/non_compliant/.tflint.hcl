@@ -0,0 +1,3 @@
+plugin "kb4" {
+  enabled = true
+}
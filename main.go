@@ -2,10 +2,13 @@ package main
 
 import (
 	_ "embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
 
 	"github.com/knowbe4/tflint-ruleset-kb4/rules"
 	"github.com/terraform-linters/tflint-plugin-sdk/plugin"
-	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 /** @todo
@@ -13,26 +16,41 @@ import (
  * For Modules
  *  - Resources should be named `this` where possible.
  *  - No providers in modules (this can be ignored on a module by module basis if needed)
- * For all terraform
- *  - `terraform_remote_state {}` in _init.tf
- *  - `provider {}` in  _init.tf
- *  - `terraform {}` in _init.tf
- *  - Do we want to restrict where locals can be put?
  */
 
+//go:generate go run ./cmd/docgen
+
 //go:embed VERSION
 var VERSION string
 
 func main() {
+	printRules := flag.Bool("print-rules", false, "print a JSON manifest of every rule and exit")
+	printConfig := flag.Bool("print-config", false, "print a starter .tflint.hcl enabling every rule and exit")
+	flag.Parse()
+
+	ruleset, err := rules.NewRuleSet(VERSION)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tflint-ruleset-kb4: %s\n", err)
+		os.Exit(1)
+	}
+
+	if *printRules {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(rules.NewManifest(ruleset)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *printConfig {
+		fmt.Print(rules.StarterConfig(ruleset))
+		return
+	}
 
 	plugin.Serve(&plugin.ServeOpts{
-		RuleSet: &tflint.BuiltinRuleSet{
-			Name:    "template",
-			Version: VERSION,
-			Rules: []tflint.Rule{
-				rules.NewTerraformValidatedVariablesRule(),
-				rules.NewTerraformKb4FileStructureRule(),
-			},
-		},
+		RuleSet: ruleset,
 	})
 }
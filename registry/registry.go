@@ -0,0 +1,29 @@
+// Package registry collects the rules served by this plugin.
+// Each rule registers itself from an init() in its own file, so adding a rule never requires touching main.go.
+package registry
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var registered []tflint.Rule
+
+// Register adds a rule to the ruleset. It panics when a rule with the same name is already registered.
+func Register(rule tflint.Rule) {
+	for _, existing := range registered {
+		if existing.Name() == rule.Name() {
+			panic(fmt.Sprintf("rule `%s` is registered more than once", rule.Name()))
+		}
+	}
+
+	registered = append(registered, rule)
+}
+
+// Rules returns every registered rule in registration order
+func Rules() []tflint.Rule {
+	rules := make([]tflint.Rule, len(registered))
+	copy(rules, registered)
+	return rules
+}
@@ -0,0 +1,43 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+type testRule struct {
+	tflint.DefaultRule
+	name string
+}
+
+func (r *testRule) Name() string              { return r.name }
+func (r *testRule) Enabled() bool             { return true }
+func (r *testRule) Severity() tflint.Severity { return tflint.ERROR }
+func (r *testRule) Check(tflint.Runner) error { return nil }
+
+func Test_Register(t *testing.T) {
+	defer func(original []tflint.Rule) { registered = original }(registered)
+	registered = nil
+
+	Register(&testRule{name: "first"})
+	Register(&testRule{name: "second"})
+
+	rules := Rules()
+	if len(rules) != 2 || rules[0].Name() != "first" || rules[1].Name() != "second" {
+		t.Fatalf("Unexpected rules: %#v", rules)
+	}
+
+	// Callers can't mutate the registry through the returned slice
+	rules[0] = &testRule{name: "replaced"}
+	if Rules()[0].Name() != "first" {
+		t.Fatal("Rules() exposed the registry's backing slice")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Expected a panic when registering a duplicate rule name")
+		}
+	}()
+	Register(&testRule{name: "first"})
+}
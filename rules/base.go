@@ -0,0 +1,183 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// BaseRule is embedded by every rule in this ruleset in place of tflint.DefaultRule.
+// It standardizes how a rule reads its block from .tflint.hcl.
+type BaseRule struct {
+	tflint.DefaultRule
+}
+
+// configValidator is implemented by rule configs that need checks beyond what HCL decoding enforces
+type configValidator interface {
+	Validate() error
+}
+
+// CommonRuleConfig holds the options every rule accepts in its block on top of its own
+type CommonRuleConfig struct {
+	Severity string `hclext:"severity,optional" doc:"Overrides the rule's severity: ERROR, WARNING or NOTICE."`
+	Message  string `hclext:"message,optional" doc:"Replaces the rule's issue messages. {message} is the rule's own message, {rule} its name, {file} and {line} where the issue is, {name} the address of the block it is in and {expected} the file the block belongs in, for rules that place blocks."`
+}
+
+// messagePlaceholderPattern matches a placeholder in a message template
+var messagePlaceholderPattern = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// messagePlaceholders are the placeholders a message template can use
+var messagePlaceholders = []string{"message", "rule", "file", "line", "name", "expected"}
+
+// Validate rejects unknown severities and message placeholders
+func (c *CommonRuleConfig) Validate() error {
+	if _, err := c.severity(); err != nil {
+		return err
+	}
+
+	for _, match := range messagePlaceholderPattern.FindAllStringSubmatch(c.Message, -1) {
+		if !containsString(messagePlaceholders, match[1]) {
+			return fmt.Errorf("message placeholder %s must be one of {%s}", match[0], strings.Join(messagePlaceholders, "}, {"))
+		}
+	}
+	return nil
+}
+
+// severity returns the configured severity override, or nil when the rule keeps its own
+func (c *CommonRuleConfig) severity() (*tflint.Severity, error) {
+	var severity tflint.Severity
+
+	switch strings.ToUpper(c.Severity) {
+	case "":
+		return nil, nil
+	case "ERROR":
+		severity = tflint.ERROR
+	case "WARNING":
+		severity = tflint.WARNING
+	case "NOTICE":
+		severity = tflint.NOTICE
+	default:
+		return nil, fmt.Errorf("severity %q must be one of ERROR, WARNING or NOTICE", c.Severity)
+	}
+
+	return &severity, nil
+}
+
+// decodeConfig reads the rule's block from .tflint.hcl into config, along with the CommonRuleConfig options.
+// config must be a pointer to an hclext-tagged struct of exported fields, already populated with the rule's defaults.
+// Attributes should be tagged optional so a missing or partial block keeps those defaults.
+// A nil config declares that the rule takes no options beyond the common ones, so any other attribute is an error.
+func (b *BaseRule) decodeConfig(runner tflint.Runner, rule tflint.Rule, config interface{}) error {
+	if config == nil {
+		config = &struct{}{}
+	}
+	common := &CommonRuleConfig{}
+
+	if err := decodeRuleBlock(runner, rule, config, common); err != nil {
+		return err
+	}
+
+	for _, c := range []interface{}{common, config} {
+		if validator, ok := c.(configValidator); ok {
+			if err := validator.Validate(); err != nil {
+				return fmt.Errorf("invalid `%s` rule config: %s", rule.Name(), err)
+			}
+		}
+	}
+
+	severity, _ := common.severity()
+	if r, ok := runner.(*Runner); ok {
+		r.overrideSeverity(rule, severity)
+		r.overrideMessage(rule, common.Message)
+	}
+
+	return nil
+}
+
+// decodeRuleBlock reads the rule's block from .tflint.hcl into config and common, without validating them.
+// A block using one of the rule's former names is read when there is none with its current name.
+func decodeRuleBlock(runner tflint.Runner, rule tflint.Rule, config interface{}, common *CommonRuleConfig) error {
+	merged := mergeConfigs(config, common)
+	names := append([]string{rule.Name()}, ruleMetadata(rule).Aliases...)
+
+	if blocks := ruleBlocks(runner); blocks != nil {
+		// Only the name the host has a block for is decoded. Hosts answer for a name without a block
+		// with an error or with empty content, depending on the version, and both would hide a block under a former name.
+		for _, name := range names {
+			if !blocks[name] {
+				continue
+			}
+			if err := runner.DecodeRuleConfig(name, merged.Interface()); err != nil {
+				return fmt.Errorf("failed to decode `%s` rule config: %s", name, err)
+			}
+			break
+		}
+	} else {
+		// Runners outside the RuleSet, like the SDK's test runner, don't say which blocks exist.
+		// A block that exists but doesn't decode fails with diagnostics; any other error means there is no block with that name.
+		for _, name := range names {
+			err := runner.DecodeRuleConfig(name, merged.Interface())
+			if err == nil {
+				break
+			}
+			var diags hcl.Diagnostics
+			if errors.As(err, &diags) {
+				return fmt.Errorf("failed to decode `%s` rule config: %s", name, err)
+			}
+		}
+	}
+
+	splitConfigs(merged, config, common)
+	return nil
+}
+
+// ruleBlocks returns the names of the rule blocks in .tflint.hcl as the host reported them, or nil when the runner doesn't know them
+func ruleBlocks(runner tflint.Runner) map[string]bool {
+	if r, ok := runner.(*Runner); ok {
+		return r.ruleBlocks
+	}
+	return nil
+}
+
+// mergeConfigs builds a pointer to a new struct holding the fields of every config, in order, with their current values.
+// hclext derives a schema from a single struct and rejects attributes outside it,
+// so the rule's own options and the common ones have to be decoded in one pass.
+func mergeConfigs(configs ...interface{}) reflect.Value {
+	fields := []reflect.StructField{}
+	for _, config := range configs {
+		ty := reflect.TypeOf(config).Elem()
+		for i := 0; i < ty.NumField(); i++ {
+			field := ty.Field(i)
+			fields = append(fields, reflect.StructField{Name: field.Name, Type: field.Type, Tag: field.Tag})
+		}
+	}
+
+	merged := reflect.New(reflect.StructOf(fields))
+	splitOrMerge(merged, configs, true)
+	return merged
+}
+
+// splitConfigs copies the decoded values from a struct built by mergeConfigs back into the configs
+func splitConfigs(merged reflect.Value, configs ...interface{}) {
+	splitOrMerge(merged, configs, false)
+}
+
+func splitOrMerge(merged reflect.Value, configs []interface{}, toMerged bool) {
+	offset := 0
+	for _, config := range configs {
+		val := reflect.ValueOf(config).Elem()
+		for i := 0; i < val.NumField(); i++ {
+			if toMerged {
+				merged.Elem().Field(offset + i).Set(val.Field(i))
+			} else {
+				val.Field(i).Set(merged.Elem().Field(offset + i))
+			}
+		}
+		offset += val.NumField()
+	}
+}
@@ -0,0 +1,260 @@
+package rules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+type testRuleConfig struct {
+	Limit int      `hclext:"limit,optional"`
+	Names []string `hclext:"names,optional"`
+}
+
+func (c *testRuleConfig) Validate() error {
+	if c.Limit < 1 {
+		return errors.New("limit must be at least 1")
+	}
+	return nil
+}
+
+type testRule struct {
+	BaseRule
+}
+
+func (r *testRule) Name() string              { return "test_rule" }
+func (r *testRule) Enabled() bool             { return true }
+func (r *testRule) Severity() tflint.Severity { return tflint.ERROR }
+func (r *testRule) Check(tflint.Runner) error { return nil }
+
+func Test_BaseRule_decodeConfig(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected testRuleConfig
+		Error    string
+	}{
+		{
+			Name:     "no rule block keeps defaults",
+			Config:   ``,
+			Expected: testRuleConfig{Limit: 10},
+		},
+		{
+			Name: "partial rule block keeps remaining defaults",
+			Config: `
+rule "test_rule" {
+  enabled = true
+  names   = ["a", "b"]
+}`,
+			Expected: testRuleConfig{Limit: 10, Names: []string{"a", "b"}},
+		},
+		{
+			Name: "full rule block",
+			Config: `
+rule "test_rule" {
+  enabled = true
+  limit   = 3
+  names   = ["a"]
+}`,
+			Expected: testRuleConfig{Limit: 3, Names: []string{"a"}},
+		},
+		{
+			Name: "validation failure",
+			Config: `
+rule "test_rule" {
+  enabled = true
+  limit   = 0
+}`,
+			Error: "invalid `test_rule` rule config: limit must be at least 1",
+		},
+		{
+			Name: "unknown attribute",
+			Config: `
+rule "test_rule" {
+  enabled = true
+  limt    = 3
+}`,
+			Error: "failed to decode `test_rule` rule config: .tflint.hcl:4,3-7: Unsupported argument; An argument named \"limt\" is not expected here. Did you mean \"limit\"?",
+		},
+	}
+
+	rule := &testRule{}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{".tflint.hcl": tc.Config})
+
+			config := testRuleConfig{Limit: 10}
+			err := rule.decodeConfig(runner, rule, &config)
+
+			if tc.Error != "" {
+				if err == nil || err.Error() != tc.Error {
+					t.Fatalf("Expected error %q, got %v", tc.Error, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			if config.Limit != tc.Expected.Limit || len(config.Names) != len(tc.Expected.Names) {
+				t.Fatalf("Expected %#v, got %#v", tc.Expected, config)
+			}
+			for i := range config.Names {
+				if config.Names[i] != tc.Expected.Names[i] {
+					t.Fatalf("Expected %#v, got %#v", tc.Expected, config)
+				}
+			}
+		})
+	}
+}
+
+func Test_BaseRule_decodeConfig_noOptions(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{".tflint.hcl": `
+rule "test_rule" {
+  enabled = true
+  limit   = 3
+}`})
+
+	rule := &testRule{}
+	if err := rule.decodeConfig(runner, rule, nil); err == nil {
+		t.Fatal("Expected an error for an option on a rule that takes none")
+	}
+}
+
+func Test_BaseRule_severityOverride(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected tflint.Severity
+		Error    string
+	}{
+		{
+			Name:     "no override",
+			Config:   ``,
+			Expected: tflint.ERROR,
+		},
+		{
+			Name: "demoted",
+			Config: `
+rule "test_rule" {
+  enabled  = true
+  severity = "warning"
+  limit    = 3
+}`,
+			Expected: tflint.WARNING,
+		},
+		{
+			Name: "unknown severity",
+			Config: `
+rule "test_rule" {
+  enabled  = true
+  severity = "fatal"
+}`,
+			Error: "invalid `test_rule` rule config: severity \"fatal\" must be one of ERROR, WARNING or NOTICE",
+		},
+	}
+
+	rule := &testRule{}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			host := helper.TestRunner(t, map[string]string{".tflint.hcl": tc.Config})
+			runner := NewRunner(host, nil)
+
+			config := testRuleConfig{Limit: 10}
+			err := rule.decodeConfig(runner, rule, &config)
+			if tc.Error != "" {
+				if err == nil || err.Error() != tc.Error {
+					t.Fatalf("Expected error %q, got %v", tc.Error, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			if err := runner.EmitIssue(rule, "issue", hclRange("main.tf")); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			if got := host.Issues[0].Rule.Severity(); got != tc.Expected {
+				t.Fatalf("Expected severity %s, got %s", tc.Expected, got)
+			}
+			if got := host.Issues[0].Rule.Name(); got != "test_rule" {
+				t.Fatalf("Expected the rule name to be kept, got %s", got)
+			}
+		})
+	}
+}
+
+func Test_BaseRule_messageTemplate(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected string
+		Error    string
+	}{
+		{
+			Name:     "no template",
+			Config:   ``,
+			Expected: "issue with {braces}",
+		},
+		{
+			Name: "template",
+			Config: `
+rule "test_rule" {
+  enabled = true
+  message = "{rule}: {message} at {file}:{line} in {name} ({expected}), see https://runbooks.example.com/{rule}"
+}`,
+			Expected: "test_rule: issue with {braces} at main.tf:2 in var.region (), see https://runbooks.example.com/test_rule",
+		},
+		{
+			Name: "unknown placeholder",
+			Config: `
+rule "test_rule" {
+  enabled = true
+  message = "{message} in {module}"
+}`,
+			Error: "invalid `test_rule` rule config: message placeholder {module} must be one of {message}, {rule}, {file}, {line}, {name}, {expected}",
+		},
+	}
+
+	rule := &testRule{}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			host := helper.TestRunner(t, map[string]string{
+				".tflint.hcl": tc.Config,
+				"main.tf": `
+variable "region" {}`,
+			})
+			runner := NewRunner(host, nil)
+
+			config := testRuleConfig{Limit: 10}
+			err := rule.decodeConfig(runner, rule, &config)
+			if tc.Error != "" {
+				if err == nil || err.Error() != tc.Error {
+					t.Fatalf("Expected error %q, got %v", tc.Error, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			issueRange := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 2, Column: 1, Byte: 1}, End: hcl.Pos{Line: 2, Column: 18, Byte: 18}}
+			if err := runner.EmitIssue(rule, "issue with {braces}", issueRange); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			if got := host.Issues[0].Message; got != tc.Expected {
+				t.Fatalf("Expected message %q, got %q", tc.Expected, got)
+			}
+		})
+	}
+}
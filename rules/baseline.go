@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// BaselineUpdateEnv is the environment variable that regenerates the baseline file instead of consulting it.
+// tflint doesn't pass its own flags to plugins, so `KB4_UPDATE_BASELINE=1 tflint` stands in for a flag.
+const BaselineUpdateEnv = "KB4_UPDATE_BASELINE"
+
+// baselineVersion is bumped whenever fingerprints change meaning, invalidating older baselines
+const baselineVersion = 2
+
+// Baseline is a committed record of known issues. Issues it lists are suppressed,
+// so strict rules can be enabled on legacy code without fixing everything first.
+// Issues are keyed by their fingerprint rather than position, so unrelated edits don't resurface them.
+type Baseline struct {
+	Version int             `json:"version"`
+	Issues  []BaselineIssue `json:"issues"`
+
+	mu        sync.Mutex
+	remaining map[string]int
+}
+
+// BaselineIssue is a single known issue
+type BaselineIssue struct {
+	Fingerprint string `json:"fingerprint"`
+	Rule        string `json:"rule"`
+	Filename    string `json:"filename"`
+	Address     string `json:"address"`
+	Message     string `json:"message"`
+}
+
+// NewBaseline returns an empty baseline, ready to record issues
+func NewBaseline() *Baseline {
+	return &Baseline{Version: baselineVersion, Issues: []BaselineIssue{}}
+}
+
+// LoadBaseline reads a baseline file
+func LoadBaseline(path string) (*Baseline, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %s", err)
+	}
+
+	baseline := NewBaseline()
+	if err := json.Unmarshal(raw, baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %s", path, err)
+	}
+	if baseline.Version != baselineVersion {
+		return nil, fmt.Errorf("baseline %s has version %d, expected %d; regenerate it with %s=1", path, baseline.Version, baselineVersion, BaselineUpdateEnv)
+	}
+
+	return baseline, nil
+}
+
+// Suppresses reports whether the issue with the fingerprint is known, consuming one occurrence of it.
+// A baseline listing an issue twice suppresses it twice, so a third occurrence is still reported.
+func (b *Baseline) Suppresses(fingerprint string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.remaining == nil {
+		b.remaining = map[string]int{}
+		for _, issue := range b.Issues {
+			b.remaining[issue.fingerprint()]++
+		}
+	}
+
+	if b.remaining[fingerprint] == 0 {
+		return false
+	}
+
+	b.remaining[fingerprint]--
+	return true
+}
+
+// fingerprint returns the issue's fingerprint under the current name of its rule.
+// Issues recorded under a rule's former name are fingerprinted again, so renaming a rule doesn't resurface them.
+func (i BaselineIssue) fingerprint() string {
+	if rule, ok := ByName(i.Rule); ok && rule.Name() != i.Rule {
+		return issueFingerprint(rule.Name(), i.Address, i.Message)
+	}
+	return i.Fingerprint
+}
+
+// Record adds an issue to the baseline. The file and address are kept alongside the fingerprint for reviewers.
+func (b *Baseline) Record(rule tflint.Rule, message string, issueRange hcl.Range, address string, fingerprint string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.Issues = append(b.Issues, BaselineIssue{
+		Fingerprint: fingerprint,
+		Rule:        rule.Name(),
+		Filename:    filepath.ToSlash(issueRange.Filename),
+		Address:     address,
+		Message:     message,
+	})
+}
+
+// Write saves the baseline with its issues sorted, so regenerating it produces reviewable diffs
+func (b *Baseline) Write(path string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sort.SliceStable(b.Issues, func(i, j int) bool {
+		if b.Issues[i].Filename != b.Issues[j].Filename {
+			return b.Issues[i].Filename < b.Issues[j].Filename
+		}
+		if b.Issues[i].Rule != b.Issues[j].Rule {
+			return b.Issues[i].Rule < b.Issues[j].Rule
+		}
+		return b.Issues[i].Message < b.Issues[j].Message
+	})
+
+	raw, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(path, append(raw, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %s", err)
+	}
+	return nil
+}
+
+// baselineUpdateRequested reports whether this run regenerates the baseline
+func baselineUpdateRequested() bool {
+	return os.Getenv(BaselineUpdateEnv) != ""
+}
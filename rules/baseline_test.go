@@ -0,0 +1,117 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_RuleSet_Baseline(t *testing.T) {
+	legacy := map[string]string{
+		"main.tf": `
+variable "first" {}
+variable "second" {}`,
+	}
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	config := DefaultConfig()
+	config.BaselineFile = path
+
+	ruleset := &RuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{Rules: []tflint.Rule{NewTerraformValidatedVariablesRule()}}, config: config}
+	if err := ruleset.ApplyGlobalConfig(&tflint.Config{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A missing baseline is an error rather than silently reporting everything
+	if err := ruleset.Check(helper.TestRunner(t, legacy)); err == nil {
+		t.Fatal("Expected an error for a missing baseline")
+	}
+
+	// Regenerating records every issue and still reports them
+	os.Setenv(BaselineUpdateEnv, "1")
+	runner := helper.TestRunner(t, legacy)
+	err := ruleset.Check(runner)
+	os.Unsetenv(BaselineUpdateEnv)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(runner.Issues) != 2 {
+		t.Fatalf("Expected 2 issues while recording, got %d", len(runner.Issues))
+	}
+
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(baseline.Issues) != 2 || baseline.Issues[0].Address != "var.first" || baseline.Issues[0].Message != "`first` variable has no validations. Please include at least 1 validation for types that are not a bool." {
+		t.Fatalf("Unexpected baseline: %#v", baseline.Issues)
+	}
+
+	// Known issues are suppressed even after they move to another file, new ones are reported
+	runner = helper.TestRunner(t, map[string]string{
+		"main.tf": `
+variable "third" {}
+
+variable "second" {}`,
+		"_variables.tf": `variable "first" {}`,
+	})
+	if err := ruleset.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	helper.AssertIssuesWithoutRange(t, helper.Issues{
+		{Rule: NewTerraformValidatedVariablesRule(), Message: "`third` variable has no validations. Please include at least 1 validation for types that are not a bool."},
+	}, runner.Issues)
+}
+
+func Test_Baseline_Suppresses(t *testing.T) {
+	rule := NewTerraformValidatedVariablesRule()
+	fingerprint := issueFingerprint(rule.Name(), "var.name", "duplicate")
+
+	baseline := NewBaseline()
+	baseline.Record(rule, "duplicate", hclRange("main.tf"), "var.name", fingerprint)
+	baseline.Record(rule, "duplicate", hclRange("main.tf"), "var.name", fingerprint)
+
+	for i := 0; i < 2; i++ {
+		if !baseline.Suppresses(fingerprint) {
+			t.Fatalf("Expected occurrence %d to be suppressed", i+1)
+		}
+	}
+	if baseline.Suppresses(fingerprint) {
+		t.Fatal("Expected a third occurrence to be reported")
+	}
+	if baseline.Suppresses(issueFingerprint(rule.Name(), "var.other", "duplicate")) {
+		t.Fatal("Expected the same message in another block to be reported")
+	}
+}
+
+func Test_LoadBaseline_version(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(path, []byte(`{"version": 0, "issues": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadBaseline(path); err == nil {
+		t.Fatal("Expected an error for a baseline of another version")
+	}
+}
+
+func Test_Baseline_renamedRule(t *testing.T) {
+	rule := NewTerraformKb4FileStructureRule()
+	message := "variable \"name\" should be moved from main.tf to _variables.tf"
+
+	baseline := NewBaseline()
+	baseline.Issues = append(baseline.Issues, BaselineIssue{
+		Fingerprint: issueFingerprint("terraform_kb4_module_structure", "var.name", message),
+		Rule:        "terraform_kb4_module_structure",
+		Filename:    "main.tf",
+		Address:     "var.name",
+		Message:     message,
+	})
+
+	if !baseline.Suppresses(issueFingerprint(rule.Name(), "var.name", message)) {
+		t.Fatal("Expected an issue recorded under the rule's former name to be suppressed")
+	}
+}
@@ -0,0 +1,24 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+)
+
+// describeBlock names a block for an issue message.
+// Labeled blocks are named by type and labels, e.g. `provider "aws"`.
+// Blocks without labels, like terraform and locals, are named by type and line,
+// so two of them in the same file can be told apart.
+func describeBlock(block *hclext.Block) string {
+	if len(block.Labels) == 0 {
+		return fmt.Sprintf("%s block on line %d", block.Type, block.DefRange.Start.Line)
+	}
+
+	parts := []string{block.Type}
+	for _, label := range block.Labels {
+		parts = append(parts, fmt.Sprintf("%q", label))
+	}
+	return strings.Join(parts, " ")
+}
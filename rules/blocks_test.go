@@ -0,0 +1,38 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+)
+
+func Test_describeBlock(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Block    *hclext.Block
+		Expected string
+	}{
+		{
+			Name:     "unlabeled",
+			Block:    &hclext.Block{Type: "terraform", DefRange: hcl.Range{Start: hcl.Pos{Line: 3}}},
+			Expected: "terraform block on line 3",
+		},
+		{
+			Name:     "one label",
+			Block:    &hclext.Block{Type: "provider", Labels: []string{"aws"}},
+			Expected: `provider "aws"`,
+		},
+		{
+			Name:     "two labels",
+			Block:    &hclext.Block{Type: "data", Labels: []string{"terraform_remote_state", "network"}},
+			Expected: `data "terraform_remote_state" "network"`,
+		},
+	}
+
+	for _, tc := range cases {
+		if got := describeBlock(tc.Block); got != tc.Expected {
+			t.Errorf("%s: expected %q, got %q", tc.Name, tc.Expected, got)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Casing conventions understood by formatCase
+const (
+	PascalCase = "PascalCase"
+	CamelCase  = "camelCase"
+	SnakeCase  = "snake_case"
+	KebabCase  = "kebab-case"
+)
+
+// casings lists the conventions formatCase understands
+var casings = []string{PascalCase, CamelCase, SnakeCase, KebabCase}
+
+// splitWords breaks an identifier into lower case words at separators and case changes,
+// e.g. "CostCenter", "cost_center" and "cost-center" are all ["cost", "center"]. A run of capitals is one word, as in "DNSName".
+func splitWords(s string) []string {
+	words := splitWordsAsWritten(s)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return words
+}
+
+// splitWordsAsWritten is splitWords keeping the case of every word, e.g. "DNSName" is ["DNS", "Name"]
+func splitWordsAsWritten(s string) []string {
+	words := []string{}
+	current := []rune{}
+	runes := []rune(s)
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = current[:0]
+		}
+	}
+
+	for i, c := range runes {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(c) && len(current) > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		current = append(current, c)
+	}
+	flush()
+
+	return words
+}
+
+// isAcronym reports whether a word as written is a run of capitals, like "DNS", "ID" or "EC2"
+func isAcronym(word string) bool {
+	if len(word) < 2 || strings.ToUpper(word) != word {
+		return false
+	}
+	return strings.IndexFunc(word, unicode.IsLetter) >= 0
+}
+
+// formatCase rewrites an identifier in the given convention.
+// PascalCase and camelCase keep acronyms in capitals, so "DNSName" stays "DNSName" rather than becoming "DnsName",
+// A single word in capitals, like "ID", is an acronym too, but words in an identifier written entirely in capitals, like "COST_CENTER", aren't.
+func formatCase(s string, casing string) (string, error) {
+	switch casing {
+	case PascalCase, CamelCase:
+		words := splitWordsAsWritten(s)
+		keepAcronyms := strings.ToUpper(s) != s || len(words) == 1
+		for i, word := range words {
+			switch {
+			case i == 0 && casing == CamelCase:
+				words[i] = strings.ToLower(word)
+			case keepAcronyms && isAcronym(word):
+			default:
+				words[i] = strings.ToUpper(word[:1]) + strings.ToLower(word[1:])
+			}
+		}
+		return strings.Join(words, ""), nil
+	case SnakeCase:
+		return strings.Join(splitWords(s), "_"), nil
+	case KebabCase:
+		return strings.Join(splitWords(s), "-"), nil
+	}
+
+	return "", fmt.Errorf("casing %q must be one of %s", casing, strings.Join(casings, ", "))
+}
@@ -0,0 +1,43 @@
+package rules
+
+import (
+	"testing"
+)
+
+func Test_formatCase(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Casing   string
+		Expected string
+	}{
+		{Input: "cost_center", Casing: PascalCase, Expected: "CostCenter"},
+		{Input: "CostCenter", Casing: PascalCase, Expected: "CostCenter"},
+		{Input: "costCenter", Casing: SnakeCase, Expected: "cost_center"},
+		{Input: "DNSName", Casing: KebabCase, Expected: "dns-name"},
+		{Input: "team-2fa", Casing: CamelCase, Expected: "team2fa"},
+		{Input: "Data Classification", Casing: CamelCase, Expected: "dataClassification"},
+		{Input: "app", Casing: PascalCase, Expected: "App"},
+		{Input: "DNSName", Casing: PascalCase, Expected: "DNSName"},
+		{Input: "ID", Casing: PascalCase, Expected: "ID"},
+		{Input: "CostCenterID", Casing: PascalCase, Expected: "CostCenterID"},
+		{Input: "DNSName", Casing: CamelCase, Expected: "dnsName"},
+		{Input: "ID", Casing: CamelCase, Expected: "id"},
+		{Input: "CostCenterID", Casing: CamelCase, Expected: "costCenterID"},
+		{Input: "cost_center_ID", Casing: PascalCase, Expected: "CostCenterID"},
+		{Input: "COST_CENTER", Casing: PascalCase, Expected: "CostCenter"},
+	}
+
+	for _, tc := range cases {
+		got, err := formatCase(tc.Input, tc.Casing)
+		if err != nil {
+			t.Fatalf("%s: unexpected error occurred: %s", tc.Input, err)
+		}
+		if got != tc.Expected {
+			t.Errorf("%s as %s: expected %q, got %q", tc.Input, tc.Casing, tc.Expected, got)
+		}
+	}
+
+	if _, err := formatCase("name", "Title Case"); err == nil || err.Error() != `casing "Title Case" must be one of PascalCase, camelCase, snake_case, kebab-case` {
+		t.Fatalf("Expected an unknown casing error, got %v", err)
+	}
+}
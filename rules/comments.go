@@ -0,0 +1,80 @@
+package rules
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// commentRun is a block comment, or consecutive line comments of the same style on lines of their own
+type commentRun struct {
+	// Style is the comment marker: #, // or /*
+	Style string
+	// Lines holds the text of each line without the comment markers
+	Lines []string
+	// Range covers the comments without the newline a line comment ends with
+	Range hcl.Range
+}
+
+// commentRuns groups the comments of a native syntax file into runs. A comment after code on the same line is a run of its own.
+func commentRuns(src []byte, filename string) []commentRun {
+	runs := []commentRun{}
+	tokens, _ := hclsyntax.LexConfig(src, filename, hcl.InitialPos)
+
+	// joinable is whether the last run is of standalone line comments that the next may continue
+	joinable := false
+	for i, token := range tokens {
+		if token.Type != hclsyntax.TokenComment {
+			continue
+		}
+
+		text := string(token.Bytes)
+		rng := token.Range
+		if trimmed := strings.TrimRight(text, "\r\n"); trimmed != text {
+			rng.End = hcl.Pos{
+				Line:   rng.Start.Line,
+				Column: rng.Start.Column + utf8.RuneCountInString(trimmed),
+				Byte:   rng.Start.Byte + len(trimmed),
+			}
+			text = trimmed
+		}
+
+		if strings.HasPrefix(text, "/*") {
+			lines := strings.Split(strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/"), "\n")
+			for j, line := range lines {
+				lines[j] = strings.TrimPrefix(strings.TrimSpace(line), "*")
+			}
+			runs = append(runs, commentRun{Style: "/*", Lines: lines, Range: rng})
+			joinable = false
+			continue
+		}
+
+		style := "#"
+		if strings.HasPrefix(text, "//") {
+			style = "//"
+		}
+		line := strings.TrimPrefix(text, style)
+
+		prev := hclsyntax.Token{Type: hclsyntax.TokenNewline}
+		if i > 0 {
+			prev = tokens[i-1]
+		}
+		lineComment := prev.Type == hclsyntax.TokenComment && strings.HasSuffix(string(prev.Bytes), "\n")
+		standalone := prev.Type == hclsyntax.TokenNewline || lineComment
+
+		// A line comment ends with its newline, so the one before is the previous token
+		if n := len(runs); lineComment && joinable {
+			if last := &runs[n-1]; last.Style == style {
+				last.Lines = append(last.Lines, line)
+				last.Range.End = rng.End
+				continue
+			}
+		}
+		runs = append(runs, commentRun{Style: style, Lines: []string{line}, Range: rng})
+		joinable = standalone
+	}
+
+	return runs
+}
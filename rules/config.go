@@ -0,0 +1,260 @@
+package rules
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// StrictEnv is the environment variable that turns strict mode on without changing .tflint.hcl,
+// so merge gates can enforce every rule while local runs keep warnings as warnings
+const StrictEnv = "KB4_STRICT"
+
+// DefaultStyleGuideURL is the style guide that rule links point at unless the ruleset config overrides it
+const DefaultStyleGuideURL = "https://engineering.internal.knowbe4.com/tech-stack/terraform/style-guide/"
+
+// Config is the ruleset-level configuration shared by every rule, read from the plugin block in .tflint.hcl:
+//
+//	plugin "kb4" {
+//	  enabled          = true
+//	  style_guide_url  = "https://docs.example.com/terraform/style-guide/"
+//	  organization     = "knowbe4"
+//	  environments     = ["dev", "staging", "prod"]
+//	  default_tag_keys = ["Environment", "Service"]
+//	  exclude_files    = ["generated_*.tf"]
+//	  categories       = ["security"]
+//	  baseline_file    = ".tflint-baseline.json"
+//	  policy_file      = "https://policy.example.com/terraform.yaml"
+//	  policy_cache_ttl = "15m"
+//	  parallelism      = 4
+//	  deep_check       = true
+//	  strict           = true
+//	  changed_files    = ["modules/app/main.tf"]
+//	  metrics_file     = "tflint-metrics.json"
+//	}
+type Config struct {
+	// StyleGuideURL is the base URL rules link their Metadata anchors onto. It also replaces DefaultStyleGuideURL in other rule links.
+	StyleGuideURL string `hclext:"style_guide_url,optional" doc:"Base URL of the style guide that rule links point at."`
+	// Organization is the org name naming rules use as a prefix when the org policy sets no pattern
+	Organization string `hclext:"organization,optional" doc:"Org name kb4_s3_bucket_naming requires as the bucket name prefix when neither its pattern nor the org policy's naming sets one."`
+	// Environments is the canonical list of environment names
+	Environments []string `hclext:"environments,optional" doc:"Canonical environment names."`
+	// DefaultTagKeys are the tag keys kb4_required_tags checks for when the org policy has no required_tags
+	DefaultTagKeys []string `hclext:"default_tag_keys,optional" doc:"Tag keys kb4_required_tags requires on every taggable resource when the org policy's required_tags lists none."`
+	// ExcludeFiles are globs of files that placement rules ignore, e.g. generated and override files
+	ExcludeFiles []string `hclext:"exclude_files,optional" doc:"Globs of generated or override files that placement rules ignore."`
+	// Categories restricts the ruleset to rules in these categories. Empty means every category.
+	Categories []string `hclext:"categories,optional" doc:"Only run rules in these categories. Empty runs every category."`
+	// ExcludeCategories skips rules in these categories
+	ExcludeCategories []string `hclext:"exclude_categories,optional" doc:"Skip rules in these categories."`
+	// BaselineFile is the path of the committed baseline of known issues, relative to where tflint runs
+	BaselineFile string `hclext:"baseline_file,optional" doc:"Baseline of known issues to suppress. Regenerate it by running tflint with KB4_UPDATE_BASELINE=1."`
+	// PolicyFile is the path of the shared org policy file, relative to where tflint runs
+	PolicyFile string `hclext:"policy_file,optional" doc:"Org policy file read by rules, relative to where tflint runs, or an https:// URL."`
+	// PolicyCacheTTL is how long a fetched remote policy is reused before it is fetched again
+	PolicyCacheTTL string `hclext:"policy_cache_ttl,optional" doc:"How long a fetched remote policy is cached, as a Go duration."`
+	// Parallelism caps how many rules run at once. Rules mostly wait on the host, so 0 runs them all at once.
+	Parallelism int `hclext:"parallelism,optional" doc:"How many rules run at once. 0 runs every rule at once."`
+	// DeepCheck lets rules look outside the module, e.g. query module registries and git remotes or read called modules
+	DeepCheck bool `hclext:"deep_check,optional" doc:"Let rules query module registries and git remotes and read called modules. Slower, and needs network access."`
+	// Strict reports WARNING issues as ERROR. Setting StrictEnv turns it on as well.
+	Strict bool `hclext:"strict,optional" doc:"Report WARNING issues as ERROR. Setting KB4_STRICT turns it on as well."`
+	// ChangedFiles are the files a pull request touches, relative to the repository root. Rules in changedFilesCategories only report issues in them.
+	ChangedFiles []string `hclext:"changed_files,optional" doc:"Files changed by the pull request, relative to the repository root. Structure, naming and style rules only report issues in these files. Empty reports every file."`
+	// MetricsFile is the path a JSON summary of each run is written to, relative to where tflint runs
+	MetricsFile string `hclext:"metrics_file,optional" doc:"JSON summary of the run written after every check, with issue counts per rule and file and the time each rule took."`
+
+	// policy is loaded from PolicyFile when the config is applied
+	policy *Policy
+}
+
+// DefaultConfig returns the ruleset config used when the plugin block sets nothing
+func DefaultConfig() *Config {
+	return &Config{
+		StyleGuideURL:     DefaultStyleGuideURL,
+		Organization:      "",
+		Environments:      []string{"dev", "staging", "prod"},
+		DefaultTagKeys:    []string{},
+		ExcludeFiles:      []string{"override.tf", "override.tf.json", "*_override.tf", "*_override.tf.json"},
+		Categories:        []string{},
+		ExcludeCategories: []string{},
+		PolicyCacheTTL:    "1h",
+	}
+}
+
+// styleGuideLink returns the link to a section of the configured style guide
+func (c *Config) styleGuideLink(anchor string) string {
+	return c.StyleGuideURL + "#" + anchor
+}
+
+// Validate checks the config for values that decode fine but can't be used
+func (c *Config) Validate() error {
+	if u, err := url.Parse(c.StyleGuideURL); err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("style_guide_url %q is not an absolute URL", c.StyleGuideURL)
+	}
+
+	if len(c.Environments) == 0 {
+		return fmt.Errorf("environments must list at least one environment")
+	}
+
+	for _, category := range append(append([]string{}, c.Categories...), c.ExcludeCategories...) {
+		if !containsString(Categories, category) {
+			return fmt.Errorf("category %q must be one of %s", category, strings.Join(Categories, ", "))
+		}
+	}
+
+	for _, pattern := range c.ExcludeFiles {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("exclude_files pattern %q is invalid: %s", pattern, err)
+		}
+	}
+
+	if c.PolicyFile != "" && isRemotePolicy(c.PolicyFile) {
+		if _, err := policyURL(c.PolicyFile); err != nil {
+			return fmt.Errorf("policy_file %s", err)
+		}
+	}
+
+	if ttl, err := time.ParseDuration(c.PolicyCacheTTL); err != nil || ttl < 0 {
+		return fmt.Errorf("policy_cache_ttl %q is not a valid duration", c.PolicyCacheTTL)
+	}
+
+	if c.Parallelism < 0 {
+		return fmt.Errorf("parallelism must not be negative")
+	}
+
+	return nil
+}
+
+// policyCacheTTL returns the parsed policy_cache_ttl. Validate has already rejected unparsable values.
+func (c *Config) policyCacheTTL() time.Duration {
+	ttl, _ := time.ParseDuration(c.PolicyCacheTTL)
+	return ttl
+}
+
+// strict reports whether WARNING issues are reported as ERROR, from the config or StrictEnv
+func (c *Config) strict() bool {
+	return c.Strict || os.Getenv(StrictEnv) != ""
+}
+
+// workers returns how many of the given number of rules run at once
+func (c *Config) workers(rules int) int {
+	if c.Parallelism > 0 && c.Parallelism < rules {
+		return c.Parallelism
+	}
+	return rules
+}
+
+// selectsRule reports whether the category filters let the rule run.
+// A rule runs when it has a selected category and none of the excluded ones.
+func (c *Config) selectsRule(rule tflint.Rule) bool {
+	categories := ruleMetadata(rule).Categories
+
+	for _, category := range categories {
+		if containsString(c.ExcludeCategories, category) {
+			return false
+		}
+	}
+
+	if len(c.Categories) == 0 {
+		return true
+	}
+	for _, category := range categories {
+		if containsString(c.Categories, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// excludesFile reports whether a file matches one of the exclude_files globs.
+// Globs match against the full file name and against its base name, so `generated_*.tf` excludes it in any directory.
+func (c *Config) excludesFile(filename string) bool {
+	filename = filepath.ToSlash(filename)
+
+	for _, pattern := range c.ExcludeFiles {
+		if ok, _ := path.Match(pattern, filename); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(filename)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// changedFilesCategories are the categories of rules that changed_files scopes to the changed files.
+// Placement, naming and ordering issues can be fixed file by file, while security and cost issues are reported everywhere.
+var changedFilesCategories = []string{CategoryStructure, CategoryNaming, CategoryStyle}
+
+// changedFilesExemptRules report every issue despite their category. kb4_ruleset_version checks the TFLint config,
+// which pull requests rarely touch, so scoping it would hide an outdated pin for good.
+var changedFilesExemptRules = []string{"kb4_ruleset_version"}
+
+// outOfScope reports whether changed_files keeps the rule from reporting an issue in the file.
+// Changed paths are relative to the repository root, as `git diff --name-only` prints them, and tflint reports
+// files relative to where it runs, so both are resolved to absolute paths and compared whole.
+func (c *Config) outOfScope(rule tflint.Rule, filename string) bool {
+	if len(c.ChangedFiles) == 0 || containsString(changedFilesExemptRules, rule.Name()) {
+		return false
+	}
+
+	scoped := false
+	for _, category := range ruleMetadata(rule).Categories {
+		if containsString(changedFilesCategories, category) {
+			scoped = true
+		}
+	}
+	if !scoped {
+		return false
+	}
+
+	file, err := filepath.Abs(filename)
+	if err != nil {
+		return false
+	}
+	root := repositoryRoot()
+	for _, changed := range c.ChangedFiles {
+		changed = filepath.FromSlash(changed)
+		if !filepath.IsAbs(changed) {
+			changed = filepath.Join(root, changed)
+		}
+		if filepath.Clean(changed) == file {
+			return false
+		}
+	}
+	return true
+}
+
+// repositoryRoot returns the closest directory holding .git at or above the working directory,
+// or the working directory itself outside a repository
+func repositoryRoot() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+
+	for dir := wd; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		if filepath.Dir(dir) == dir {
+			return wd
+		}
+	}
+}
+
+// ruleSetConfig returns the ruleset config carried by the runner, or the defaults
+// when a rule is checked against a runner that didn't come from the RuleSet (e.g. in tests)
+func ruleSetConfig(runner tflint.Runner) *Config {
+	if r, ok := runner.(*Runner); ok && r.config != nil {
+		return r.config
+	}
+	return DefaultConfig()
+}
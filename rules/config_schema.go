@@ -0,0 +1,102 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// configField describes one attribute of a rule or ruleset config struct
+type configField struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Default     string `json:"default"`
+	Description string `json:"description,omitempty"`
+}
+
+// configFields lists the hclext attributes of a config struct with their HCL types and default values.
+// config is expected to be a pointer to a struct populated with defaults.
+func configFields(config interface{}) []configField {
+	if config == nil {
+		return nil
+	}
+
+	val := reflect.Indirect(reflect.ValueOf(config))
+	ty := val.Type()
+	fields := []configField{}
+
+	for i := 0; i < ty.NumField(); i++ {
+		tag := strings.Split(ty.Field(i).Tag.Get("hclext"), ",")
+		// Nested blocks have no literal default, rules describe them with Metadata.ConfigExample instead
+		if tag[0] == "" || containsString(tag[1:], "block") {
+			continue
+		}
+		name := tag[0]
+
+		fields = append(fields, configField{
+			Name:        name,
+			Type:        hclType(ty.Field(i).Type),
+			Default:     hclValue(val.Field(i)),
+			Description: ty.Field(i).Tag.Get("doc"),
+		})
+	}
+
+	return fields
+}
+
+// hclType returns the Terraform type expression matching a Go field type
+func hclType(ty reflect.Type) string {
+	switch ty.Kind() {
+	case reflect.Ptr:
+		return hclType(ty.Elem())
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice:
+		return fmt.Sprintf("list(%s)", hclType(ty.Elem()))
+	case reflect.Map:
+		return fmt.Sprintf("map(%s)", hclType(ty.Elem()))
+	}
+	return "any"
+}
+
+// hclValue renders a Go value as an HCL literal
+func hclValue(val reflect.Value) string {
+	switch val.Kind() {
+	case reflect.Ptr:
+		if val.IsNil() {
+			return "null"
+		}
+		return hclValue(val.Elem())
+	case reflect.String:
+		return fmt.Sprintf("%q", val.String())
+	case reflect.Slice:
+		items := make([]string, val.Len())
+		for i := range items {
+			items[i] = hclValue(val.Index(i))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Map:
+		keys := make([]string, 0, val.Len())
+		for _, key := range val.MapKeys() {
+			keys = append(keys, key.String())
+		}
+		sort.Strings(keys)
+
+		items := make([]string, len(keys))
+		for i, key := range keys {
+			items[i] = fmt.Sprintf("%q = %s", key, hclValue(val.MapIndex(reflect.ValueOf(key))))
+		}
+		if len(items) == 0 {
+			return "{}"
+		}
+		return "{ " + strings.Join(items, ", ") + " }"
+	}
+	return fmt.Sprintf("%v", val.Interface())
+}
@@ -0,0 +1,39 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_configFields(t *testing.T) {
+	config := &struct {
+		Name     string            `hclext:"name,optional" doc:"The name."`
+		Limit    int               `hclext:"limit,optional"`
+		Strict   bool              `hclext:"strict,optional"`
+		Patterns []string          `hclext:"patterns,optional"`
+		Hints    map[string]string `hclext:"hints,optional"`
+		internal string
+	}{
+		Name:     "this",
+		Limit:    30,
+		Patterns: []string{"*_arn", "tags"},
+		Hints:    map[string]string{"b": "2", "a": "1"},
+	}
+
+	expected := []configField{
+		{Name: "name", Type: "string", Default: `"this"`, Description: "The name."},
+		{Name: "limit", Type: "number", Default: "30"},
+		{Name: "strict", Type: "bool", Default: "false"},
+		{Name: "patterns", Type: "list(string)", Default: `["*_arn", "tags"]`},
+		{Name: "hints", Type: "map(string)", Default: `{ "a" = "1", "b" = "2" }`},
+	}
+
+	if diff := cmp.Diff(expected, configFields(config)); diff != "" {
+		t.Fatalf("Unexpected fields:\n%s", diff)
+	}
+
+	if fields := configFields(nil); fields != nil {
+		t.Fatalf("Expected no fields for a nil config, got %#v", fields)
+	}
+}
@@ -0,0 +1,94 @@
+package rules
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_Config_excludesFile(t *testing.T) {
+	config := DefaultConfig()
+	config.ExcludeFiles = append(config.ExcludeFiles, "generated_*.tf", "vendor/*.tf")
+
+	cases := []struct {
+		Filename string
+		Expected bool
+	}{
+		{Filename: "main.tf", Expected: false},
+		{Filename: "override.tf", Expected: true},
+		{Filename: "main_override.tf", Expected: true},
+		{Filename: "main_override.tf.json", Expected: true},
+		{Filename: "generated_iam.tf", Expected: true},
+		{Filename: "modules/app/generated_iam.tf", Expected: true},
+		{Filename: "vendor/main.tf", Expected: true},
+		{Filename: "generated.tf", Expected: false},
+	}
+
+	for _, tc := range cases {
+		if got := config.excludesFile(tc.Filename); got != tc.Expected {
+			t.Errorf("excludesFile(%q) = %t, expected %t", tc.Filename, got, tc.Expected)
+		}
+	}
+}
+
+func Test_Config_Validate(t *testing.T) {
+	config := DefaultConfig()
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Defaults must be valid: %s", err)
+	}
+
+	config.ExcludeFiles = []string{"[generated"}
+	if err := config.Validate(); err == nil || err.Error() != `exclude_files pattern "[generated" is invalid: syntax error in pattern` {
+		t.Fatalf("Expected an invalid pattern error, got %v", err)
+	}
+}
+
+func Test_Config_Validate_policy(t *testing.T) {
+	config := DefaultConfig()
+	config.PolicyFile = "ftp://policy.example.com/terraform.yaml"
+	if err := config.Validate(); err == nil || err.Error() != `policy_file "ftp://policy.example.com/terraform.yaml" must be a local path or an https:// URL` {
+		t.Fatalf("Expected an unsupported scheme error, got %v", err)
+	}
+
+	config = DefaultConfig()
+	config.PolicyCacheTTL = "a day"
+	if err := config.Validate(); err == nil || err.Error() != `policy_cache_ttl "a day" is not a valid duration` {
+		t.Fatalf("Expected an invalid duration error, got %v", err)
+	}
+}
+
+func Test_Config_outOfScope(t *testing.T) {
+	repo := testRepository(t, "modules/a", "modules/b")
+	config := DefaultConfig()
+	config.ChangedFiles = []string{"modules/a/main.tf", "./modules/b/_variables.tf"}
+
+	cases := []struct {
+		Dir      string
+		Rule     tflint.Rule
+		Filename string
+		Expected bool
+	}{
+		{Dir: "modules/a", Rule: NewTerraformKb4FileStructureRule(), Filename: "main.tf", Expected: false},
+		{Dir: "modules/b", Rule: NewTerraformKb4FileStructureRule(), Filename: "main.tf", Expected: true},
+		{Dir: "modules/b", Rule: NewTerraformKb4FileStructureRule(), Filename: "_variables.tf", Expected: false},
+		{Dir: "modules/b", Rule: NewTerraformKb4FileStructureRule(), Filename: "_outputs.tf", Expected: true},
+		{Dir: ".", Rule: NewTerraformKb4FileStructureRule(), Filename: "modules/a/main.tf", Expected: false},
+		{Dir: ".", Rule: NewTerraformKb4FileStructureRule(), Filename: "main.tf", Expected: true},
+		{Dir: "modules/a", Rule: NewKb4LocalsNamingRule(), Filename: "locals.tf", Expected: true},
+		{Dir: "modules/a", Rule: NewKb4TodoCommentsRule(), Filename: "locals.tf", Expected: true},
+		{Dir: "modules/b", Rule: NewKb4IamAccessKeysRule(), Filename: "main.tf", Expected: false},
+		{Dir: "modules/b", Rule: NewKb4RulesetVersionRule(), Filename: ".tflint.hcl", Expected: false},
+	}
+
+	for _, tc := range cases {
+		chdir(t, filepath.Join(repo, tc.Dir))
+		if got := config.outOfScope(tc.Rule, tc.Filename); got != tc.Expected {
+			t.Errorf("outOfScope(%s, %q) in %s = %t, expected %t", tc.Rule.Name(), tc.Filename, tc.Dir, got, tc.Expected)
+		}
+	}
+
+	if DefaultConfig().outOfScope(NewTerraformKb4FileStructureRule(), "_outputs.tf") {
+		t.Error("Expected every file to be in scope without changed_files")
+	}
+}
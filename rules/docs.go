@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const docsHeader = "<!-- Generated by `go generate`. DO NOT EDIT. -->\n\n"
+
+// Documentation renders the docs/rules/<name>.md page for a rule
+func Documentation(rule tflint.Rule) string {
+	metadata := ruleMetadata(rule)
+
+	var b strings.Builder
+	b.WriteString(docsHeader)
+	fmt.Fprintf(&b, "# %s\n\n", rule.Name())
+	if metadata.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", metadata.Description)
+	}
+	if len(metadata.Aliases) > 0 {
+		fmt.Fprintf(&b, "Formerly named `%s`. Rule blocks using a former name still work, with a deprecation warning.\n\n", strings.Join(metadata.Aliases, "`, `"))
+	}
+
+	b.WriteString("|Severity|Enabled by default|Categories|\n| --- | --- | --- |\n")
+	fmt.Fprintf(&b, "|%s|%t|%s|\n\n", strings.ToUpper(rule.Severity().String()), rule.Enabled(), strings.Join(metadata.Categories, ", "))
+
+	if metadata.Example != "" {
+		fmt.Fprintf(&b, "## Example\n\n```hcl\n%s\n```\n\n", strings.Trim(metadata.Example, "\n"))
+	}
+
+	b.WriteString("## Configuration\n\n```hcl\n")
+	fields := configFields(metadata.Config)
+	if metadata.ConfigExample != "" {
+		fmt.Fprintf(&b, "%s\n", strings.Trim(metadata.ConfigExample, "\n"))
+	} else {
+		fmt.Fprintf(&b, "rule %q {\n  enabled = %t\n", rule.Name(), rule.Enabled())
+		for _, field := range fields {
+			fmt.Fprintf(&b, "  %s = %s\n", field.Name, field.Default)
+		}
+		b.WriteString("}\n")
+	}
+	b.WriteString("```\n\n")
+
+	if len(fields) > 0 {
+		b.WriteString("|Name|Type|Default|Description|\n| --- | --- | --- | --- |\n")
+		for _, field := range fields {
+			fmt.Fprintf(&b, "|%s|%s|`%s`|%s|\n", field.Name, field.Type, field.Default, field.Description)
+		}
+		b.WriteString("\n")
+	} else if metadata.ConfigExample == "" {
+		b.WriteString("This rule has no options.\n\n")
+	}
+
+	if link := rule.Link(); link != "" {
+		fmt.Fprintf(&b, "## Reference\n\n- %s\n", link)
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// DocumentationIndex renders the docs/rules/README.md table of every rule
+func DocumentationIndex(rules []tflint.Rule) string {
+	var b strings.Builder
+	b.WriteString(docsHeader)
+	b.WriteString("# Rules\n\n")
+	b.WriteString("Besides the options on its own page, every rule accepts these in its block:\n\n")
+	b.WriteString("|Name|Type|Description|\n| --- | --- | --- |\n")
+	for _, field := range configFields(&CommonRuleConfig{}) {
+		fmt.Fprintf(&b, "|%s|%s|%s|\n", field.Name, field.Type, field.Description)
+	}
+	b.WriteString("\n")
+	b.WriteString("|Name|Description|Severity|Enabled|Categories|\n| --- | --- | --- | --- | --- |\n")
+
+	for _, rule := range rules {
+		enabled := ""
+		if rule.Enabled() {
+			enabled = "✔"
+		}
+		metadata := ruleMetadata(rule)
+		fmt.Fprintf(&b, "|[%s](%s.md)|%s|%s|%s|%s|\n", rule.Name(), rule.Name(), metadata.Description, strings.ToUpper(rule.Severity().String()), enabled, strings.Join(metadata.Categories, ", "))
+	}
+
+	return b.String()
+}
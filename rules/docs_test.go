@@ -0,0 +1,39 @@
+package rules
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// Test_DocumentationInSync fails when docs/rules doesn't match the registered rules. Run `go generate` to fix it.
+func Test_DocumentationInSync(t *testing.T) {
+	dir := filepath.Join("..", "docs", "rules")
+	all := All()
+
+	expected := map[string]string{"README.md": DocumentationIndex(all)}
+	for _, rule := range all {
+		expected[rule.Name()+".md"] = Documentation(rule)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range paths {
+		if _, ok := expected[filepath.Base(path)]; !ok {
+			t.Errorf("%s documents an unregistered rule, run `go generate`", path)
+		}
+	}
+
+	for name, content := range expected {
+		actual, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("%s is missing, run `go generate`", name)
+			continue
+		}
+		if string(actual) != content {
+			t.Errorf("%s is out of date, run `go generate`", name)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// exemptionPattern matches an exemption annotation, e.g. `# kb4:exempt kb4_time_hacks waits for IAM propagation`.
+// The rule name is followed by the justification, which rules that accept exemptions require.
+var exemptionPattern = regexp.MustCompile(`^(?:#|//|/\*)\s*kb4:exempt\s+([a-z0-9_]+)(.*?)(?:\*/)?$`)
+
+// exemption is an annotation exempting the block or line below it, or the line it ends, from a rule
+type exemption struct {
+	Rule          string
+	Justification string
+	Range         hcl.Range
+}
+
+// exemptions are the annotations for one rule in the module, keyed by file and the line they apply to
+type exemptions struct {
+	byLine map[string]map[int]exemption
+}
+
+// loadExemptions collects the rule's exemption annotations from every native syntax file in the module
+func loadExemptions(runner tflint.Runner, rule tflint.Rule) (*exemptions, error) {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	e := &exemptions{byLine: map[string]map[int]exemption{}}
+	for filename, file := range files {
+		if _, ok := file.Body.(*hclsyntax.Body); !ok {
+			continue
+		}
+
+		tokens, _ := hclsyntax.LexConfig(file.Bytes, filename, hcl.InitialPos)
+		for i, token := range tokens {
+			if token.Type != hclsyntax.TokenComment {
+				continue
+			}
+
+			match := exemptionPattern.FindStringSubmatch(strings.TrimSpace(string(token.Bytes)))
+			if match == nil || (match[1] != rule.Name() && !containsString(ruleMetadata(rule).Aliases, match[1])) {
+				continue
+			}
+
+			if e.byLine[filename] == nil {
+				e.byLine[filename] = map[int]exemption{}
+			}
+			annotation := exemption{Rule: match[1], Justification: strings.TrimSpace(match[2]), Range: token.Range}
+
+			// A comment after code on the same line exempts that line, one on its own line exempts the line below.
+			// A line comment ends with its newline, so one directly above is the previous token.
+			line := token.Range.Start.Line + 1
+			if i > 0 {
+				prev := tokens[i-1]
+				lineComment := prev.Type == hclsyntax.TokenComment && strings.HasSuffix(string(prev.Bytes), "\n")
+				if prev.Type != hclsyntax.TokenNewline && !lineComment && prev.Range.End.Line == token.Range.Start.Line {
+					line = token.Range.Start.Line
+				}
+			}
+			e.byLine[filename][line] = annotation
+		}
+	}
+
+	return e, nil
+}
+
+// lookup returns the exemption for something starting at rng: one on the line above, or at the end of the same line
+func (e *exemptions) lookup(rng hcl.Range) (exemption, bool) {
+	annotation, ok := e.byLine[rng.Filename][rng.Start.Line]
+	return annotation, ok
+}
+
+// applies reports whether an annotation exempts something starting at any of the ranges, e.g. an attribute and its enclosing block.
+// An annotation without a justification doesn't exempt anything; unjustified reports that one was found.
+func (e *exemptions) applies(ranges ...hcl.Range) (exempt bool, unjustified bool) {
+	for _, rng := range ranges {
+		annotation, ok := e.lookup(rng)
+		if !ok {
+			continue
+		}
+		if annotation.Justification != "" {
+			return true, false
+		}
+		unjustified = true
+	}
+	return false, unjustified
+}
+
+// exemptionMessage appends a note to an issue message when an annotation tried to exempt it without a justification
+func exemptionMessage(message string, unjustified bool) string {
+	if unjustified {
+		return message + " (the kb4:exempt annotation needs a justification)"
+	}
+	return message
+}
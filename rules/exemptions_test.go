@@ -0,0 +1,66 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_exemptions(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{"main.tf": `
+# kb4:exempt kb4_time_hacks waits for IAM propagation
+resource "time_sleep" "iam" {
+  create_duration = "30s"
+}
+
+resource "null_resource" "this" {
+  triggers = {
+    always = timestamp() # kb4:exempt kb4_time_hacks forces a rerun on every apply
+    other  = timestamp()
+  }
+}
+
+// kb4:exempt kb4_time_hacks
+resource "time_sleep" "unjustified" {}
+
+# kb4:exempt kb4_other_rule not for this rule
+resource "time_sleep" "other" {}
+
+# Waits for the DNS records to propagate
+# kb4:exempt kb4_time_hacks waits for DNS propagation
+resource "time_sleep" "dns" {}
+`})
+
+	e, err := loadExemptions(runner, NewKb4TimeHacksRule())
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	cases := []struct {
+		Line          int
+		Found         bool
+		Justification string
+	}{
+		{Line: 3, Found: true, Justification: "waits for IAM propagation"},
+		{Line: 4},
+		{Line: 9, Found: true, Justification: "forces a rerun on every apply"},
+		{Line: 10},
+		{Line: 15, Found: true, Justification: ""},
+		{Line: 18},
+		{Line: 22, Found: true, Justification: "waits for DNS propagation"},
+		{Line: 21},
+	}
+
+	for _, tc := range cases {
+		got, ok := e.lookup(hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: tc.Line}})
+		if ok != tc.Found {
+			t.Errorf("line %d: expected found=%t, got %t", tc.Line, tc.Found, ok)
+			continue
+		}
+		if diff := cmp.Diff(tc.Justification, got.Justification); ok && diff != "" {
+			t.Errorf("line %d: unexpected justification: %s", tc.Line, diff)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// sameTerraformFile reports whether two file names denote the same Terraform file,
+// treating the JSON syntax variant `name.tf.json` as equivalent to `name.tf`
+func sameTerraformFile(a string, b string) bool {
+	return strings.TrimSuffix(a, ".json") == strings.TrimSuffix(b, ".json")
+}
+
+// hasTerraformFile reports whether the module has the named file in either syntax
+func hasTerraformFile(files map[string]*hcl.File, name string) bool {
+	for filename := range files {
+		if sameTerraformFile(filename, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package rules
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// lineReferencePattern matches the line numbers messages mention, e.g. "locals block on line 12"
+var lineReferencePattern = regexp.MustCompile(`(?i)\blines? [0-9]+(?:(?:-| to | and )[0-9]+)?\b`)
+
+// issueFingerprint identifies an issue independently of its position: the rule, the blockAddress of the block it is in
+// and its message, normalized. Labeled blocks are addressed the way Terraform addresses them, so moving a block,
+// even to another file, keeps its fingerprint. Anything else is addressed by its file and block type.
+func issueFingerprint(rule string, address string, message string) string {
+	sum := sha256.Sum256([]byte(rule + "\x00" + address + "\x00" + normalizeMessage(message)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// normalizeMessage drops what changes about a message when unrelated code moves: line numbers and runs of whitespace
+func normalizeMessage(message string) string {
+	return strings.Join(strings.Fields(lineReferencePattern.ReplaceAllString(message, "line N")), " ")
+}
+
+// blockAddress returns the address of the top-level block the range starts in, e.g. aws_s3_bucket.logs, data.aws_ami.this,
+// module.vpc or var.name. Unlabeled blocks like locals, ranges outside any block and JSON files are addressed by file, e.g. main.tf:locals.
+func blockAddress(files map[string]*hcl.File, issueRange hcl.Range) string {
+	filename := filepath.ToSlash(issueRange.Filename)
+
+	file, ok := files[issueRange.Filename]
+	if !ok {
+		return filename
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return filename
+	}
+
+	for _, block := range body.Blocks {
+		if !block.Range().ContainsOffset(issueRange.Start.Byte) {
+			continue
+		}
+
+		switch {
+		case block.Type == "resource" && len(block.Labels) == 2:
+			return block.Labels[0] + "." + block.Labels[1]
+		case block.Type == "data" && len(block.Labels) == 2:
+			return "data." + block.Labels[0] + "." + block.Labels[1]
+		case block.Type == "variable" && len(block.Labels) == 1:
+			return "var." + block.Labels[0]
+		case len(block.Labels) == 1:
+			return block.Type + "." + block.Labels[0]
+		}
+		return filename + ":" + block.Type
+	}
+
+	return filename
+}
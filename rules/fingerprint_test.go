@@ -0,0 +1,92 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+func Test_blockAddress(t *testing.T) {
+	parser := hclparse.NewParser()
+	native, _ := parser.ParseHCL([]byte(`
+resource "aws_s3_bucket" "logs" {
+  bucket = "kb4-logs"
+}
+data "aws_ami" "this" {}
+module "vpc" {}
+variable "name" {}
+output "id" {}
+locals {}
+`), "main.tf")
+	json, _ := parser.ParseJSON([]byte(`{"variable": {"name": {}}}`), "main.tf.json")
+	files := map[string]*hcl.File{"main.tf": native, "main.tf.json": json}
+
+	at := func(filename string, line int) hcl.Range {
+		file := files[filename]
+		offset := 0
+		for l := 1; l < line; l++ {
+			for file.Bytes[offset] != '\n' {
+				offset++
+			}
+			offset++
+		}
+		return hcl.Range{Filename: filename, Start: hcl.Pos{Line: line, Column: 1, Byte: offset}}
+	}
+
+	cases := []struct {
+		Range    hcl.Range
+		Expected string
+	}{
+		{at("main.tf", 3), "aws_s3_bucket.logs"},
+		{at("main.tf", 5), "data.aws_ami.this"},
+		{at("main.tf", 6), "module.vpc"},
+		{at("main.tf", 7), "var.name"},
+		{at("main.tf", 8), "output.id"},
+		{at("main.tf", 9), "main.tf:locals"},
+		{at("main.tf", 1), "main.tf"},
+		{at("main.tf.json", 1), "main.tf.json"},
+		{hcl.Range{Filename: "missing.tf"}, "missing.tf"},
+	}
+
+	for _, tc := range cases {
+		if got := blockAddress(files, tc.Range); got != tc.Expected {
+			t.Errorf("blockAddress(%s) = %q, expected %q", tc.Range, got, tc.Expected)
+		}
+	}
+}
+
+func Test_normalizeMessage(t *testing.T) {
+	cases := map[string]string{
+		"locals block on line 12 should be moved out of _init.tf": "locals block on line N should be moved out of _init.tf",
+		"Lines 3-9 look  like\na disabled block":                  "line N look like a disabled block",
+		"`tags` local is made of 61 expressions":                  "`tags` local is made of 61 expressions",
+	}
+
+	for message, expected := range cases {
+		if got := normalizeMessage(message); got != expected {
+			t.Errorf("normalizeMessage(%q) = %q, expected %q", message, got, expected)
+		}
+	}
+}
+
+func Test_Runner_EmitIssue_duplicates(t *testing.T) {
+	host := testRunner(t, map[string]string{"main.tf": `variable "name" {}`})
+	runner := NewRunner(host, nil)
+	rule := NewTerraformValidatedVariablesRule()
+
+	first := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1, Column: 1}, End: hcl.Pos{Line: 1, Column: 16, Byte: 15}}
+	second := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1, Column: 10, Byte: 9}, End: hcl.Pos{Line: 1, Column: 16, Byte: 15}}
+	for _, rng := range []hcl.Range{first, first, second} {
+		if err := runner.EmitIssue(rule, "duplicate", rng); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+	}
+
+	if len(host.Issues) != 2 {
+		t.Fatalf("Expected the repeated issue to be dropped, got %d issues", len(host.Issues))
+	}
+	if runner.stats.duplicates != 1 {
+		t.Fatalf("Expected 1 duplicate, got %d", runner.stats.duplicates)
+	}
+}
@@ -0,0 +1,94 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+// testRunner is helper.TestRunner with support for JSON syntax files.
+// Files ending in .json are parsed as JSON and added after the HCL files,
+// so variables declared in them are not known to EvaluateExpr.
+func testRunner(t *testing.T, files map[string]string) *helper.Runner {
+	hclFiles := map[string]string{}
+	jsonFiles := map[string]string{}
+
+	for name, src := range files {
+		if strings.HasSuffix(name, ".json") {
+			jsonFiles[name] = src
+		} else {
+			hclFiles[name] = src
+		}
+	}
+
+	runner := helper.TestRunner(t, hclFiles)
+	parser := hclparse.NewParser()
+
+	for name, src := range jsonFiles {
+		file, diags := parser.ParseJSON([]byte(src), name)
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		runner.AddLocalFile(name, file)
+	}
+
+	return runner
+}
+
+// assertIssues is helper.AssertIssues ignoring order.
+// The test runner visits files in map order, so issues from different files arrive in any order.
+func assertIssues(t *testing.T, expected helper.Issues, actual helper.Issues) {
+	sortIssues(expected)
+	sortIssues(actual)
+	helper.AssertIssues(t, expected, actual)
+}
+
+func sortIssues(issues helper.Issues) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.Range.Filename != b.Range.Filename {
+			return a.Range.Filename < b.Range.Filename
+		}
+		if a.Range.Start.Line != b.Range.Start.Line {
+			return a.Range.Start.Line < b.Range.Start.Line
+		}
+		return a.Message < b.Message
+	})
+}
+
+// hclRange returns a range at the start of a file
+func hclRange(filename string) hcl.Range {
+	return hcl.Range{Filename: filename, Start: hcl.InitialPos, End: hcl.InitialPos}
+}
+
+// testRepository creates a git repository with the given directories in a temporary directory and returns its path
+func testRepository(t *testing.T, dirs ...string) string {
+	repo, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range append([]string{".git"}, dirs...) {
+		if err := os.MkdirAll(filepath.Join(repo, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return repo
+}
+
+// chdir changes the working directory for the rest of the test
+func chdir(t *testing.T, dir string) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
@@ -0,0 +1,162 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kb4AwsProviderAssumeRoleRuleConfig is the rule's .tflint.hcl config
+type Kb4AwsProviderAssumeRoleRuleConfig struct {
+	RoleArnPattern string `hclext:"role_arn_pattern,optional" doc:"Regular expression literal role ARNs must match."`
+}
+
+func newKb4AwsProviderAssumeRoleRuleConfig() *Kb4AwsProviderAssumeRoleRuleConfig {
+	return &Kb4AwsProviderAssumeRoleRuleConfig{
+		RoleArnPattern: `^arn:aws:iam::[0-9]{12}:role/terraform-.+$`,
+	}
+}
+
+// Validate rejects a role ARN pattern that doesn't compile
+func (c *Kb4AwsProviderAssumeRoleRuleConfig) Validate() error {
+	if _, err := regexp.Compile(c.RoleArnPattern); err != nil {
+		return fmt.Errorf("role_arn_pattern is invalid: %s", err)
+	}
+	return nil
+}
+
+// Kb4AwsProviderAssumeRoleRule checks that root modules deploy through the deployment role
+type Kb4AwsProviderAssumeRoleRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4AwsProviderAssumeRoleRule())
+}
+
+// NewKb4AwsProviderAssumeRoleRule returns a new rule
+func NewKb4AwsProviderAssumeRoleRule() *Kb4AwsProviderAssumeRoleRule {
+	return &Kb4AwsProviderAssumeRoleRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4AwsProviderAssumeRoleRule) Name() string {
+	return "kb4_aws_provider_assume_role"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4AwsProviderAssumeRoleRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4AwsProviderAssumeRoleRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4AwsProviderAssumeRoleRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4AwsProviderAssumeRoleRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`aws` provider blocks in root modules must configure `assume_role` with a `role_arn`, so applies run as the deployment role rather than whoever holds the credentials. Literal role ARNs must match `role_arn_pattern`. Root modules are the ones configuring a backend, Terraform Cloud or a provider, so roots on local state are checked too.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "providers",
+		Example: `
+terraform {
+  backend "s3" {}
+}
+
+provider "aws" {
+  region = "us-east-1"
+}`,
+		Config: newKb4AwsProviderAssumeRoleRuleConfig(),
+	}
+}
+
+// Check emits issues for aws providers in root modules without a deployment role
+func (r *Kb4AwsProviderAssumeRoleRule) Check(runner tflint.Runner) error {
+	config := newKb4AwsProviderAssumeRoleRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	pattern := regexp.MustCompile(config.RoleArnPattern)
+
+	root, err := isRootModule(runner)
+	if err != nil || !root {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "provider",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "assume_role",
+							Body: &hclext.BodySchema{
+								Attributes: []hclext.AttributeSchema{{Name: "role_arn"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, provider := range content.Blocks {
+		if provider.Labels[0] != "aws" {
+			continue
+		}
+
+		if len(provider.Body.Blocks) == 0 {
+			if err := runner.EmitIssue(r, fmt.Sprintf("%s should configure assume_role with the deployment role", describeBlock(provider)), provider.DefRange); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, assumeRole := range provider.Body.Blocks {
+			roleArn, ok := assumeRole.Body.Attributes["role_arn"]
+			if !ok {
+				if err := runner.EmitIssue(r, fmt.Sprintf("assume_role of %s should set role_arn", describeBlock(provider)), assumeRole.DefRange); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// Only literal ARNs are checked; references are resolved per environment
+			value, diags := roleArn.Expr.Value(nil)
+			if diags.HasErrors() || value.Type() != cty.String || !value.IsKnown() || value.IsNull() {
+				continue
+			}
+			if pattern.MatchString(value.AsString()) {
+				continue
+			}
+
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s` is not a deployment role; role ARNs must match %s", value.AsString(), config.RoleArnPattern),
+				roleArn.Expr.Range(),
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
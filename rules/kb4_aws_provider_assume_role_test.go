@@ -0,0 +1,149 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4AwsProviderAssumeRoleRule(t *testing.T) {
+	backend := `
+terraform {
+  backend "s3" {}
+}
+`
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "child module",
+			Content: `
+provider "aws" {
+  alias = "replica"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "root module with local state",
+			Content: `
+provider "aws" {
+  region = "us-east-1"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4AwsProviderAssumeRoleRule(),
+					Message: "provider \"aws\" should configure assume_role with the deployment role",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 15},
+					},
+				},
+			},
+		},
+		{
+			Name: "deployment role",
+			Content: backend + `
+provider "aws" {
+  assume_role {
+    role_arn = "arn:aws:iam::123456789012:role/terraform-deploy"
+  }
+}
+
+provider "aws" {
+  alias = "usw2"
+
+  assume_role {
+    role_arn = var.deploy_role_arn
+  }
+}
+
+provider "cloudflare" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "missing assume_role",
+			Content: backend + `
+provider "aws" {
+  region = "us-east-1"
+}
+
+provider "aws" {
+  alias = "usw2"
+
+  assume_role {
+    session_name = "terraform"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4AwsProviderAssumeRoleRule(),
+					Message: "provider \"aws\" should configure assume_role with the deployment role",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 15},
+					},
+				},
+				{
+					Rule:    NewKb4AwsProviderAssumeRoleRule(),
+					Message: "assume_role of provider \"aws\" should set role_arn",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 13, Column: 3},
+						End:      hcl.Pos{Line: 13, Column: 14},
+					},
+				},
+			},
+		},
+		{
+			Name: "role outside the pattern",
+			Content: backend + `
+provider "aws" {
+  assume_role {
+    role_arn = "arn:aws:iam::123456789012:role/terraform-deploy"
+  }
+}`,
+			Config: `
+rule "kb4_aws_provider_assume_role" {
+  enabled          = true
+  role_arn_pattern = ":role/deployer$"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4AwsProviderAssumeRoleRule(),
+					Message: "`arn:aws:iam::123456789012:role/terraform-deploy` is not a deployment role; role ARNs must match :role/deployer$",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 8, Column: 16},
+						End:      hcl.Pos{Line: 8, Column: 65},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4AwsProviderAssumeRoleRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"_init.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
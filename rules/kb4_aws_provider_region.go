@@ -0,0 +1,124 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kb4AwsProviderRegionRuleConfig is the rule's .tflint.hcl config
+type Kb4AwsProviderRegionRuleConfig struct {
+	// ExemptAliases are the region-specific providers, whose alias already names the region they pin
+	ExemptAliases []string `hclext:"exempt_aliases,optional" doc:"Aliases of region-specific providers that may hard-code their region."`
+}
+
+func newKb4AwsProviderRegionRuleConfig() *Kb4AwsProviderRegionRuleConfig {
+	return &Kb4AwsProviderRegionRuleConfig{
+		ExemptAliases: regionAliases(),
+	}
+}
+
+// Kb4AwsProviderRegionRule checks that aws providers take their region from a variable or local
+type Kb4AwsProviderRegionRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4AwsProviderRegionRule())
+}
+
+// NewKb4AwsProviderRegionRule returns a new rule
+func NewKb4AwsProviderRegionRule() *Kb4AwsProviderRegionRule {
+	return &Kb4AwsProviderRegionRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4AwsProviderRegionRule) Name() string {
+	return "kb4_aws_provider_region"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4AwsProviderRegionRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4AwsProviderRegionRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4AwsProviderRegionRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4AwsProviderRegionRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`aws` provider blocks must not hard-code `region`; use `var.region` or the org-standard locals so a stack can be deployed to another region unchanged. Providers aliased in `exempt_aliases` pin a region on purpose and are skipped.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "providers",
+		Example: `
+provider "aws" {
+  region = "us-east-1"
+}`,
+		Config: newKb4AwsProviderRegionRuleConfig(),
+	}
+}
+
+// Check emits issues for literal regions in aws providers that aren't exempt
+func (r *Kb4AwsProviderRegionRule) Check(runner tflint.Runner) error {
+	config := newKb4AwsProviderRegionRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "provider",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "alias"}, {Name: "region"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, provider := range content.Blocks {
+		region, ok := provider.Body.Attributes["region"]
+		if provider.Labels[0] != "aws" || !ok {
+			continue
+		}
+
+		if alias, ok := provider.Body.Attributes["alias"]; ok {
+			value, diags := alias.Expr.Value(nil)
+			if !diags.HasErrors() && value.Type() == cty.String && value.IsKnown() && !value.IsNull() && containsString(config.ExemptAliases, value.AsString()) {
+				continue
+			}
+		}
+
+		// Anything that evaluates without a context is a literal
+		value, diags := region.Expr.Value(nil)
+		if diags.HasErrors() || value.Type() != cty.String || !value.IsKnown() || value.IsNull() {
+			continue
+		}
+
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s hard-codes region `%s`; use var.region or the org-standard locals", describeBlock(provider), value.AsString()),
+			region.Expr.Range(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4AwsProviderRegionRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "referenced regions",
+			Content: `
+provider "aws" {
+  region = var.region
+}
+
+provider "aws" {
+  alias  = "replica"
+  region = local.replica_region
+}
+
+provider "aws" {
+  alias  = "use1"
+  region = "us-east-1"
+}
+
+provider "google" {
+  region = "us-east1"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "literal regions",
+			Content: `
+provider "aws" {
+  region = "us-east-1"
+}
+
+provider "aws" {
+  alias  = "use1"
+  region = "us-east-1"
+}`,
+			Config: `
+rule "kb4_aws_provider_region" {
+  enabled        = true
+  exempt_aliases = []
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4AwsProviderRegionRule(),
+					Message: "provider \"aws\" hard-codes region `us-east-1`; use var.region or the org-standard locals",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 3, Column: 12},
+						End:      hcl.Pos{Line: 3, Column: 23},
+					},
+				},
+				{
+					Rule:    NewKb4AwsProviderRegionRule(),
+					Message: "provider \"aws\" hard-codes region `us-east-1`; use var.region or the org-standard locals",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 8, Column: 12},
+						End:      hcl.Pos{Line: 8, Column: 23},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4AwsProviderRegionRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"_init.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kb4CapacityStrategyRuleConfig is the rule's .tflint.hcl config
+type Kb4CapacityStrategyRuleConfig struct {
+	RequiredEnvironments []string `hclext:"required_environments,optional" doc:"Environments whose autoscaling groups and node groups must use mixed instances or spot capacity."`
+	EnvironmentVariable  string   `hclext:"environment_variable,optional" doc:"Variable the module's environment is read from."`
+}
+
+func newKb4CapacityStrategyRuleConfig() *Kb4CapacityStrategyRuleConfig {
+	return &Kb4CapacityStrategyRuleConfig{
+		RequiredEnvironments: []string{"dev", "staging"},
+		EnvironmentVariable:  "environment",
+	}
+}
+
+// Validate rejects an empty variable name
+func (c *Kb4CapacityStrategyRuleConfig) Validate() error {
+	if c.EnvironmentVariable == "" {
+		return fmt.Errorf("environment_variable must not be empty")
+	}
+	return nil
+}
+
+// Kb4CapacityStrategyRule checks that autoscaling groups and node groups use cheaper capacity where the environment requires it
+type Kb4CapacityStrategyRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4CapacityStrategyRule())
+}
+
+// NewKb4CapacityStrategyRule returns a new rule
+func NewKb4CapacityStrategyRule() *Kb4CapacityStrategyRule {
+	return &Kb4CapacityStrategyRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4CapacityStrategyRule) Name() string {
+	return "kb4_capacity_strategy"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4CapacityStrategyRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4CapacityStrategyRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4CapacityStrategyRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4CapacityStrategyRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "In the `required_environments`, `aws_autoscaling_group` resources must declare a `mixed_instances_policy`, and `aws_eks_node_group` resources must set `capacity_type = \"SPOT\"` or list more than one instance type. The environment is the value of `var.environment`, so modules whose environment isn't known are skipped.",
+		Categories:  []string{CategoryCost},
+		Anchor:      "compute",
+		Example: `
+variable "environment" {
+  default = "dev"
+}
+
+resource "aws_autoscaling_group" "workers" {
+  launch_template {
+    id = aws_launch_template.workers.id
+  }
+}`,
+		Config: newKb4CapacityStrategyRuleConfig(),
+	}
+}
+
+// Check emits an issue for every autoscaling group and node group on plain on-demand capacity in an environment requiring otherwise
+func (r *Kb4CapacityStrategyRule) Check(runner tflint.Runner) error {
+	config := newKb4CapacityStrategyRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	environment, err := moduleEnvironment(runner, config.EnvironmentVariable)
+	if err != nil {
+		return err
+	}
+	if environment == "" || !containsString(config.RequiredEnvironments, environment) {
+		return nil
+	}
+
+	groups, err := runner.GetResourceContent("aws_autoscaling_group", &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{{Type: "mixed_instances_policy"}},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups.Blocks {
+		if len(group.Body.Blocks) > 0 {
+			continue
+		}
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s should declare a mixed_instances_policy in %s", describeBlock(group), environment),
+			group.DefRange,
+		); err != nil {
+			return err
+		}
+	}
+
+	nodeGroups, err := runner.GetResourceContent("aws_eks_node_group", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "capacity_type"}, {Name: "instance_types"}},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, nodeGroup := range nodeGroups.Blocks {
+		mixed, err := r.mixedCapacity(runner, nodeGroup)
+		if err != nil {
+			return err
+		}
+		if mixed {
+			continue
+		}
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s should set capacity_type = \"SPOT\" or list several instance_types in %s", describeBlock(nodeGroup), environment),
+			nodeGroup.DefRange,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mixedCapacity reports whether a node group runs on spot capacity or several instance types.
+// Values that aren't known yet count as mixed, so only groups that are certainly on-demand are reported.
+func (r *Kb4CapacityStrategyRule) mixedCapacity(runner tflint.Runner, nodeGroup *hclext.Block) (bool, error) {
+	mixed := false
+
+	if attr, ok := nodeGroup.Body.Attributes["capacity_type"]; ok {
+		var value cty.Value
+		err := runner.EvaluateExpr(attr.Expr, &value, nil)
+		err = runner.EnsureNoError(err, func() error {
+			mixed = !value.IsKnown() || value.Type() == cty.String && !value.IsNull() && value.AsString() == "SPOT"
+			return nil
+		})
+		if err != nil || mixed {
+			return mixed, err
+		}
+	}
+
+	if attr, ok := nodeGroup.Body.Attributes["instance_types"]; ok {
+		var value cty.Value
+		err := runner.EvaluateExpr(attr.Expr, &value, nil)
+		err = runner.EnsureNoError(err, func() error {
+			mixed = !value.IsKnown() || !value.IsNull() && value.CanIterateElements() && value.LengthInt() > 1
+			return nil
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return mixed, nil
+}
+
+// moduleEnvironment returns the value of the environment variable where the module references it,
+// or "" when the module doesn't reference it or its value isn't known.
+// Expressions are evaluated by the host from their source, so an existing reference is evaluated rather than a synthesized one.
+func moduleEnvironment(runner tflint.Runner, variable string) (string, error) {
+	traversals, err := references(runner, "var")
+	if err != nil {
+		return "", err
+	}
+
+	for _, traversal := range traversals {
+		if traversalAttr(traversal) != variable {
+			continue
+		}
+
+		environment := ""
+		expr := &hclsyntax.ScopeTraversalExpr{Traversal: traversal[:2], SrcRange: traversal[:2].SourceRange()}
+		err := evaluateString(runner, expr, func(value string) error {
+			environment = value
+			return nil
+		})
+		return environment, err
+	}
+
+	return "", nil
+}
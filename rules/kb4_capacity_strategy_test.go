@@ -0,0 +1,145 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4CapacityStrategyRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "production",
+			Content: `
+variable "environment" {
+  default = "prod"
+}
+
+resource "aws_autoscaling_group" "workers" {
+  name = "workers-${var.environment}"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "unknown environment",
+			Content: `
+variable "environment" {}
+
+resource "aws_autoscaling_group" "workers" {
+  name = "workers-${var.environment}"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "mixed capacity",
+			Content: `
+variable "environment" {
+  default = "dev"
+}
+
+resource "aws_autoscaling_group" "workers" {
+  name = "workers-${var.environment}"
+
+  mixed_instances_policy {}
+}
+
+resource "aws_eks_node_group" "spot" {
+  capacity_type = "SPOT"
+}
+
+resource "aws_eks_node_group" "mixed" {
+  instance_types = ["m6i.large", "m6a.large"]
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "on-demand capacity",
+			Content: `
+variable "environment" {
+  default = "dev"
+}
+
+resource "aws_autoscaling_group" "workers" {
+  name = "workers-${var.environment}"
+}
+
+resource "aws_eks_node_group" "workers" {
+  capacity_type  = "ON_DEMAND"
+  instance_types = ["m6i.large"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4CapacityStrategyRule(),
+					Message: "resource \"aws_autoscaling_group\" \"workers\" should declare a mixed_instances_policy in dev",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 43},
+					},
+				},
+				{
+					Rule:    NewKb4CapacityStrategyRule(),
+					Message: "resource \"aws_eks_node_group\" \"workers\" should set capacity_type = \"SPOT\" or list several instance_types in dev",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 10, Column: 1},
+						End:      hcl.Pos{Line: 10, Column: 40},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured environments",
+			Content: `
+variable "stage" {
+  default = "prod"
+}
+
+resource "aws_autoscaling_group" "workers" {
+  name = "workers-${var.stage}"
+}`,
+			Config: `
+rule "kb4_capacity_strategy" {
+  enabled               = true
+  required_environments = ["prod"]
+  environment_variable  = "stage"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4CapacityStrategyRule(),
+					Message: "resource \"aws_autoscaling_group\" \"workers\" should declare a mixed_instances_policy in prod",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 43},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4CapacityStrategyRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// cloudfrontProtocolVersions are CloudFront's viewer security policies, oldest first
+var cloudfrontProtocolVersions = []string{
+	"SSLv3",
+	"TLSv1",
+	"TLSv1_2016",
+	"TLSv1.1_2016",
+	"TLSv1.2_2018",
+	"TLSv1.2_2019",
+	"TLSv1.2_2021",
+	"TLSv1.2_2025",
+	"TLSv1.3_2025",
+}
+
+// cloudfrontProtocolRank returns the position of a security policy in cloudfrontProtocolVersions, or -1 for ones it doesn't know
+func cloudfrontProtocolRank(version string) int {
+	for i, v := range cloudfrontProtocolVersions {
+		if v == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// Kb4CloudfrontTLSRuleConfig is the rule's .tflint.hcl config
+type Kb4CloudfrontTLSRuleConfig struct {
+	MinimumProtocolVersion string `hclext:"minimum_protocol_version,optional" doc:"Oldest security policy viewer certificates may use."`
+}
+
+func newKb4CloudfrontTLSRuleConfig() *Kb4CloudfrontTLSRuleConfig {
+	return &Kb4CloudfrontTLSRuleConfig{MinimumProtocolVersion: "TLSv1.2_2021"}
+}
+
+// Validate rejects security policies CloudFront doesn't offer
+func (c *Kb4CloudfrontTLSRuleConfig) Validate() error {
+	if cloudfrontProtocolRank(c.MinimumProtocolVersion) < 0 {
+		return fmt.Errorf("minimum_protocol_version must be one of %s", strings.Join(cloudfrontProtocolVersions, ", "))
+	}
+	return nil
+}
+
+// Kb4CloudfrontTLSRule checks that CloudFront distributions don't accept outdated TLS versions
+type Kb4CloudfrontTLSRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4CloudfrontTLSRule())
+}
+
+// NewKb4CloudfrontTLSRule returns a new rule
+func NewKb4CloudfrontTLSRule() *Kb4CloudfrontTLSRule {
+	return &Kb4CloudfrontTLSRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4CloudfrontTLSRule) Name() string {
+	return "kb4_cloudfront_tls"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4CloudfrontTLSRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4CloudfrontTLSRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4CloudfrontTLSRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4CloudfrontTLSRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "The `viewer_certificate` of `aws_cloudfront_distribution` resources must set `minimum_protocol_version` to the configured floor, `TLSv1.2_2021` by default, or newer. Distributions on the CloudFront default certificate can't choose a version and are skipped.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "encryption",
+		Example: `
+resource "aws_cloudfront_distribution" "assets" {
+  viewer_certificate {
+    acm_certificate_arn      = var.certificate_arn
+    ssl_support_method       = "sni-only"
+    minimum_protocol_version = "TLSv1"
+  }
+}`,
+		Config: newKb4CloudfrontTLSRuleConfig(),
+	}
+}
+
+// Check emits an issue for every viewer certificate allowing TLS versions older than the configured floor
+func (r *Kb4CloudfrontTLSRule) Check(runner tflint.Runner) error {
+	config := newKb4CloudfrontTLSRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+	floor := cloudfrontProtocolRank(config.MinimumProtocolVersion)
+
+	content, err := runner.GetResourceContent("aws_cloudfront_distribution", &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "viewer_certificate",
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "cloudfront_default_certificate"}, {Name: "minimum_protocol_version"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, distribution := range content.Blocks {
+		for _, certificate := range distribution.Body.Blocks {
+			attr, ok := certificate.Body.Attributes["minimum_protocol_version"]
+			if !ok {
+				defaultCertificate := false
+				if attr, ok := certificate.Body.Attributes["cloudfront_default_certificate"]; ok {
+					err := evaluateBool(runner, attr.Expr, func(value bool) error {
+						defaultCertificate = value
+						return nil
+					})
+					if err != nil {
+						return err
+					}
+				}
+				if defaultCertificate {
+					continue
+				}
+
+				if err := runner.EmitIssue(
+					r,
+					fmt.Sprintf("viewer_certificate of %s should set minimum_protocol_version to %s or newer", describeBlock(distribution), config.MinimumProtocolVersion),
+					certificate.DefRange,
+				); err != nil {
+					return err
+				}
+				continue
+			}
+
+			err := evaluateString(runner, attr.Expr, func(value string) error {
+				rank := cloudfrontProtocolRank(value)
+				if rank < 0 || rank >= floor {
+					return nil
+				}
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("%s is older than the minimum protocol version of %s", value, config.MinimumProtocolVersion),
+					attr.Expr.Range(),
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4CloudfrontTLSRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "current policies",
+			Content: `
+resource "aws_cloudfront_distribution" "assets" {
+  viewer_certificate {
+    minimum_protocol_version = "TLSv1.2_2021"
+  }
+}
+
+resource "aws_cloudfront_distribution" "preview" {
+  viewer_certificate {
+    cloudfront_default_certificate = true
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "outdated and unset",
+			Content: `
+resource "aws_cloudfront_distribution" "assets" {
+  viewer_certificate {
+    minimum_protocol_version = "TLSv1.2_2019"
+  }
+}
+
+resource "aws_cloudfront_distribution" "docs" {
+  viewer_certificate {
+    ssl_support_method = "sni-only"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4CloudfrontTLSRule(),
+					Message: "TLSv1.2_2019 is older than the minimum protocol version of TLSv1.2_2021",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 32},
+						End:      hcl.Pos{Line: 4, Column: 46},
+					},
+				},
+				{
+					Rule:    NewKb4CloudfrontTLSRule(),
+					Message: "viewer_certificate of resource \"aws_cloudfront_distribution\" \"docs\" should set minimum_protocol_version to TLSv1.2_2021 or newer",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 3},
+						End:      hcl.Pos{Line: 9, Column: 21},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured floor",
+			Content: `
+resource "aws_cloudfront_distribution" "assets" {
+  viewer_certificate {
+    minimum_protocol_version = "TLSv1.2_2019"
+  }
+}`,
+			Config: `
+rule "kb4_cloudfront_tls" {
+  enabled                  = true
+  minimum_protocol_version = "TLSv1.2_2018"
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewKb4CloudfrontTLSRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
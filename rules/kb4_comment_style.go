@@ -0,0 +1,119 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4CommentStyleRuleConfig is the rule's .tflint.hcl config
+type Kb4CommentStyleRuleConfig struct {
+	Style string `hclext:"style,optional" doc:"Marker every line comment must use, \"#\" or \"//\"."`
+}
+
+func newKb4CommentStyleRuleConfig() *Kb4CommentStyleRuleConfig {
+	return &Kb4CommentStyleRuleConfig{
+		Style: "#",
+	}
+}
+
+// Validate rejects anything but the two line comment markers
+func (c *Kb4CommentStyleRuleConfig) Validate() error {
+	if c.Style != "#" && c.Style != "//" {
+		return fmt.Errorf("style must be \"#\" or \"//\", not %q", c.Style)
+	}
+	return nil
+}
+
+// Kb4CommentStyleRule checks that line comments use a single marker
+type Kb4CommentStyleRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4CommentStyleRule())
+}
+
+// NewKb4CommentStyleRule returns a new rule
+func NewKb4CommentStyleRule() *Kb4CommentStyleRule {
+	return &Kb4CommentStyleRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4CommentStyleRule) Name() string {
+	return "kb4_comment_style"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4CommentStyleRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4CommentStyleRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4CommentStyleRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4CommentStyleRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Line comments must all use one marker, `#` by default, rather than mixing `#` and `//`. Consecutive comment lines are reported once. Block comments are left alone.",
+		Categories:  []string{CategoryStyle},
+		Anchor:      "comments",
+		Example: `
+// Shared by every account
+// in the organization
+resource "aws_s3_bucket" "logs" {}`,
+		Config: newKb4CommentStyleRuleConfig(),
+	}
+}
+
+// Check emits an issue on every run of line comments using the other marker
+func (r *Kb4CommentStyleRule) Check(runner tflint.Runner) error {
+	config := newKb4CommentStyleRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+
+	issues := []commentRun{}
+	for filename, file := range files {
+		if _, ok := file.Body.(*hclsyntax.Body); !ok {
+			continue
+		}
+
+		for _, run := range commentRuns(file.Bytes, filename) {
+			if run.Style != "/*" && run.Style != config.Style {
+				issues = append(issues, run)
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return rangeLess(issues[i].Range, issues[j].Range)
+	})
+
+	for _, run := range issues {
+		message := fmt.Sprintf("Comment should start with `%s` rather than `%s`", config.Style, run.Style)
+		if len(run.Lines) > 1 {
+			message = fmt.Sprintf("%d comment lines should start with `%s` rather than `%s`", len(run.Lines), config.Style, run.Style)
+		}
+		if err := runner.EmitIssue(r, message, run.Range); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4CommentStyleRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "hash comments",
+			Content: `
+# Shared by every account
+# in the organization
+resource "aws_s3_bucket" "logs" {
+  bucket = "kb4-logs" # see SRE-123
+}
+
+/* Block comments
+   are left alone */`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "slash comments",
+			Content: `
+// Shared by every account
+// in the organization
+resource "aws_s3_bucket" "logs" {
+  bucket = "kb4-logs" // see SRE-123
+  // Versioned for the
+  # compliance audit
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4CommentStyleRule(),
+					Message: "2 comment lines should start with `#` rather than `//`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 23},
+					},
+				},
+				{
+					Rule:    NewKb4CommentStyleRule(),
+					Message: "Comment should start with `#` rather than `//`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 23},
+						End:      hcl.Pos{Line: 5, Column: 37},
+					},
+				},
+				{
+					Rule:    NewKb4CommentStyleRule(),
+					Message: "Comment should start with `#` rather than `//`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 3},
+						End:      hcl.Pos{Line: 6, Column: 23},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured style",
+			Content: `
+// Shared by every account
+# in the organization
+resource "aws_s3_bucket" "logs" {}`,
+			Config: `
+rule "kb4_comment_style" {
+  enabled = true
+  style   = "//"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4CommentStyleRule(),
+					Message: "Comment should start with `//` rather than `#`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 22},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4CommentStyleRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// blockHeaderPattern matches the opening line of a top-level Terraform block, e.g. `resource "aws_s3_bucket" "logs" {`
+var blockHeaderPattern = regexp.MustCompile(`^\s*((?:resource|data|module|variable|output|locals|provider|terraform|moved|import)\b[^=#{]*?)\s*\{\s*$`)
+
+// Kb4CommentedCodeRuleConfig is the rule's .tflint.hcl config
+type Kb4CommentedCodeRuleConfig struct {
+	MinLines int `hclext:"min_lines,optional" doc:"Lines a comment opening with a block header must span to be reported as a disabled block."`
+}
+
+func newKb4CommentedCodeRuleConfig() *Kb4CommentedCodeRuleConfig {
+	return &Kb4CommentedCodeRuleConfig{
+		MinLines: 3,
+	}
+}
+
+// Validate rejects a non-positive line count
+func (c *Kb4CommentedCodeRuleConfig) Validate() error {
+	if c.MinLines < 1 {
+		return fmt.Errorf("min_lines must be at least 1")
+	}
+	return nil
+}
+
+// Kb4CommentedCodeRule checks that disabled blocks are deleted rather than commented out
+type Kb4CommentedCodeRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4CommentedCodeRule())
+}
+
+// NewKb4CommentedCodeRule returns a new rule
+func NewKb4CommentedCodeRule() *Kb4CommentedCodeRule {
+	return &Kb4CommentedCodeRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4CommentedCodeRule) Name() string {
+	return "kb4_commented_code"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4CommentedCodeRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4CommentedCodeRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4CommentedCodeRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4CommentedCodeRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Blocks that are no longer needed must be deleted, not commented out. Disabled code reads like live configuration to whoever is paging through a module during an incident, and version control keeps it anyway. A comment is reported when one of its lines is a block header such as `resource \"aws_s3_bucket\" \"logs\" {` and it spans at least `min_lines` lines.",
+		Categories:  []string{CategoryStyle},
+		Anchor:      "comments",
+		Example: `
+# resource "aws_s3_bucket" "logs" {
+#   bucket = "kb4-logs"
+# }`,
+		Config: newKb4CommentedCodeRuleConfig(),
+	}
+}
+
+// Check emits an issue on every comment run that looks like a disabled block
+func (r *Kb4CommentedCodeRule) Check(runner tflint.Runner) error {
+	config := newKb4CommentedCodeRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+
+	type issue struct {
+		header string
+		rng    hcl.Range
+	}
+	issues := []issue{}
+
+	for filename, file := range files {
+		if _, ok := file.Body.(*hclsyntax.Body); !ok {
+			continue
+		}
+
+		for _, run := range commentRuns(file.Bytes, filename) {
+			if len(run.Lines) < config.MinLines {
+				continue
+			}
+			for _, line := range run.Lines {
+				if match := blockHeaderPattern.FindStringSubmatch(line); match != nil {
+					issues = append(issues, issue{match[1], run.Range})
+					break
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return rangeLess(issues[i].rng, issues[j].rng)
+	})
+
+	for _, i := range issues {
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("Comment looks like a disabled `%s` block; delete it and rely on version control history", strings.Join(strings.Fields(i.header), " ")),
+			i.rng,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
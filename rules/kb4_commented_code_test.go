@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4CommentedCodeRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "prose comments",
+			Content: `
+# The logs bucket is shared by every account in the organization, so its
+# resource "aws_s3_bucket_policy" is managed by the security team.
+resource "aws_s3_bucket" "logs" {
+  bucket = "kb4-logs" # resource "aws_s3_bucket" "old" {
+}
+
+# locals {
+#   name = "short"
+# }`,
+			Config: `
+rule "kb4_commented_code" {
+  enabled   = true
+  min_lines = 4
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "disabled blocks",
+			Content: `
+# resource "aws_s3_bucket" "legacy" {
+#   bucket = "kb4-legacy"
+# }
+resource "aws_s3_bucket" "logs" {
+  bucket = "kb4-logs"
+}
+
+// Kept until the cutover
+// module "vpc" {
+//   source = "./modules/vpc"
+// }
+
+/*
+data "aws_iam_policy_document" "assets" {
+  statement {}
+}
+*/
+
+# output "id" {
+// value = aws_s3_bucket.logs.id
+# }`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4CommentedCodeRule(),
+					Message: "Comment looks like a disabled `resource \"aws_s3_bucket\" \"legacy\"` block; delete it and rely on version control history",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 4, Column: 4},
+					},
+				},
+				{
+					Rule:    NewKb4CommentedCodeRule(),
+					Message: "Comment looks like a disabled `module \"vpc\"` block; delete it and rely on version control history",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 1},
+						End:      hcl.Pos{Line: 12, Column: 5},
+					},
+				},
+				{
+					Rule:    NewKb4CommentedCodeRule(),
+					Message: "Comment looks like a disabled `data \"aws_iam_policy_document\" \"assets\"` block; delete it and rely on version control history",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 14, Column: 1},
+						End:      hcl.Pos{Line: 18, Column: 3},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4CommentedCodeRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4ConditionalComplexityRuleConfig is the rule's .tflint.hcl config
+type Kb4ConditionalComplexityRuleConfig struct {
+	MaxLength int `hclext:"max_length,optional" doc:"Longest conditional expression allowed, in characters of source."`
+}
+
+func newKb4ConditionalComplexityRuleConfig() *Kb4ConditionalComplexityRuleConfig {
+	return &Kb4ConditionalComplexityRuleConfig{MaxLength: 100}
+}
+
+// Validate rejects a limit below one
+func (c *Kb4ConditionalComplexityRuleConfig) Validate() error {
+	if c.MaxLength < 1 {
+		return fmt.Errorf("max_length must be at least 1")
+	}
+	return nil
+}
+
+// Kb4ConditionalComplexityRule checks that conditional expressions stay readable
+type Kb4ConditionalComplexityRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4ConditionalComplexityRule())
+}
+
+// NewKb4ConditionalComplexityRule returns a new rule
+func NewKb4ConditionalComplexityRule() *Kb4ConditionalComplexityRule {
+	return &Kb4ConditionalComplexityRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4ConditionalComplexityRule) Name() string {
+	return "kb4_conditional_complexity"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4ConditionalComplexityRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4ConditionalComplexityRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4ConditionalComplexityRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4ConditionalComplexityRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Conditional expressions must not nest, e.g. `a ? b : c ? d : e`, and must not be longer than `max_length` characters. Name the pieces in locals, or pick the value from a lookup map keyed by the condition.",
+		Categories:  []string{CategoryStyle},
+		Anchor:      "locals",
+		Example: `
+locals {
+  instance_type = var.environment == "prod" ? "m6i.large" : var.environment == "staging" ? "t3.medium" : "t3.small"
+}`,
+		Config: newKb4ConditionalComplexityRuleConfig(),
+	}
+}
+
+// Check emits an issue for the outermost conditional of every nest, and for every other conditional that is too long
+func (r *Kb4ConditionalComplexityRule) Check(runner tflint.Runner) error {
+	config := newKb4ConditionalComplexityRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+
+	type issue struct {
+		message string
+		rng     hcl.Range
+	}
+	issues := []issue{}
+
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		// Conditionals inside one already reported on are part of its issue
+		inner := map[*hclsyntax.ConditionalExpr]bool{}
+		hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+			cond, ok := node.(*hclsyntax.ConditionalExpr)
+			if !ok || inner[cond] {
+				return nil
+			}
+
+			nested := false
+			for _, part := range []hclsyntax.Expression{cond.Condition, cond.TrueResult, cond.FalseResult} {
+				hclsyntax.VisitAll(part, func(node hclsyntax.Node) hcl.Diagnostics {
+					if child, ok := node.(*hclsyntax.ConditionalExpr); ok {
+						inner[child] = true
+						nested = true
+					}
+					return nil
+				})
+			}
+
+			rng := cond.Range()
+			length := rng.End.Byte - rng.Start.Byte
+			switch {
+			case nested:
+				issues = append(issues, issue{"Conditional expressions should not be nested; use locals or a lookup map instead", rng})
+			case length > config.MaxLength:
+				issues = append(issues, issue{fmt.Sprintf("Conditional expression is %d characters long, more than the %d allowed; use locals or a lookup map instead", length, config.MaxLength), rng})
+			}
+			return nil
+		})
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return rangeLess(issues[i].rng, issues[j].rng)
+	})
+
+	for _, i := range issues {
+		if err := runner.EmitIssue(r, i.message, i.rng); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
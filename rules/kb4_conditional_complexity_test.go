@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4ConditionalComplexityRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "simple conditionals",
+			Content: `
+locals {
+  instance_type = var.environment == "prod" ? "m6i.large" : "t3.small"
+  sizes = {
+    prod    = "m6i.large"
+    staging = "t3.medium"
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "nested and long conditionals",
+			Content: `
+locals {
+  instance_type = var.environment == "prod" ? "m6i.large" : var.environment == "staging" ? "t3.medium" : "t3.small"
+  subnet_ids    = var.private ? (var.multi_az ? var.private_subnet_ids : [var.private_subnet_ids[0]]) : var.public_subnet_ids
+  retention     = var.environment == "prod" && var.compliance_mode && !var.ephemeral ? var.long_retention_days : var.short_retention_days
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ConditionalComplexityRule(),
+					Message: "Conditional expressions should not be nested; use locals or a lookup map instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 19},
+						End:      hcl.Pos{Line: 3, Column: 116},
+					},
+				},
+				{
+					Rule:    NewKb4ConditionalComplexityRule(),
+					Message: "Conditional expressions should not be nested; use locals or a lookup map instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 19},
+						End:      hcl.Pos{Line: 4, Column: 126},
+					},
+				},
+				{
+					Rule:    NewKb4ConditionalComplexityRule(),
+					Message: "Conditional expression is 119 characters long, more than the 100 allowed; use locals or a lookup map instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 19},
+						End:      hcl.Pos{Line: 5, Column: 138},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured length",
+			Content: `
+resource "aws_instance" "web" {
+  instance_type = var.environment == "prod" ? "m6i.large" : "t3.small"
+}`,
+			Config: `
+rule "kb4_conditional_complexity" {
+  enabled    = true
+  max_length = 40
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ConditionalComplexityRule(),
+					Message: "Conditional expression is 52 characters long, more than the 40 allowed; use locals or a lookup map instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 19},
+						End:      hcl.Pos{Line: 3, Column: 71},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4ConditionalComplexityRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// customCheckBlockLabels are the block types a custom check can target, with their labels
+var customCheckBlockLabels = map[string][]string{
+	"resource": {"type", "name"},
+	"data":     {"type", "name"},
+	"module":   {"name"},
+	"provider": {"name"},
+	"variable": {"name"},
+	"output":   {"name"},
+}
+
+// Kb4CustomCheckRuleConfig is the rule's .tflint.hcl config, a list of check blocks
+type Kb4CustomCheckRuleConfig struct {
+	Checks []Kb4CustomCheck `hclext:"check,block"`
+}
+
+// Kb4CustomCheck is a single check declared in .tflint.hcl.
+// Condition is an HCL expression with the attribute's value bound to `value`, null when the attribute is unset.
+// Every targeted block the condition is false for is reported with Message.
+type Kb4CustomCheck struct {
+	// Name is the check block's label, used in errors and the debug log
+	Name string `hclext:"name,label"`
+	// Block is the targeted block type, one of the keys of customCheckBlockLabels
+	Block string `hclext:"block"`
+	// Type narrows resource and data blocks down to one type, e.g. aws_s3_bucket
+	Type string `hclext:"type,optional"`
+	// Attribute is the attribute bound to `value` in Condition
+	Attribute string `hclext:"attribute"`
+	// Condition is the HCL expression that must be true
+	Condition string `hclext:"condition"`
+	// Message is reported for every block Condition is false for
+	Message string `hclext:"message"`
+
+	condition hcl.Expression
+}
+
+// Validate rejects checks on unsupported blocks and conditions that don't parse
+func (c *Kb4CustomCheckRuleConfig) Validate() error {
+	for i := range c.Checks {
+		check := &c.Checks[i]
+
+		labels, ok := customCheckBlockLabels[check.Block]
+		if !ok {
+			blocks := make([]string, 0, len(customCheckBlockLabels))
+			for block := range customCheckBlockLabels {
+				blocks = append(blocks, block)
+			}
+			sort.Strings(blocks)
+			return fmt.Errorf("check %q: block %q must be one of %s", check.Name, check.Block, strings.Join(blocks, ", "))
+		}
+		if check.Type != "" && len(labels) != 2 {
+			return fmt.Errorf("check %q: type only applies to resource and data blocks", check.Name)
+		}
+
+		expr, diags := hclsyntax.ParseExpression([]byte(check.Condition), fmt.Sprintf("check %q condition", check.Name), hcl.InitialPos)
+		if diags.HasErrors() {
+			return fmt.Errorf("check %q: %s", check.Name, diags.Error())
+		}
+		check.condition = expr
+	}
+	return nil
+}
+
+// Kb4CustomCheckRule runs the checks declared in its rule block, so teams can add one-off org checks without writing Go
+type Kb4CustomCheckRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4CustomCheckRule())
+}
+
+// NewKb4CustomCheckRule returns a new rule
+func NewKb4CustomCheckRule() *Kb4CustomCheckRule {
+	return &Kb4CustomCheckRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4CustomCheckRule) Name() string {
+	return "kb4_custom_check"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4CustomCheckRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4CustomCheckRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4CustomCheckRule) Link() string {
+	return "https://github.com/kb4sre/tflint-ruleset-kb4/blob/main/docs/rules/kb4_custom_check.md"
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4CustomCheckRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Runs the `check` blocks declared in the rule's block. Each check targets a `block` type (`resource`, `data`, `module`, `provider`, `variable` or `output`), optionally narrowed to a resource or data source `type`, and reports `message` for every block where the HCL `condition` is false. The condition sees the `attribute` as `value`, which is null when the attribute is unset.",
+		Categories:  []string{CategoryStyle},
+		Example: `
+resource "aws_s3_bucket" "this" {
+  acl = "public-read"
+}`,
+		ConfigExample: `
+rule "kb4_custom_check" {
+  enabled = true
+
+  # Conditions can call most of Terraform's pure functions, e.g. can, contains,
+  # length, lookup, lower, regex and startswith.
+  check "private_buckets" {
+    block     = "resource"
+    type      = "aws_s3_bucket"
+    attribute = "acl"
+    condition = "value == null || value == \"private\""
+    message   = "S3 buckets must be private"
+  }
+}`,
+		Config: &Kb4CustomCheckRuleConfig{},
+	}
+}
+
+// Check runs every configured check
+func (r *Kb4CustomCheckRule) Check(runner tflint.Runner) error {
+	config := &Kb4CustomCheckRuleConfig{}
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	for _, check := range config.Checks {
+		done := timeCheck(runner, r, check.Name)
+		err := r.runCheck(runner, check)
+		done()
+		if err != nil {
+			return fmt.Errorf("check %q: %s", check.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Kb4CustomCheckRule) runCheck(runner tflint.Runner, check Kb4CustomCheck) error {
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       check.Block,
+				LabelNames: customCheckBlockLabels[check.Block],
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: check.Attribute}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, block := range content.Blocks {
+		if check.Type != "" && block.Labels[0] != check.Type {
+			continue
+		}
+
+		attribute, ok := block.Body.Attributes[check.Attribute]
+		if !ok {
+			if err := r.evaluate(runner, check, block.DefRange, cty.NullVal(cty.DynamicPseudoType)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var value cty.Value
+		err := runner.EvaluateExpr(attribute.Expr, &value, nil)
+		err = runner.EnsureNoError(err, func() error {
+			return r.evaluate(runner, check, attribute.Expr.Range(), value)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// evaluate reports the check's message at issueRange when its condition is false for value.
+// Conditions that depend on values unknown until apply are skipped.
+func (r *Kb4CustomCheckRule) evaluate(runner tflint.Runner, check Kb4CustomCheck, issueRange hcl.Range, value cty.Value) error {
+	if !value.IsWhollyKnown() {
+		return nil
+	}
+
+	result, diags := check.condition.Value(&hcl.EvalContext{
+		Variables: map[string]cty.Value{"value": value},
+		Functions: terraformFunctions,
+	})
+	if diags.HasErrors() {
+		return diags
+	}
+
+	if !result.IsKnown() {
+		return nil
+	}
+	if result.IsNull() || result.Type() != cty.Bool {
+		return fmt.Errorf("condition must be a bool, got %s", result.Type().FriendlyName())
+	}
+
+	if result.False() {
+		return runner.EmitIssue(r, check.Message, issueRange)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+const customCheckConfig = `
+rule "kb4_custom_check" {
+  enabled = true
+
+  check "private_buckets" {
+    block     = "resource"
+    type      = "aws_s3_bucket"
+    attribute = "acl"
+    condition = "value == null || value == \"private\""
+    message   = "S3 buckets must be private"
+  }
+
+  check "described_variables" {
+    block     = "variable"
+    attribute = "description"
+    condition = "length(coalesce(value, \"\")) > 0"
+    message   = "Variables must be described"
+  }
+}`
+
+func Test_Kb4CustomCheckRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "conditions hold",
+			Content: `
+variable "acl" {
+  description = "Bucket ACL"
+  default     = "private"
+}
+
+resource "aws_s3_bucket" "this" {
+  acl = var.acl
+}
+
+resource "aws_s3_bucket" "default" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "conditions fail",
+			Content: `
+variable "acl" {
+  default = "public-read"
+}
+
+resource "aws_s3_bucket" "this" {
+  acl = var.acl
+}
+
+resource "aws_s3_bucket_acl" "this" {
+  acl = "public-read"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4CustomCheckRule(),
+					Message: "Variables must be described",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 15},
+					},
+				},
+				{
+					Rule:    NewKb4CustomCheckRule(),
+					Message: "S3 buckets must be private",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 9},
+						End:      hcl.Pos{Line: 7, Column: 16},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4CustomCheckRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content, ".tflint.hcl": customCheckConfig})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			assertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_Kb4CustomCheckRule_noChecks(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{"main.tf": `resource "aws_s3_bucket" "this" {}`})
+
+	if err := NewKb4CustomCheckRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	helper.AssertIssues(t, helper.Issues{}, runner.Issues)
+}
+
+func Test_Kb4CustomCheckRule_invalidConfig(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Check string
+		Error string
+	}{
+		{
+			Name: "unsupported block",
+			Check: `
+    block     = "locals"
+    attribute = "name"
+    condition = "true"
+    message   = "Nope"`,
+			Error: "invalid `kb4_custom_check` rule config: check \"test\": block \"locals\" must be one of data, module, output, provider, resource, variable",
+		},
+		{
+			Name: "type on a single label block",
+			Check: `
+    block     = "module"
+    type      = "vpc"
+    attribute = "source"
+    condition = "true"
+    message   = "Nope"`,
+			Error: "invalid `kb4_custom_check` rule config: check \"test\": type only applies to resource and data blocks",
+		},
+		{
+			Name: "unparsable condition",
+			Check: `
+    block     = "variable"
+    attribute = "type"
+    condition = "value =="
+    message   = "Nope"`,
+			Error: "invalid `kb4_custom_check` rule config: check \"test\": check \"test\" condition:1,9-9: Missing expression; Expected the start of an expression, but found the end of the file.",
+		},
+		{
+			Name: "condition is not a bool",
+			Check: `
+    block     = "variable"
+    attribute = "default"
+    condition = "value"
+    message   = "Nope"`,
+			Error: "check \"test\": condition must be a bool, got string",
+		},
+	}
+
+	rule := NewKb4CustomCheckRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{
+				"main.tf": `variable "name" { default = "x" }`,
+				".tflint.hcl": `
+rule "kb4_custom_check" {
+  enabled = true
+  check "test" {` + tc.Check + `
+  }
+}`,
+			})
+
+			err := rule.Check(runner)
+			if err == nil || err.Error() != tc.Error {
+				t.Fatalf("Expected error %q, got %v", tc.Error, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package rules
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kb4DataSourceIterationRuleConfig is the rule's .tflint.hcl config
+type Kb4DataSourceIterationRuleConfig struct {
+	MaxForEachInstances int `hclext:"max_for_each_instances,optional" doc:"How many instances a data source's for_each may create before every refresh slows down."`
+}
+
+func newKb4DataSourceIterationRuleConfig() *Kb4DataSourceIterationRuleConfig {
+	return &Kb4DataSourceIterationRuleConfig{MaxForEachInstances: 10}
+}
+
+// Validate rejects a limit below one
+func (c *Kb4DataSourceIterationRuleConfig) Validate() error {
+	if c.MaxForEachInstances < 1 {
+		return fmt.Errorf("max_for_each_instances must be at least 1")
+	}
+	return nil
+}
+
+// Kb4DataSourceIterationRule checks how data sources are repeated
+type Kb4DataSourceIterationRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4DataSourceIterationRule())
+}
+
+// NewKb4DataSourceIterationRule returns a new rule
+func NewKb4DataSourceIterationRule() *Kb4DataSourceIterationRule {
+	return &Kb4DataSourceIterationRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4DataSourceIterationRule) Name() string {
+	return "kb4_data_source_iteration"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4DataSourceIterationRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4DataSourceIterationRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4DataSourceIterationRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4DataSourceIterationRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Data sources must not use `count`, whose indexes shift when the list changes; use `for_each` with stable keys. A conditional `count` that toggles a single lookup between 0 and 1 is allowed. A data source's `for_each` must also not create more than `max_for_each_instances` instances, since every one is read again on each refresh.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "meta-arguments",
+		Example: `
+data "aws_subnet" "private" {
+  count = length(var.subnet_ids)
+  id    = var.subnet_ids[count.index]
+}`,
+		Config: newKb4DataSourceIterationRuleConfig(),
+	}
+}
+
+// Check emits issues for count on data sources and for data sources iterated over too many instances
+func (r *Kb4DataSourceIterationRule) Check(runner tflint.Runner) error {
+	config := newKb4DataSourceIterationRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "data",
+				LabelNames: []string{"type", "name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "count"}, {Name: "for_each"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, data := range content.Blocks {
+		if attr, ok := data.Body.Attributes["count"]; ok && !isToggleCount(attr.Expr) {
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s uses count; use for_each with stable keys", describeBlock(data)),
+				attr.Range,
+			); err != nil {
+				return err
+			}
+		}
+
+		if attr, ok := data.Body.Attributes["for_each"]; ok {
+			var value cty.Value
+			err := runner.EvaluateExpr(attr.Expr, &value, nil)
+			err = runner.EnsureNoError(err, func() error {
+				if !value.IsKnown() || value.IsNull() || !value.CanIterateElements() || value.LengthInt() <= config.MaxForEachInstances {
+					return nil
+				}
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("%s is read for %d instances, more than the %d allowed; look them up once with a plural data source or pass them in", describeBlock(data), value.LengthInt(), config.MaxForEachInstances),
+					attr.Expr.Range(),
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isToggleCount reports whether a count switches a single instance on or off, e.g. `var.enabled ? 1 : 0`
+func isToggleCount(expr hcl.Expression) bool {
+	cond, ok := expr.(*hclsyntax.ConditionalExpr)
+	if !ok {
+		return false
+	}
+
+	results := []int64{}
+	for _, branch := range []hclsyntax.Expression{cond.TrueResult, cond.FalseResult} {
+		value, diags := branch.Value(nil)
+		if diags.HasErrors() || value.Type() != cty.Number || value.IsNull() {
+			return false
+		}
+		n, accuracy := value.AsBigFloat().Int64()
+		if accuracy != big.Exact {
+			return false
+		}
+		results = append(results, n)
+	}
+
+	return results[0]+results[1] == 1 && results[0]*results[1] == 0
+}
@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4DataSourceIterationRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "keyed and toggled data sources",
+			Content: `
+variable "enabled" {
+  default = true
+}
+
+variable "subnets" {
+  default = { a = "subnet-1", b = "subnet-2" }
+}
+
+data "aws_subnet" "private" {
+  for_each = var.subnets
+  id       = each.value
+}
+
+data "aws_vpc" "shared" {
+  count = var.enabled ? 1 : 0
+}
+
+data "aws_caller_identity" "current" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "count and heavy for_each",
+			Content: `
+variable "subnet_ids" {
+  default = ["subnet-1", "subnet-2"]
+}
+
+variable "accounts" {
+  default = {
+    a = 1, b = 2, c = 3, d = 4, e = 5, f = 6,
+    g = 7, h = 8, i = 9, j = 10, k = 11,
+  }
+}
+
+data "aws_subnet" "private" {
+  count = length(var.subnet_ids)
+  id    = var.subnet_ids[count.index]
+}
+
+data "aws_iam_role" "deployer" {
+  for_each = var.accounts
+  name     = "deployer-${each.key}"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4DataSourceIterationRule(),
+					Message: "data \"aws_subnet\" \"private\" uses count; use for_each with stable keys",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 14, Column: 3},
+						End:      hcl.Pos{Line: 14, Column: 33},
+					},
+				},
+				{
+					Rule:    NewKb4DataSourceIterationRule(),
+					Message: "data \"aws_iam_role\" \"deployer\" is read for 11 instances, more than the 10 allowed; look them up once with a plural data source or pass them in",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 19, Column: 14},
+						End:      hcl.Pos{Line: 19, Column: 26},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured limit",
+			Content: `
+variable "subnets" {
+  default = { a = "subnet-1", b = "subnet-2", c = "subnet-3" }
+}
+
+data "aws_subnet" "private" {
+  for_each = var.subnets
+  id       = each.value
+}`,
+			Config: `
+rule "kb4_data_source_iteration" {
+  enabled                = true
+  max_for_each_instances = 2
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4DataSourceIterationRule(),
+					Message: "data \"aws_subnet\" \"private\" is read for 3 instances, more than the 2 allowed; look them up once with a plural data source or pass them in",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 14},
+						End:      hcl.Pos{Line: 7, Column: 25},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4DataSourceIterationRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
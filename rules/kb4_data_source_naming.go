@@ -0,0 +1,131 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// snakeCasePattern matches lower snake_case names
+var snakeCasePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// Kb4DataSourceNamingRuleConfig is the rule's .tflint.hcl config
+type Kb4DataSourceNamingRuleConfig struct {
+	Patterns map[string]string `hclext:"patterns,optional" doc:"Regular expressions names must also match, by data source type."`
+}
+
+func newKb4DataSourceNamingRuleConfig() *Kb4DataSourceNamingRuleConfig {
+	return &Kb4DataSourceNamingRuleConfig{Patterns: map[string]string{}}
+}
+
+// Validate rejects patterns that don't compile
+func (c *Kb4DataSourceNamingRuleConfig) Validate() error {
+	for ty, pattern := range c.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("pattern for %q is invalid: %s", ty, err)
+		}
+	}
+	return nil
+}
+
+// Kb4DataSourceNamingRule checks that data source names describe what they look up
+type Kb4DataSourceNamingRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4DataSourceNamingRule())
+}
+
+// NewKb4DataSourceNamingRule returns a new rule
+func NewKb4DataSourceNamingRule() *Kb4DataSourceNamingRule {
+	return &Kb4DataSourceNamingRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4DataSourceNamingRule) Name() string {
+	return "kb4_data_source_naming"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4DataSourceNamingRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4DataSourceNamingRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4DataSourceNamingRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4DataSourceNamingRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Data source names must be snake_case, must not start with `data_`, and may only be `this` when the module has a single data source of that type. Names of the types in `patterns` must also match their pattern.",
+		Categories:  []string{CategoryNaming},
+		Anchor:      "naming",
+		Example: `
+data "aws_ami" "this" {}
+
+data "aws_ami" "data_bastion" {}`,
+		Config: newKb4DataSourceNamingRuleConfig(),
+	}
+}
+
+// Check emits an issue for every data source name breaking the convention
+func (r *Kb4DataSourceNamingRule) Check(runner tflint.Runner) error {
+	config := newKb4DataSourceNamingRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "data",
+				LabelNames: []string{"type", "name"},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	perType := map[string]int{}
+	for _, data := range content.Blocks {
+		perType[data.Labels[0]]++
+	}
+
+	for _, data := range content.Blocks {
+		ty, name := data.Labels[0], data.Labels[1]
+
+		message := ""
+		switch {
+		case !snakeCasePattern.MatchString(name):
+			message = fmt.Sprintf("`%s` data source name should be snake_case", name)
+		case strings.HasPrefix(name, "data_"):
+			message = fmt.Sprintf("`%s` data source name should not start with data_", name)
+		case name == "this" && perType[ty] > 1:
+			message = fmt.Sprintf("`this` is only for a module's single %s data source; name each one after what it looks up", ty)
+		case config.Patterns[ty] != "" && !regexp.MustCompile(config.Patterns[ty]).MatchString(name):
+			message = fmt.Sprintf("`%s` %s data source name should match %s", name, ty, config.Patterns[ty])
+		default:
+			continue
+		}
+
+		if err := runner.EmitIssue(r, message, data.DefRange); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
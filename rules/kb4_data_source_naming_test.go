@@ -0,0 +1,108 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4DataSourceNamingRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "descriptive names",
+			Content: `
+data "aws_caller_identity" "this" {}
+
+data "aws_ami" "amazon_linux" {}
+
+data "aws_ami" "bastion" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "bad names",
+			Content: `
+data "aws_ami" "this" {}
+
+data "aws_ami" "BastionAMI" {}
+
+data "aws_vpc" "data_vpc" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4DataSourceNamingRule(),
+					Message: "`this` is only for a module's single aws_ami data source; name each one after what it looks up",
+					Range: hcl.Range{
+						Filename: "data.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 22},
+					},
+				},
+				{
+					Rule:    NewKb4DataSourceNamingRule(),
+					Message: "`BastionAMI` data source name should be snake_case",
+					Range: hcl.Range{
+						Filename: "data.tf",
+						Start:    hcl.Pos{Line: 4, Column: 1},
+						End:      hcl.Pos{Line: 4, Column: 28},
+					},
+				},
+				{
+					Rule:    NewKb4DataSourceNamingRule(),
+					Message: "`data_vpc` data source name should not start with data_",
+					Range: hcl.Range{
+						Filename: "data.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 26},
+					},
+				},
+			},
+		},
+		{
+			Name: "type patterns",
+			Content: `
+data "aws_iam_policy_document" "assume_role" {}
+
+data "aws_iam_policy_document" "bucket_policy" {}`,
+			Config: `
+rule "kb4_data_source_naming" {
+  enabled  = true
+  patterns = { aws_iam_policy_document = "_policy$" }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4DataSourceNamingRule(),
+					Message: "`assume_role` aws_iam_policy_document data source name should match _policy$",
+					Range: hcl.Range{
+						Filename: "data.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 45},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4DataSourceNamingRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"data.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// defaultNetworkResources are the resources that adopt a region's default VPC and its security group
+var defaultNetworkResources = []string{"aws_default_vpc", "aws_default_security_group"}
+
+// Kb4DefaultVpcRuleConfig is the rule's .tflint.hcl config
+type Kb4DefaultVpcRuleConfig struct {
+	CleanupModules []string `hclext:"cleanup_modules,optional" doc:"Glob patterns of module directories, relative to where tflint runs or to the repository root, that lock down the default VPC and may use it."`
+}
+
+func newKb4DefaultVpcRuleConfig() *Kb4DefaultVpcRuleConfig {
+	return &Kb4DefaultVpcRuleConfig{CleanupModules: []string{}}
+}
+
+// Validate rejects malformed glob patterns
+func (c *Kb4DefaultVpcRuleConfig) Validate() error {
+	for _, pattern := range c.CleanupModules {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("cleanup_modules pattern %q is invalid: %s", pattern, err)
+		}
+	}
+	return nil
+}
+
+// cleansUp reports whether the file belongs to a designated cleanup module.
+// The module's directory is matched as seen from where tflint runs and from the repository root,
+// so running tflint inside the module, where every file is in ".", still finds it.
+func (c *Kb4DefaultVpcRuleConfig) cleansUp(filename string) bool {
+	dir := filepath.Dir(filename)
+	dirs := []string{filepath.ToSlash(dir)}
+	if abs, err := filepath.Abs(dir); err == nil {
+		if rel, err := filepath.Rel(repositoryRoot(), abs); err == nil {
+			dirs = append(dirs, filepath.ToSlash(rel))
+		}
+	}
+
+	for _, pattern := range c.CleanupModules {
+		for _, dir := range dirs {
+			if ok, _ := path.Match(pattern, dir); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Kb4DefaultVpcRule checks that modules don't deploy into the default VPC or rely on the default security group
+type Kb4DefaultVpcRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4DefaultVpcRule())
+}
+
+// NewKb4DefaultVpcRule returns a new rule
+func NewKb4DefaultVpcRule() *Kb4DefaultVpcRule {
+	return &Kb4DefaultVpcRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4DefaultVpcRule) Name() string {
+	return "kb4_default_vpc"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4DefaultVpcRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4DefaultVpcRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4DefaultVpcRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4DefaultVpcRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Modules must not look up the default VPC with `data \"aws_vpc\"` and `default = true`, or manage `aws_default_vpc` and `aws_default_security_group` resources. Modules matching `cleanup_modules`, which lock the defaults down, are exempt.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "networking",
+		Example: `
+data "aws_vpc" "default" {
+  default = true
+}`,
+		Config: newKb4DefaultVpcRuleConfig(),
+	}
+}
+
+// Check emits an issue for every default VPC lookup and default network resource outside cleanup modules
+func (r *Kb4DefaultVpcRule) Check(runner tflint.Runner) error {
+	config := newKb4DefaultVpcRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "data",
+				LabelNames: []string{"type", "name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "default"}},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, data := range content.Blocks {
+		attr, ok := data.Body.Attributes["default"]
+		if data.Labels[0] != "aws_vpc" || !ok || config.cleansUp(data.DefRange.Filename) {
+			continue
+		}
+
+		err := evaluateBool(runner, attr.Expr, func(value bool) error {
+			if !value {
+				return nil
+			}
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s looks up the default VPC; use a VPC from the network stack", describeBlock(data)),
+				data.DefRange,
+			)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, resourceType := range defaultNetworkResources {
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			if config.cleansUp(resource.DefRange.Filename) {
+				continue
+			}
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s adopts a default network resource; only cleanup modules may manage defaults", describeBlock(resource)),
+				resource.DefRange,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
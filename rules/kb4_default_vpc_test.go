@@ -0,0 +1,122 @@
+package rules
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4DefaultVpcRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "network stack VPC",
+			Files: map[string]string{
+				"main.tf": `
+data "aws_vpc" "main" {
+  tags = { Name = "main" }
+}
+
+data "aws_vpc" "shared" {
+  default = false
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "defaults",
+			Files: map[string]string{
+				"main.tf": `
+data "aws_vpc" "default" {
+  default = true
+}
+
+resource "aws_default_vpc" "default" {}
+
+resource "aws_default_security_group" "default" {}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4DefaultVpcRule(),
+					Message: "data \"aws_vpc\" \"default\" looks up the default VPC; use a VPC from the network stack",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 25},
+					},
+				},
+				{
+					Rule:    NewKb4DefaultVpcRule(),
+					Message: "resource \"aws_default_vpc\" \"default\" adopts a default network resource; only cleanup modules may manage defaults",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 37},
+					},
+				},
+				{
+					Rule:    NewKb4DefaultVpcRule(),
+					Message: "resource \"aws_default_security_group\" \"default\" adopts a default network resource; only cleanup modules may manage defaults",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 1},
+						End:      hcl.Pos{Line: 8, Column: 48},
+					},
+				},
+			},
+		},
+		{
+			Name: "cleanup module",
+			Files: map[string]string{
+				"modules/default-vpc-cleanup/main.tf": `
+resource "aws_default_vpc" "default" {}
+
+resource "aws_default_security_group" "default" {}`,
+				".tflint.hcl": `
+rule "kb4_default_vpc" {
+  enabled         = true
+  cleanup_modules = ["modules/*-cleanup"]
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewKb4DefaultVpcRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.Files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_Kb4DefaultVpcRule_inModule(t *testing.T) {
+	chdir(t, filepath.Join(testRepository(t, "modules/default-vpc-cleanup"), "modules/default-vpc-cleanup"))
+
+	runner := helper.TestRunner(t, map[string]string{
+		"main.tf": `resource "aws_default_vpc" "default" {}`,
+		".tflint.hcl": `
+rule "kb4_default_vpc" {
+  enabled         = true
+  cleanup_modules = ["modules/*-cleanup"]
+}`,
+	})
+
+	if err := NewKb4DefaultVpcRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	helper.AssertIssues(t, helper.Issues{}, runner.Issues)
+}
@@ -0,0 +1,175 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4DeniedArgumentsRuleConfig is the rule's .tflint.hcl config
+type Kb4DeniedArgumentsRuleConfig struct {
+	// Denied maps each banned argument, written as type.argument, to its replacement, or to "" when there is none
+	Denied map[string]string `hclext:"denied,optional" doc:"Forbidden or deprecated arguments and nested blocks, written as resource_type.argument, each mapped to a replacement hint or to an empty string. Setting it replaces the defaults."`
+}
+
+func newKb4DeniedArgumentsRuleConfig() *Kb4DeniedArgumentsRuleConfig {
+	return &Kb4DeniedArgumentsRuleConfig{
+		Denied: map[string]string{
+			"aws_s3_bucket.acl":                                  "use an aws_s3_bucket_acl resource, or none with BucketOwnerEnforced object ownership",
+			"aws_s3_bucket.policy":                               "use an aws_s3_bucket_policy resource",
+			"aws_s3_bucket.versioning":                           "use an aws_s3_bucket_versioning resource",
+			"aws_s3_bucket.logging":                              "use an aws_s3_bucket_logging resource",
+			"aws_s3_bucket.server_side_encryption_configuration": "use an aws_s3_bucket_server_side_encryption_configuration resource",
+			"aws_s3_bucket.lifecycle_rule":                       "use an aws_s3_bucket_lifecycle_configuration resource",
+			"aws_instance.security_groups":                       "use vpc_security_group_ids",
+		},
+	}
+}
+
+// Validate rejects entries that aren't written as type.argument
+func (c *Kb4DeniedArgumentsRuleConfig) Validate() error {
+	for key := range c.Denied {
+		if _, _, ok := splitDeniedArgument(key); !ok {
+			return fmt.Errorf("denied argument %q must be written as resource_type.argument", key)
+		}
+	}
+	return nil
+}
+
+// byResourceType groups the denied argument names by resource type
+func (c *Kb4DeniedArgumentsRuleConfig) byResourceType() map[string][]string {
+	grouped := map[string][]string{}
+	for key := range c.Denied {
+		resourceType, argument, _ := splitDeniedArgument(key)
+		grouped[resourceType] = append(grouped[resourceType], argument)
+	}
+	for _, arguments := range grouped {
+		sort.Strings(arguments)
+	}
+	return grouped
+}
+
+// splitDeniedArgument splits a type.argument entry into its resource type and argument
+func splitDeniedArgument(key string) (string, string, bool) {
+	parts := strings.Split(key, ".")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// Kb4DeniedArgumentsRule checks that resources don't set forbidden or deprecated arguments
+type Kb4DeniedArgumentsRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4DeniedArgumentsRule())
+}
+
+// NewKb4DeniedArgumentsRule returns a new rule
+func NewKb4DeniedArgumentsRule() *Kb4DeniedArgumentsRule {
+	return &Kb4DeniedArgumentsRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4DeniedArgumentsRule) Name() string {
+	return "kb4_denied_arguments"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4DeniedArgumentsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4DeniedArgumentsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4DeniedArgumentsRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4DeniedArgumentsRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Resources must not set the arguments or nested blocks in the `denied` list, such as the inline `aws_s3_bucket` settings the AWS provider split into their own resources. The issue names the replacement.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "approved-services",
+		Example: `
+resource "aws_s3_bucket" "logs" {
+  acl = "log-delivery-write"
+}`,
+		Config: newKb4DeniedArgumentsRuleConfig(),
+	}
+}
+
+// Check emits an issue for every denied argument or nested block a resource sets
+func (r *Kb4DeniedArgumentsRule) Check(runner tflint.Runner) error {
+	config := newKb4DeniedArgumentsRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	type issue struct {
+		message string
+		rng     hcl.Range
+	}
+	issues := []issue{}
+
+	for resourceType, arguments := range config.byResourceType() {
+		// Arguments and nested blocks are fetched separately, since a denied name can be either
+		attributes, blocks := []hclext.AttributeSchema{}, []hclext.BlockSchema{}
+		for _, argument := range arguments {
+			attributes = append(attributes, hclext.AttributeSchema{Name: argument})
+			blocks = append(blocks, hclext.BlockSchema{Type: argument})
+		}
+
+		withAttributes, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{Attributes: attributes}, nil)
+		if err != nil {
+			return err
+		}
+		withBlocks, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{Blocks: blocks}, nil)
+		if err != nil {
+			return err
+		}
+
+		describe := func(resource *hclext.Block, argument string) string {
+			message := fmt.Sprintf("%s sets `%s`, which is not allowed", describeBlock(resource), argument)
+			if hint := config.Denied[resourceType+"."+argument]; hint != "" {
+				message += "; " + hint
+			}
+			return message
+		}
+
+		for _, resource := range withAttributes.Blocks {
+			for _, attr := range resource.Body.Attributes {
+				issues = append(issues, issue{describe(resource, attr.Name), attr.Range})
+			}
+		}
+		for _, resource := range withBlocks.Blocks {
+			for _, block := range resource.Body.Blocks {
+				issues = append(issues, issue{describe(resource, block.Type), block.DefRange})
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return rangeLess(issues[i].rng, issues[j].rng)
+	})
+
+	for _, i := range issues {
+		if err := runner.EmitIssue(r, i.message, i.rng); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
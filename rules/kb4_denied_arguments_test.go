@@ -0,0 +1,125 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4DeniedArgumentsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "allowed arguments",
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  bucket = "kb4-logs"
+}
+
+resource "aws_s3_bucket_acl" "logs" {
+  acl = "log-delivery-write"
+}
+
+resource "aws_instance" "bastion" {
+  vpc_security_group_ids = [var.security_group_id]
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "denied arguments",
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  bucket = "kb4-logs"
+  acl    = "log-delivery-write"
+
+  versioning {
+    enabled = true
+  }
+}
+
+resource "aws_instance" "bastion" {
+  security_groups = ["bastion"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4DeniedArgumentsRule(),
+					Message: "resource \"aws_s3_bucket\" \"logs\" sets `acl`, which is not allowed; use an aws_s3_bucket_acl resource, or none with BucketOwnerEnforced object ownership",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 32},
+					},
+				},
+				{
+					Rule:    NewKb4DeniedArgumentsRule(),
+					Message: "resource \"aws_s3_bucket\" \"logs\" sets `versioning`, which is not allowed; use an aws_s3_bucket_versioning resource",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 3},
+						End:      hcl.Pos{Line: 6, Column: 13},
+					},
+				},
+				{
+					Rule:    NewKb4DeniedArgumentsRule(),
+					Message: "resource \"aws_instance\" \"bastion\" sets `security_groups`, which is not allowed; use vpc_security_group_ids",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 12, Column: 3},
+						End:      hcl.Pos{Line: 12, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured deny-list",
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  acl = "private"
+}
+
+resource "aws_db_instance" "main" {
+  name = "main"
+}`,
+			Config: `
+rule "kb4_denied_arguments" {
+  enabled = true
+  denied  = { "aws_db_instance.name" = "" }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4DeniedArgumentsRule(),
+					Message: "resource \"aws_db_instance\" \"main\" sets `name`, which is not allowed",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 16},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4DeniedArgumentsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4DeniedResourceTypesRuleConfig is the rule's .tflint.hcl config
+type Kb4DeniedResourceTypesRuleConfig struct {
+	// Denied maps each banned resource type or glob pattern to the approved alternative, or to "" when there is none
+	Denied map[string]string `hclext:"denied,optional" doc:"Banned resource types, or glob patterns matching a whole service, each mapped to the approved alternative or to an empty string. The org policy's denied_resource_types replace it."`
+}
+
+func newKb4DeniedResourceTypesRuleConfig() *Kb4DeniedResourceTypesRuleConfig {
+	return &Kb4DeniedResourceTypesRuleConfig{
+		Denied: map[string]string{
+			"aws_iam_saml_provider": "federate through the organization's IAM Identity Center instead",
+			"aws_lightsail_*":       "use ECS or EC2 in a shared VPC instead",
+		},
+	}
+}
+
+// Validate rejects malformed glob patterns
+func (c *Kb4DeniedResourceTypesRuleConfig) Validate() error {
+	for pattern := range c.Denied {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("denied pattern %q is invalid: %s", pattern, err)
+		}
+	}
+	return nil
+}
+
+// deniedResourceType returns the deny-list entry matching the resource type.
+// An exact entry wins over patterns, and patterns are tried in sorted order so the match doesn't depend on map order.
+func deniedResourceType(denied map[string]string, resourceType string) (string, bool) {
+	if alternative, ok := denied[resourceType]; ok {
+		return alternative, true
+	}
+
+	patterns := make([]string, 0, len(denied))
+	for pattern := range denied {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, resourceType); ok {
+			return denied[pattern], true
+		}
+	}
+	return "", false
+}
+
+// Kb4DeniedResourceTypesRule checks that modules don't deploy resource types banned org-wide
+type Kb4DeniedResourceTypesRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4DeniedResourceTypesRule())
+}
+
+// NewKb4DeniedResourceTypesRule returns a new rule
+func NewKb4DeniedResourceTypesRule() *Kb4DeniedResourceTypesRule {
+	return &Kb4DeniedResourceTypesRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4DeniedResourceTypesRule) Name() string {
+	return "kb4_denied_resource_types"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4DeniedResourceTypesRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4DeniedResourceTypesRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4DeniedResourceTypesRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4DeniedResourceTypesRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Resource types on the deny-list, which the org policy's `denied_resource_types` replace, must not be declared. Entries may be glob patterns like `aws_lightsail_*` covering a whole service, and the issue names the approved alternative.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "approved-services",
+		Example: `
+resource "aws_lightsail_instance" "blog" {
+  name = "blog"
+}`,
+		Config: newKb4DeniedResourceTypesRuleConfig(),
+	}
+}
+
+// Check emits an issue for every resource of a denied type
+func (r *Kb4DeniedResourceTypesRule) Check(runner tflint.Runner) error {
+	config := newKb4DeniedResourceTypesRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	denied := orgPolicy(runner).DeniedResourceTypes
+	if len(denied) == 0 {
+		denied = config.Denied
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	resources := append([]*hclext.Block{}, content.Blocks...)
+	sort.SliceStable(resources, func(i, j int) bool {
+		return rangeLess(resources[i].DefRange, resources[j].DefRange)
+	})
+
+	for _, resource := range resources {
+		alternative, ok := deniedResourceType(denied, resource.Labels[0])
+		if !ok {
+			continue
+		}
+
+		message := fmt.Sprintf("`%s` resources are not allowed", resource.Labels[0])
+		if alternative != "" {
+			message = fmt.Sprintf("`%s` resources are not allowed; %s", resource.Labels[0], alternative)
+		}
+		if err := runner.EmitIssue(r, message, resource.DefRange); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_Kb4DeniedResourceTypesRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Policy   *Policy
+		Expected helper.Issues
+	}{
+		{
+			Name: "approved types",
+			Content: `
+resource "aws_instance" "blog" {}
+
+resource "aws_iam_openid_connect_provider" "ci" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "denied types",
+			Content: `
+resource "aws_lightsail_instance" "blog" {}
+
+resource "aws_iam_saml_provider" "okta" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4DeniedResourceTypesRule(),
+					Message: "`aws_lightsail_instance` resources are not allowed; use ECS or EC2 in a shared VPC instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 41},
+					},
+				},
+				{
+					Rule:    NewKb4DeniedResourceTypesRule(),
+					Message: "`aws_iam_saml_provider` resources are not allowed; federate through the organization's IAM Identity Center instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 1},
+						End:      hcl.Pos{Line: 4, Column: 40},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured deny-list",
+			Content: `
+resource "aws_lightsail_instance" "blog" {}
+
+resource "aws_default_vpc" "this" {}`,
+			Config: `
+rule "kb4_denied_resource_types" {
+  enabled = true
+  denied  = { "aws_default_*" = "" }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4DeniedResourceTypesRule(),
+					Message: "`aws_default_vpc` resources are not allowed",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 1},
+						End:      hcl.Pos{Line: 4, Column: 34},
+					},
+				},
+			},
+		},
+		{
+			Name: "org policy",
+			Content: `
+resource "aws_lightsail_instance" "blog" {}
+
+resource "aws_lightsail_database" "blog" {}`,
+			Policy: &Policy{DeniedResourceTypes: map[string]string{"aws_lightsail_*": "use ECS", "aws_lightsail_database": "use RDS"}},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4DeniedResourceTypesRule(),
+					Message: "`aws_lightsail_instance` resources are not allowed; use ECS",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 41},
+					},
+				},
+				{
+					Rule:    NewKb4DeniedResourceTypesRule(),
+					Message: "`aws_lightsail_database` resources are not allowed; use RDS",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 1},
+						End:      hcl.Pos{Line: 4, Column: 41},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4DeniedResourceTypesRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			testRunner := helper.TestRunner(t, files)
+
+			var runner tflint.Runner = testRunner
+			if tc.Policy != nil {
+				config := DefaultConfig()
+				config.policy = tc.Policy
+				runner = NewRunner(testRunner, config)
+			}
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, testRunner.Issues)
+		})
+	}
+}
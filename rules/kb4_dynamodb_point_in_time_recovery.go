@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4DynamodbPointInTimeRecoveryRule checks that DynamoDB tables can be restored to a point in time
+type Kb4DynamodbPointInTimeRecoveryRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4DynamodbPointInTimeRecoveryRule())
+}
+
+// NewKb4DynamodbPointInTimeRecoveryRule returns a new rule
+func NewKb4DynamodbPointInTimeRecoveryRule() *Kb4DynamodbPointInTimeRecoveryRule {
+	return &Kb4DynamodbPointInTimeRecoveryRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4DynamodbPointInTimeRecoveryRule) Name() string {
+	return "kb4_dynamodb_point_in_time_recovery"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4DynamodbPointInTimeRecoveryRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4DynamodbPointInTimeRecoveryRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4DynamodbPointInTimeRecoveryRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4DynamodbPointInTimeRecoveryRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`aws_dynamodb_table` resources must enable point-in-time recovery with `point_in_time_recovery { enabled = true }`. Ephemeral tables can be exempted with a `# kb4:exempt kb4_dynamodb_point_in_time_recovery <justification>` comment on the line above.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "backups",
+		Example: `
+resource "aws_dynamodb_table" "sessions" {
+  name     = "sessions"
+  hash_key = "id"
+}`,
+	}
+}
+
+// Check emits an issue for every table that isn't exempt and doesn't enable point-in-time recovery
+func (r *Kb4DynamodbPointInTimeRecoveryRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	exempt, err := loadExemptions(runner, r)
+	if err != nil {
+		return err
+	}
+
+	content, err := runner.GetResourceContent("aws_dynamodb_table", &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "point_in_time_recovery",
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "enabled"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, table := range content.Blocks {
+		ok, unjustified := exempt.applies(table.DefRange)
+		if ok {
+			continue
+		}
+		message := exemptionMessage(fmt.Sprintf("%s should enable point_in_time_recovery", describeBlock(table)), unjustified)
+
+		var recovery *hclext.Block
+		for _, block := range table.Body.Blocks {
+			recovery = block
+		}
+		if recovery == nil {
+			if err := runner.EmitIssue(r, message, table.DefRange); err != nil {
+				return err
+			}
+			continue
+		}
+
+		enabled, ok := recovery.Body.Attributes["enabled"]
+		if !ok {
+			if err := runner.EmitIssue(r, message, recovery.DefRange); err != nil {
+				return err
+			}
+			continue
+		}
+
+		err := evaluateBool(runner, enabled.Expr, func(value bool) error {
+			if value {
+				return nil
+			}
+			return runner.EmitIssue(r, message, enabled.Expr.Range())
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
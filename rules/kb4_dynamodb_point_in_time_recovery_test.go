@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4DynamodbPointInTimeRecoveryRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "enabled",
+			Content: `
+resource "aws_dynamodb_table" "sessions" {
+  point_in_time_recovery {
+    enabled = true
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "exempt",
+			Content: `
+# kb4:exempt kb4_dynamodb_point_in_time_recovery test fixtures are recreated on every run
+resource "aws_dynamodb_table" "fixtures" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "missing and disabled",
+			Content: `
+resource "aws_dynamodb_table" "sessions" {}
+
+resource "aws_dynamodb_table" "locks" {
+  point_in_time_recovery {
+    enabled = false
+  }
+}
+
+resource "aws_dynamodb_table" "cache" {
+  point_in_time_recovery {}
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4DynamodbPointInTimeRecoveryRule(),
+					Message: "resource \"aws_dynamodb_table\" \"sessions\" should enable point_in_time_recovery",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 41},
+					},
+				},
+				{
+					Rule:    NewKb4DynamodbPointInTimeRecoveryRule(),
+					Message: "resource \"aws_dynamodb_table\" \"locks\" should enable point_in_time_recovery",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 15},
+						End:      hcl.Pos{Line: 6, Column: 20},
+					},
+				},
+				{
+					Rule:    NewKb4DynamodbPointInTimeRecoveryRule(),
+					Message: "resource \"aws_dynamodb_table\" \"cache\" should enable point_in_time_recovery",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 3},
+						End:      hcl.Pos{Line: 11, Column: 25},
+					},
+				},
+			},
+		},
+		{
+			Name: "unjustified exemption",
+			Content: `
+# kb4:exempt kb4_dynamodb_point_in_time_recovery
+resource "aws_dynamodb_table" "fixtures" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4DynamodbPointInTimeRecoveryRule(),
+					Message: "resource \"aws_dynamodb_table\" \"fixtures\" should enable point_in_time_recovery (the kb4:exempt annotation needs a justification)",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 41},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4DynamodbPointInTimeRecoveryRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4EbsEncryptionRule checks that EBS volumes, instance root volumes and launch template volumes are encrypted
+type Kb4EbsEncryptionRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4EbsEncryptionRule())
+}
+
+// NewKb4EbsEncryptionRule returns a new rule
+func NewKb4EbsEncryptionRule() *Kb4EbsEncryptionRule {
+	return &Kb4EbsEncryptionRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4EbsEncryptionRule) Name() string {
+	return "kb4_ebs_encryption"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4EbsEncryptionRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4EbsEncryptionRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4EbsEncryptionRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4EbsEncryptionRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`aws_ebs_volume` resources, the `root_block_device` of `aws_instance` resources and the EBS `block_device_mappings` of `aws_launch_template` resources must set `encrypted = true`.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "encryption",
+		Example: `
+resource "aws_ebs_volume" "data" {
+  availability_zone = "us-east-1a"
+  size              = 100
+}`,
+	}
+}
+
+// Check emits an issue for every volume that doesn't set encrypted = true
+func (r *Kb4EbsEncryptionRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	encrypted := &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "encrypted"}},
+	}
+
+	volumes, err := runner.GetResourceContent("aws_ebs_volume", encrypted, nil)
+	if err != nil {
+		return err
+	}
+	for _, volume := range volumes.Blocks {
+		if err := r.checkEncrypted(runner, describeBlock(volume), volume); err != nil {
+			return err
+		}
+	}
+
+	instances, err := runner.GetResourceContent("aws_instance", &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{{Type: "root_block_device", Body: encrypted}},
+	}, nil)
+	if err != nil {
+		return err
+	}
+	for _, instance := range instances.Blocks {
+		if len(instance.Body.Blocks) == 0 {
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s should set root_block_device.encrypted = true", describeBlock(instance)),
+				instance.DefRange,
+			); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, device := range instance.Body.Blocks {
+			if err := r.checkEncrypted(runner, "root_block_device of "+describeBlock(instance), device); err != nil {
+				return err
+			}
+		}
+	}
+
+	templates, err := runner.GetResourceContent("aws_launch_template", &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "block_device_mappings",
+				Body: &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{{Type: "ebs", Body: encrypted}},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+	for _, template := range templates.Blocks {
+		for _, mapping := range template.Body.Blocks {
+			for _, ebs := range mapping.Body.Blocks {
+				if err := r.checkEncrypted(runner, "block_device_mappings of "+describeBlock(template), ebs); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkEncrypted emits an issue when the block doesn't set encrypted, or sets it to false
+func (r *Kb4EbsEncryptionRule) checkEncrypted(runner tflint.Runner, name string, block *hclext.Block) error {
+	message := fmt.Sprintf("%s should set encrypted = true", name)
+
+	attr, ok := block.Body.Attributes["encrypted"]
+	if !ok {
+		return runner.EmitIssue(r, message, block.DefRange)
+	}
+
+	return evaluateBool(runner, attr.Expr, func(value bool) error {
+		if value {
+			return nil
+		}
+		return runner.EmitIssue(r, message, attr.Expr.Range())
+	})
+}
@@ -0,0 +1,113 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4EbsEncryptionRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "encrypted",
+			Content: `
+resource "aws_ebs_volume" "data" {
+  encrypted = true
+}
+
+resource "aws_instance" "bastion" {
+  root_block_device {
+    encrypted = true
+  }
+}
+
+resource "aws_launch_template" "workers" {
+  block_device_mappings {
+    device_name = "/dev/xvda"
+
+    ebs {
+      encrypted = true
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "unencrypted",
+			Content: `
+resource "aws_ebs_volume" "data" {
+  encrypted = false
+}
+
+resource "aws_instance" "bastion" {}
+
+resource "aws_instance" "worker" {
+  root_block_device {}
+}
+
+resource "aws_launch_template" "workers" {
+  block_device_mappings {
+    ebs {}
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4EbsEncryptionRule(),
+					Message: "resource \"aws_ebs_volume\" \"data\" should set encrypted = true",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 15},
+						End:      hcl.Pos{Line: 3, Column: 20},
+					},
+				},
+				{
+					Rule:    NewKb4EbsEncryptionRule(),
+					Message: "resource \"aws_instance\" \"bastion\" should set root_block_device.encrypted = true",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 34},
+					},
+				},
+				{
+					Rule:    NewKb4EbsEncryptionRule(),
+					Message: "root_block_device of resource \"aws_instance\" \"worker\" should set encrypted = true",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 3},
+						End:      hcl.Pos{Line: 9, Column: 20},
+					},
+				},
+				{
+					Rule:    NewKb4EbsEncryptionRule(),
+					Message: "block_device_mappings of resource \"aws_launch_template\" \"workers\" should set encrypted = true",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 14, Column: 5},
+						End:      hcl.Pos{Line: 14, Column: 8},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4EbsEncryptionRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
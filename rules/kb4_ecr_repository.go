@@ -0,0 +1,137 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4EcrRepositoryRule checks that ECR repositories scan images on push and don't let tags be overwritten
+type Kb4EcrRepositoryRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4EcrRepositoryRule())
+}
+
+// NewKb4EcrRepositoryRule returns a new rule
+func NewKb4EcrRepositoryRule() *Kb4EcrRepositoryRule {
+	return &Kb4EcrRepositoryRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4EcrRepositoryRule) Name() string {
+	return "kb4_ecr_repository"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4EcrRepositoryRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4EcrRepositoryRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4EcrRepositoryRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4EcrRepositoryRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`aws_ecr_repository` resources must set `image_scanning_configuration { scan_on_push = true }` and `image_tag_mutability = \"IMMUTABLE\"`. Both default to off.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "supply-chain",
+		Example: `
+resource "aws_ecr_repository" "api" {
+  name                 = "api"
+  image_tag_mutability = "MUTABLE"
+}`,
+	}
+}
+
+// Check emits issues for repositories that don't scan on push or have mutable tags
+func (r *Kb4EcrRepositoryRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	content, err := runner.GetResourceContent("aws_ecr_repository", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "image_tag_mutability"}},
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "image_scanning_configuration",
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "scan_on_push"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, repository := range content.Blocks {
+		if err := r.checkScanOnPush(runner, repository); err != nil {
+			return err
+		}
+		if err := r.checkTagMutability(runner, repository); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Kb4EcrRepositoryRule) checkScanOnPush(runner tflint.Runner, repository *hclext.Block) error {
+	message := fmt.Sprintf("%s should enable image_scanning_configuration.scan_on_push", describeBlock(repository))
+
+	if len(repository.Body.Blocks) == 0 {
+		return runner.EmitIssue(r, message, repository.DefRange)
+	}
+
+	for _, scanning := range repository.Body.Blocks {
+		attr, ok := scanning.Body.Attributes["scan_on_push"]
+		if !ok {
+			if err := runner.EmitIssue(r, message, scanning.DefRange); err != nil {
+				return err
+			}
+			continue
+		}
+
+		err := evaluateBool(runner, attr.Expr, func(value bool) error {
+			if value {
+				return nil
+			}
+			return runner.EmitIssue(r, message, attr.Expr.Range())
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Kb4EcrRepositoryRule) checkTagMutability(runner tflint.Runner, repository *hclext.Block) error {
+	message := fmt.Sprintf("%s should set image_tag_mutability = \"IMMUTABLE\"", describeBlock(repository))
+
+	attr, ok := repository.Body.Attributes["image_tag_mutability"]
+	if !ok {
+		return runner.EmitIssue(r, message, repository.DefRange)
+	}
+
+	return evaluateString(runner, attr.Expr, func(value string) error {
+		if value != "MUTABLE" {
+			return nil
+		}
+		return runner.EmitIssue(r, message, attr.Expr.Range())
+	})
+}
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4EcrRepositoryRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "scanned and immutable",
+			Content: `
+resource "aws_ecr_repository" "api" {
+  image_tag_mutability = "IMMUTABLE"
+
+  image_scanning_configuration {
+    scan_on_push = true
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:    "defaults",
+			Content: `resource "aws_ecr_repository" "api" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4EcrRepositoryRule(),
+					Message: "resource \"aws_ecr_repository\" \"api\" should enable image_scanning_configuration.scan_on_push",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 36},
+					},
+				},
+				{
+					Rule:    NewKb4EcrRepositoryRule(),
+					Message: "resource \"aws_ecr_repository\" \"api\" should set image_tag_mutability = \"IMMUTABLE\"",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 36},
+					},
+				},
+			},
+		},
+		{
+			Name: "disabled",
+			Content: `
+resource "aws_ecr_repository" "api" {
+  image_tag_mutability = "MUTABLE"
+
+  image_scanning_configuration {
+    scan_on_push = false
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4EcrRepositoryRule(),
+					Message: "resource \"aws_ecr_repository\" \"api\" should enable image_scanning_configuration.scan_on_push",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 20},
+						End:      hcl.Pos{Line: 6, Column: 25},
+					},
+				},
+				{
+					Rule:    NewKb4EcrRepositoryRule(),
+					Message: "resource \"aws_ecr_repository\" \"api\" should set image_tag_mutability = \"IMMUTABLE\"",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 26},
+						End:      hcl.Pos{Line: 3, Column: 35},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4EcrRepositoryRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// eksLogTypes are the control plane log types EKS can export
+var eksLogTypes = []string{"api", "audit", "authenticator", "controllerManager", "scheduler"}
+
+// Kb4EksClusterLoggingRuleConfig is the rule's .tflint.hcl config
+type Kb4EksClusterLoggingRuleConfig struct {
+	RequiredLogTypes []string `hclext:"required_log_types,optional" doc:"Control plane log types every cluster must enable."`
+}
+
+func newKb4EksClusterLoggingRuleConfig() *Kb4EksClusterLoggingRuleConfig {
+	return &Kb4EksClusterLoggingRuleConfig{RequiredLogTypes: []string{"api", "audit", "authenticator"}}
+}
+
+// Validate rejects log types EKS doesn't export
+func (c *Kb4EksClusterLoggingRuleConfig) Validate() error {
+	for _, logType := range c.RequiredLogTypes {
+		if !containsString(eksLogTypes, logType) {
+			return fmt.Errorf("required log type %q must be one of %s", logType, strings.Join(eksLogTypes, ", "))
+		}
+	}
+	return nil
+}
+
+// Kb4EksClusterLoggingRule checks that EKS clusters export the required control plane logs
+type Kb4EksClusterLoggingRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4EksClusterLoggingRule())
+}
+
+// NewKb4EksClusterLoggingRule returns a new rule
+func NewKb4EksClusterLoggingRule() *Kb4EksClusterLoggingRule {
+	return &Kb4EksClusterLoggingRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4EksClusterLoggingRule) Name() string {
+	return "kb4_eks_cluster_logging"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4EksClusterLoggingRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4EksClusterLoggingRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4EksClusterLoggingRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4EksClusterLoggingRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`aws_eks_cluster` resources must list every log type in `required_log_types` in `enabled_cluster_log_types`.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "logging",
+		Example: `
+resource "aws_eks_cluster" "main" {
+  enabled_cluster_log_types = ["api"]
+}`,
+		Config: newKb4EksClusterLoggingRuleConfig(),
+	}
+}
+
+// Check emits an issue for every cluster missing a required log type
+func (r *Kb4EksClusterLoggingRule) Check(runner tflint.Runner) error {
+	config := newKb4EksClusterLoggingRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+	if len(config.RequiredLogTypes) == 0 {
+		return nil
+	}
+
+	content, err := runner.GetResourceContent("aws_eks_cluster", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "enabled_cluster_log_types"}},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, cluster := range content.Blocks {
+		attr, ok := cluster.Body.Attributes["enabled_cluster_log_types"]
+		if !ok {
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s should enable the %s control plane logs", describeBlock(cluster), strings.Join(config.RequiredLogTypes, ", ")),
+				cluster.DefRange,
+			); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var value cty.Value
+		err := runner.EvaluateExpr(attr.Expr, &value, nil)
+		err = runner.EnsureNoError(err, func() error {
+			if !value.IsWhollyKnown() || value.IsNull() || !value.CanIterateElements() {
+				return nil
+			}
+
+			enabled := []string{}
+			for it := value.ElementIterator(); it.Next(); {
+				_, v := it.Element()
+				if v.Type() == cty.String {
+					enabled = append(enabled, v.AsString())
+				}
+			}
+
+			missing := []string{}
+			for _, logType := range config.RequiredLogTypes {
+				if !containsString(enabled, logType) {
+					missing = append(missing, logType)
+				}
+			}
+			if len(missing) == 0 {
+				return nil
+			}
+
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s should enable the %s control plane logs", describeBlock(cluster), strings.Join(missing, ", ")),
+				attr.Expr.Range(),
+			)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4EksClusterLoggingRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "required logs",
+			Content: `
+resource "aws_eks_cluster" "main" {
+  enabled_cluster_log_types = ["api", "audit", "authenticator", "scheduler"]
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "missing logs",
+			Content: `
+resource "aws_eks_cluster" "main" {
+  enabled_cluster_log_types = ["api"]
+}
+
+resource "aws_eks_cluster" "batch" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4EksClusterLoggingRule(),
+					Message: "resource \"aws_eks_cluster\" \"main\" should enable the audit, authenticator control plane logs",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 31},
+						End:      hcl.Pos{Line: 3, Column: 38},
+					},
+				},
+				{
+					Rule:    NewKb4EksClusterLoggingRule(),
+					Message: "resource \"aws_eks_cluster\" \"batch\" should enable the api, audit, authenticator control plane logs",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 35},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured log types",
+			Content: `
+resource "aws_eks_cluster" "main" {
+  enabled_cluster_log_types = ["api"]
+}`,
+			Config: `
+rule "kb4_eks_cluster_logging" {
+  enabled            = true
+  required_log_types = ["audit"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4EksClusterLoggingRule(),
+					Message: "resource \"aws_eks_cluster\" \"main\" should enable the audit control plane logs",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 31},
+						End:      hcl.Pos{Line: 3, Column: 38},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4EksClusterLoggingRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// elasticacheEncryptionAttributes are the replication group attributes that must be true
+var elasticacheEncryptionAttributes = []string{"transit_encryption_enabled", "at_rest_encryption_enabled"}
+
+// Kb4ElasticacheEncryptionRule checks that ElastiCache replication groups encrypt data in transit and at rest
+type Kb4ElasticacheEncryptionRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4ElasticacheEncryptionRule())
+}
+
+// NewKb4ElasticacheEncryptionRule returns a new rule
+func NewKb4ElasticacheEncryptionRule() *Kb4ElasticacheEncryptionRule {
+	return &Kb4ElasticacheEncryptionRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4ElasticacheEncryptionRule) Name() string {
+	return "kb4_elasticache_encryption"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4ElasticacheEncryptionRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4ElasticacheEncryptionRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4ElasticacheEncryptionRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4ElasticacheEncryptionRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`aws_elasticache_replication_group` resources must set both `transit_encryption_enabled` and `at_rest_encryption_enabled` to true.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "encryption",
+		Example: `
+resource "aws_elasticache_replication_group" "sessions" {
+  replication_group_id       = "sessions"
+  at_rest_encryption_enabled = true
+}`,
+	}
+}
+
+// Check emits an issue for every encryption setting a replication group leaves off
+func (r *Kb4ElasticacheEncryptionRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	schema := &hclext.BodySchema{}
+	for _, name := range elasticacheEncryptionAttributes {
+		schema.Attributes = append(schema.Attributes, hclext.AttributeSchema{Name: name})
+	}
+
+	content, err := runner.GetResourceContent("aws_elasticache_replication_group", schema, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range content.Blocks {
+		for _, name := range elasticacheEncryptionAttributes {
+			message := fmt.Sprintf("%s should set %s = true", describeBlock(group), name)
+
+			attr, ok := group.Body.Attributes[name]
+			if !ok {
+				if err := runner.EmitIssue(r, message, group.DefRange); err != nil {
+					return err
+				}
+				continue
+			}
+
+			err := evaluateBool(runner, attr.Expr, func(value bool) error {
+				if value {
+					return nil
+				}
+				return runner.EmitIssue(r, message, attr.Expr.Range())
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4ElasticacheEncryptionRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "encrypted",
+			Content: `
+resource "aws_elasticache_replication_group" "sessions" {
+  transit_encryption_enabled = true
+  at_rest_encryption_enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "unencrypted",
+			Content: `
+resource "aws_elasticache_replication_group" "sessions" {
+  at_rest_encryption_enabled = false
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ElasticacheEncryptionRule(),
+					Message: "resource \"aws_elasticache_replication_group\" \"sessions\" should set transit_encryption_enabled = true",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 56},
+					},
+				},
+				{
+					Rule:    NewKb4ElasticacheEncryptionRule(),
+					Message: "resource \"aws_elasticache_replication_group\" \"sessions\" should set at_rest_encryption_enabled = true",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 32},
+						End:      hcl.Pos{Line: 3, Column: 37},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4ElasticacheEncryptionRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
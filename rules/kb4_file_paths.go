@@ -0,0 +1,152 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// fileFunctions are the functions whose first argument is the path of a file they read
+var fileFunctions = []string{"file", "filebase64", "templatefile"}
+
+// Kb4FilePathsRule checks that files read with file() and friends exist
+type Kb4FilePathsRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4FilePathsRule())
+}
+
+// NewKb4FilePathsRule returns a new rule
+func NewKb4FilePathsRule() *Kb4FilePathsRule {
+	return &Kb4FilePathsRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4FilePathsRule) Name() string {
+	return "kb4_file_paths"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4FilePathsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4FilePathsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4FilePathsRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4FilePathsRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Literal paths passed to `file()`, `filebase64()` and `templatefile()` must exist. Relative paths and paths under `path.module` are resolved against the module directory, so a typo fails the lint instead of a plan deep in CI.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "standard-files-names-and-usage",
+		Example: `
+locals {
+  policy = file("${path.module}/policies/bucket.jsn")
+}`,
+	}
+}
+
+// Check emits an issue for every literal file path that doesn't exist
+func (r *Kb4FilePathsRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+
+	type missingFile struct {
+		function string
+		path     string
+		rng      hcl.Range
+	}
+	missing := []missingFile{}
+
+	for filename, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		dir := filepath.Dir(filename)
+
+		hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+			call, ok := node.(*hclsyntax.FunctionCallExpr)
+			if !ok || len(call.Args) == 0 || !containsString(fileFunctions, call.Name) {
+				return nil
+			}
+
+			path, ok := moduleRelativePath(call.Args[0])
+			if !ok {
+				return nil
+			}
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(path))); os.IsNotExist(err) {
+				missing = append(missing, missingFile{call.Name, path, call.Args[0].Range()})
+			}
+			return nil
+		})
+	}
+
+	sort.SliceStable(missing, func(i, j int) bool {
+		return rangeLess(missing[i].rng, missing[j].rng)
+	})
+
+	for _, m := range missing {
+		if err := runner.EmitIssue(r, fmt.Sprintf("%s() reads %s, which doesn't exist in the module", m.function, m.path), m.rng); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// moduleRelativePath returns the path relative to the module directory that the expression names,
+// when it is a relative literal or a literal under "${path.module}/"
+func moduleRelativePath(expr hclsyntax.Expression) (string, bool) {
+	if template, ok := expr.(*hclsyntax.TemplateExpr); ok && len(template.Parts) == 2 {
+		traversal, ok := template.Parts[0].(*hclsyntax.ScopeTraversalExpr)
+		if !ok || traversal.Traversal.RootName() != "path" || traversalAttr(traversal.Traversal) != "module" {
+			return "", false
+		}
+
+		rest, ok := literalString(template.Parts[1])
+		if !ok || !strings.HasPrefix(rest, "/") {
+			return "", false
+		}
+		return strings.TrimPrefix(rest, "/"), true
+	}
+
+	path, ok := literalString(expr)
+	if !ok || path == "" || filepath.IsAbs(path) || strings.HasPrefix(path, "/") {
+		return "", false
+	}
+	return path, true
+}
+
+// literalString returns the value of an expression that is a plain string without interpolations
+func literalString(expr hclsyntax.Expression) (string, bool) {
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || value.Type() != cty.String || !value.IsKnown() || value.IsNull() {
+		return "", false
+	}
+	return value.AsString(), true
+}
@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4FilePathsRule(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "policies"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "policies", "bucket.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(dir, "main.tf")
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "existing files",
+			Content: `
+locals {
+  policy   = file("${path.module}/policies/bucket.json")
+  relative = filebase64("policies/bucket.json")
+  dynamic  = file("${path.module}/policies/${var.name}.json")
+  absolute = file("/etc/hosts")
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "missing files",
+			Content: `
+locals {
+  policy = templatefile("${path.module}/policies/bucket.json.tpl", {})
+  script = file("scripts/init.sh")
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4FilePathsRule(),
+					Message: "templatefile() reads policies/bucket.json.tpl, which doesn't exist in the module",
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 3, Column: 25},
+						End:      hcl.Pos{Line: 3, Column: 66},
+					},
+				},
+				{
+					Rule:    NewKb4FilePathsRule(),
+					Message: "file() reads scripts/init.sh, which doesn't exist in the module",
+					Range: hcl.Range{
+						Filename: filename,
+						Start:    hcl.Pos{Line: 4, Column: 17},
+						End:      hcl.Pos{Line: 4, Column: 34},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4FilePathsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4ForEachTosetRule checks that for_each is never given a list
+type Kb4ForEachTosetRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4ForEachTosetRule())
+}
+
+// NewKb4ForEachTosetRule returns a new rule
+func NewKb4ForEachTosetRule() *Kb4ForEachTosetRule {
+	return &Kb4ForEachTosetRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4ForEachTosetRule) Name() string {
+	return "kb4_for_each_toset"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4ForEachTosetRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4ForEachTosetRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4ForEachTosetRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4ForEachTosetRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`for_each` on resources, data sources and modules must not be given a list literal or a list-typed variable, which fails at plan time. Wrap the list in `toset()`.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "meta-arguments",
+		Example: `
+variable "names" {
+  type = list(string)
+}
+
+resource "aws_sqs_queue" "this" {
+  for_each = var.names
+}`,
+	}
+}
+
+// Check emits an issue for every for_each given a list literal or list-typed variable
+func (r *Kb4ForEachTosetRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	forEach := &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "for_each"}},
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "type"}},
+				},
+			},
+			{Type: "resource", LabelNames: []string{"type", "name"}, Body: forEach},
+			{Type: "data", LabelNames: []string{"type", "name"}, Body: forEach},
+			{Type: "module", LabelNames: []string{"name"}, Body: forEach},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	lists := map[string]bool{}
+	for _, variable := range content.Blocks {
+		if variable.Type != "variable" {
+			continue
+		}
+		typeAttr, ok := variable.Body.Attributes["type"]
+		if !ok {
+			continue
+		}
+		if ty, err := parseVariableType(typeAttr.Expr); err == nil && (ty.Name == "list" || ty.Name == "tuple") {
+			lists[variable.Labels[0]] = true
+		}
+	}
+
+	attrs := []*hclext.Attribute{}
+	for _, block := range content.Blocks {
+		if attr, ok := block.Body.Attributes["for_each"]; ok && block.Type != "variable" {
+			attrs = append(attrs, attr)
+		}
+	}
+	sort.SliceStable(attrs, func(i, j int) bool {
+		return rangeLess(attrs[i].Range, attrs[j].Range)
+	})
+
+	for _, attr := range attrs {
+		message := ""
+		switch expr := attr.Expr.(type) {
+		case *hclsyntax.TupleConsExpr:
+			message = "for_each is given a list literal; wrap it in toset()"
+		case *hclsyntax.ScopeTraversalExpr:
+			if len(expr.Traversal) != 2 || expr.Traversal.RootName() != "var" {
+				continue
+			}
+			name := traversalAttr(expr.Traversal)
+			if !lists[name] {
+				continue
+			}
+			message = fmt.Sprintf("for_each is given `var.%s`, which is a list; use toset(var.%s)", name, name)
+		default:
+			continue
+		}
+
+		if err := runner.EmitIssue(r, message, attr.Expr.Range()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4ForEachTosetRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "sets and maps",
+			Content: `
+variable "names" {
+  type = list(string)
+}
+
+variable "queues" {
+  type = map(string)
+}
+
+resource "aws_sqs_queue" "named" {
+  for_each = toset(var.names)
+}
+
+resource "aws_sqs_queue" "mapped" {
+  for_each = var.queues
+}
+
+module "buckets" {
+  for_each = { logs = "logs" }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "lists",
+			Content: `
+variable "names" {
+  type = list(string)
+}
+
+resource "aws_sqs_queue" "this" {
+  for_each = var.names
+}
+
+data "aws_iam_role" "this" {
+  for_each = ["deploy", "read"]
+}
+
+module "buckets" {
+  for_each = ["logs"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ForEachTosetRule(),
+					Message: "for_each is given `var.names`, which is a list; use toset(var.names)",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 14},
+						End:      hcl.Pos{Line: 7, Column: 23},
+					},
+				},
+				{
+					Rule:    NewKb4ForEachTosetRule(),
+					Message: "for_each is given a list literal; wrap it in toset()",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 14},
+						End:      hcl.Pos{Line: 11, Column: 32},
+					},
+				},
+				{
+					Rule:    NewKb4ForEachTosetRule(),
+					Message: "for_each is given a list literal; wrap it in toset()",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 15, Column: 14},
+						End:      hcl.Pos{Line: 15, Column: 22},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4ForEachTosetRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
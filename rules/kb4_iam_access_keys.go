@@ -0,0 +1,84 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// workloadIdentityAnchor is the style guide section on IRSA and OIDC federation, which replace access keys
+const workloadIdentityAnchor = "workload-identity"
+
+// Kb4IamAccessKeysRule checks that modules don't create long-lived IAM access keys
+type Kb4IamAccessKeysRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4IamAccessKeysRule())
+}
+
+// NewKb4IamAccessKeysRule returns a new rule
+func NewKb4IamAccessKeysRule() *Kb4IamAccessKeysRule {
+	return &Kb4IamAccessKeysRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4IamAccessKeysRule) Name() string {
+	return "kb4_iam_access_keys"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4IamAccessKeysRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4IamAccessKeysRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4IamAccessKeysRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4IamAccessKeysRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`aws_iam_access_key` resources are not allowed. The secret key ends up in state, and the key lives until someone rotates it. Workloads should assume roles through IRSA or OIDC federation instead.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      workloadIdentityAnchor,
+		Example: `
+resource "aws_iam_access_key" "ci" {
+  user = aws_iam_user.ci.name
+}`,
+	}
+}
+
+// Check emits an issue for every access key
+func (r *Kb4IamAccessKeysRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+	guidance := ruleSetConfig(runner).styleGuideLink(workloadIdentityAnchor)
+
+	content, err := runner.GetResourceContent("aws_iam_access_key", &hclext.BodySchema{}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range content.Blocks {
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s stores a long-lived secret key in state; use IRSA or OIDC federation instead, see %s", describeBlock(key), guidance),
+			key.DefRange,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4IamAccessKeysRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name:     "no access keys",
+			Content:  `resource "aws_iam_role" "ci" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:    "access key",
+			Content: `resource "aws_iam_access_key" "ci" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4IamAccessKeysRule(),
+					Message: "resource \"aws_iam_access_key\" \"ci\" stores a long-lived secret key in state; use IRSA or OIDC federation instead, see https://engineering.internal.knowbe4.com/tech-stack/terraform/style-guide/#workload-identity",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 35},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4IamAccessKeysRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_Kb4IamAccessKeysRule_styleGuideURL(t *testing.T) {
+	testRunner := helper.TestRunner(t, map[string]string{"main.tf": `resource "aws_iam_access_key" "ci" {}`})
+
+	config := DefaultConfig()
+	config.StyleGuideURL = "https://docs.example.com/terraform/"
+
+	if err := NewKb4IamAccessKeysRule().Check(NewRunner(testRunner, config)); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	expected := "resource \"aws_iam_access_key\" \"ci\" stores a long-lived secret key in state; use IRSA or OIDC federation instead, see https://docs.example.com/terraform/#workload-identity"
+	if len(testRunner.Issues) != 1 || testRunner.Issues[0].Message != expected {
+		t.Fatalf("Expected the guidance to link to the configured style guide, got %v", testRunner.Issues)
+	}
+}
@@ -0,0 +1,112 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4IamUsersRule checks that modules don't create IAM users, since people sign in through SSO
+type Kb4IamUsersRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4IamUsersRule())
+}
+
+// NewKb4IamUsersRule returns a new rule
+func NewKb4IamUsersRule() *Kb4IamUsersRule {
+	return &Kb4IamUsersRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4IamUsersRule) Name() string {
+	return "kb4_iam_users"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4IamUsersRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4IamUsersRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4IamUsersRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4IamUsersRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`aws_iam_user` resources are not allowed, since human access goes through SSO. Users named in the org policy's `service_users` are exempt. `aws_iam_user_login_profile` resources give console access and are never allowed.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "iam",
+		Example: `
+resource "aws_iam_user" "deploy" {
+  name = "deploy"
+}`,
+	}
+}
+
+// Check emits an issue for every IAM user not approved in the org policy and every login profile
+func (r *Kb4IamUsersRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+	approved := orgPolicy(runner).ServiceUsers
+
+	users, err := runner.GetResourceContent("aws_iam_user", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "name"}},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users.Blocks {
+		service := false
+		if attr, ok := user.Body.Attributes["name"]; ok {
+			err := evaluateString(runner, attr.Expr, func(value string) error {
+				service = containsString(approved, value)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if service {
+			continue
+		}
+
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s creates an IAM user; people sign in through SSO, and service users must be approved in the org policy", describeBlock(user)),
+			user.DefRange,
+		); err != nil {
+			return err
+		}
+	}
+
+	profiles, err := runner.GetResourceContent("aws_iam_user_login_profile", &hclext.BodySchema{}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, profile := range profiles.Blocks {
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s gives an IAM user console access; people sign in through SSO", describeBlock(profile)),
+			profile.DefRange,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
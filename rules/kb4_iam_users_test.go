@@ -0,0 +1,100 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_Kb4IamUsersRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Policy   *Policy
+		Expected helper.Issues
+	}{
+		{
+			Name:     "no users",
+			Content:  `resource "aws_iam_role" "deploy" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "users",
+			Content: `
+resource "aws_iam_user" "deploy" {
+  name = "deploy"
+}
+
+resource "aws_iam_user_login_profile" "deploy" {
+  user = aws_iam_user.deploy.name
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4IamUsersRule(),
+					Message: "resource \"aws_iam_user\" \"deploy\" creates an IAM user; people sign in through SSO, and service users must be approved in the org policy",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 33},
+					},
+				},
+				{
+					Rule:    NewKb4IamUsersRule(),
+					Message: "resource \"aws_iam_user_login_profile\" \"deploy\" gives an IAM user console access; people sign in through SSO",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 47},
+					},
+				},
+			},
+		},
+		{
+			Name: "approved service users",
+			Content: `
+resource "aws_iam_user" "ci" {
+  name = "ci-legacy"
+}
+
+resource "aws_iam_user" "deploy" {
+  name = "deploy"
+}`,
+			Policy: &Policy{ServiceUsers: []string{"ci-legacy"}},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4IamUsersRule(),
+					Message: "resource \"aws_iam_user\" \"deploy\" creates an IAM user; people sign in through SSO, and service users must be approved in the org policy",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 33},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4IamUsersRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			testRunner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			var runner tflint.Runner = testRunner
+			if tc.Policy != nil {
+				config := DefaultConfig()
+				config.policy = tc.Policy
+				runner = NewRunner(testRunner, config)
+			}
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, testRunner.Issues)
+		})
+	}
+}
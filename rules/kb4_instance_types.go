@@ -0,0 +1,180 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// instanceTypeAttributes maps the resources that launch instances to the argument naming their instance types
+var instanceTypeAttributes = map[string]string{
+	"aws_instance":        "instance_type",
+	"aws_launch_template": "instance_type",
+	"aws_eks_node_group":  "instance_types",
+}
+
+// Kb4InstanceTypesRuleConfig is the rule's .tflint.hcl config
+type Kb4InstanceTypesRuleConfig struct {
+	AllowedInstanceTypes []string `hclext:"allowed_instance_types,optional" doc:"Instance types, or glob patterns matching a whole family, that may be deployed. The org policy's instance_types replace it."`
+}
+
+func newKb4InstanceTypesRuleConfig() *Kb4InstanceTypesRuleConfig {
+	return &Kb4InstanceTypesRuleConfig{
+		AllowedInstanceTypes: []string{
+			"t3.*", "t3a.*", "t4g.*",
+			"m6i.*", "m6a.*", "m6g.*", "m7i.*", "m7g.*",
+			"c6i.*", "c6a.*", "c6g.*", "c7i.*", "c7g.*",
+			"r6i.*", "r6a.*", "r6g.*", "r7i.*", "r7g.*",
+		},
+	}
+}
+
+// Validate rejects malformed glob patterns
+func (c *Kb4InstanceTypesRuleConfig) Validate() error {
+	for _, pattern := range c.AllowedInstanceTypes {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("allowed_instance_types pattern %q is invalid: %s", pattern, err)
+		}
+	}
+	return nil
+}
+
+// instanceTypeAllowed reports whether the instance type matches the allow-list.
+// Bare metal sizes are easy to pull in by accident with a family pattern, so they must be listed exactly.
+func instanceTypeAllowed(allowed []string, instanceType string) bool {
+	metal := strings.HasSuffix(instanceType, ".metal") || strings.Contains(instanceType, ".metal-")
+
+	for _, pattern := range allowed {
+		if pattern == instanceType {
+			return true
+		}
+		if ok, _ := path.Match(pattern, instanceType); ok && !metal {
+			return true
+		}
+	}
+	return false
+}
+
+// Kb4InstanceTypesRule checks that literal instance types come from the allow-list
+type Kb4InstanceTypesRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4InstanceTypesRule())
+}
+
+// NewKb4InstanceTypesRule returns a new rule
+func NewKb4InstanceTypesRule() *Kb4InstanceTypesRule {
+	return &Kb4InstanceTypesRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4InstanceTypesRule) Name() string {
+	return "kb4_instance_types"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4InstanceTypesRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4InstanceTypesRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4InstanceTypesRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4InstanceTypesRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Literal instance types in `aws_instance`, `aws_launch_template` and `aws_eks_node_group` resources must match the allow-list, which the org policy's `instance_types` replace. Bare metal sizes must be listed exactly, since family patterns don't allow them.",
+		Categories:  []string{CategoryCost},
+		Anchor:      "compute",
+		Example: `
+resource "aws_instance" "bastion" {
+  instance_type = "m4.large"
+}`,
+		Config: newKb4InstanceTypesRuleConfig(),
+	}
+}
+
+// instanceType is a literal instance type found in a resource
+type instanceType struct {
+	value string
+	rng   hcl.Range
+}
+
+// Check emits an issue for every literal instance type outside the allow-list
+func (r *Kb4InstanceTypesRule) Check(runner tflint.Runner) error {
+	config := newKb4InstanceTypesRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	allowed := orgPolicy(runner).InstanceTypes
+	if len(allowed) == 0 {
+		allowed = config.AllowedInstanceTypes
+	}
+
+	found := []instanceType{}
+	for resourceType, argument := range instanceTypeAttributes {
+		content, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: argument}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range content.Blocks {
+			attr, ok := resource.Body.Attributes[argument]
+			if !ok {
+				continue
+			}
+			expr, ok := attr.Expr.(hclsyntax.Expression)
+			if !ok {
+				continue
+			}
+
+			exprs := []hclsyntax.Expression{expr}
+			if tuple, ok := expr.(*hclsyntax.TupleConsExpr); ok {
+				exprs = tuple.Exprs
+			}
+			for _, expr := range exprs {
+				if value, ok := literalString(expr); ok {
+					found = append(found, instanceType{value, expr.Range()})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return rangeLess(found[i].rng, found[j].rng)
+	})
+
+	for _, f := range found {
+		if instanceTypeAllowed(allowed, f.value) {
+			continue
+		}
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("`%s` is not an approved instance type; use one of %s", f.value, strings.Join(allowed, ", ")),
+			f.rng,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
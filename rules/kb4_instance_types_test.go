@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_Kb4InstanceTypesRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Policy   *Policy
+		Expected helper.Issues
+	}{
+		{
+			Name: "approved types",
+			Content: `
+variable "instance_type" {}
+
+resource "aws_instance" "bastion" {
+  instance_type = "t3.micro"
+}
+
+resource "aws_launch_template" "workers" {
+  instance_type = var.instance_type
+}
+
+resource "aws_eks_node_group" "workers" {
+  instance_types = ["m6i.large", "m6a.large"]
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "unapproved types",
+			Content: `
+resource "aws_instance" "bastion" {
+  instance_type = "m4.large"
+}
+
+resource "aws_eks_node_group" "workers" {
+  instance_types = ["m6i.large", "m6i.metal"]
+}`,
+			Config: `
+rule "kb4_instance_types" {
+  enabled                = true
+  allowed_instance_types = ["t3.*", "m6i.*"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4InstanceTypesRule(),
+					Message: "`m4.large` is not an approved instance type; use one of t3.*, m6i.*",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 19},
+						End:      hcl.Pos{Line: 3, Column: 29},
+					},
+				},
+				{
+					Rule:    NewKb4InstanceTypesRule(),
+					Message: "`m6i.metal` is not an approved instance type; use one of t3.*, m6i.*",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 34},
+						End:      hcl.Pos{Line: 7, Column: 45},
+					},
+				},
+			},
+		},
+		{
+			Name: "org policy",
+			Content: `
+resource "aws_instance" "bastion" {
+  instance_type = "t3.micro"
+}
+
+resource "aws_instance" "gpu" {
+  instance_type = "g5.metal"
+}`,
+			Policy: &Policy{InstanceTypes: []string{"g5.metal"}},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4InstanceTypesRule(),
+					Message: "`t3.micro` is not an approved instance type; use one of g5.metal",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 19},
+						End:      hcl.Pos{Line: 3, Column: 29},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4InstanceTypesRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			testRunner := helper.TestRunner(t, files)
+
+			var runner tflint.Runner = testRunner
+			if tc.Policy != nil {
+				config := DefaultConfig()
+				config.policy = tc.Policy
+				runner = NewRunner(testRunner, config)
+			}
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, testRunner.Issues)
+		})
+	}
+}
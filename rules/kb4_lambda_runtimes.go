@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4LambdaRuntimesRule checks that Lambda functions use a runtime the org policy allows
+type Kb4LambdaRuntimesRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4LambdaRuntimesRule())
+}
+
+// NewKb4LambdaRuntimesRule returns a new rule
+func NewKb4LambdaRuntimesRule() *Kb4LambdaRuntimesRule {
+	return &Kb4LambdaRuntimesRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4LambdaRuntimesRule) Name() string {
+	return "kb4_lambda_runtimes"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4LambdaRuntimesRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4LambdaRuntimesRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4LambdaRuntimesRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4LambdaRuntimesRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "The `runtime` of `aws_lambda_function` resources must be one of the org policy's `allowed_runtimes` under `lambda`, so functions move off runtimes that lose security patches. Runtimes that aren't known until apply are skipped. Without `allowed_runtimes.lambda` the rule checks nothing.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "compute",
+		Example: `
+# policy.yaml: allowed_runtimes: { lambda: [python3.9, nodejs16.x] }
+resource "aws_lambda_function" "report" {
+  runtime = "python3.6"
+}`,
+	}
+}
+
+// Check emits an issue for every Lambda function whose runtime isn't allowed
+func (r *Kb4LambdaRuntimesRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	allowed := orgPolicy(runner).AllowedRuntimes["lambda"]
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	content, err := runner.GetResourceContent("aws_lambda_function", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "runtime"}},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	functions := append([]*hclext.Block{}, content.Blocks...)
+	sort.SliceStable(functions, func(i, j int) bool {
+		return rangeLess(functions[i].DefRange, functions[j].DefRange)
+	})
+
+	for _, function := range functions {
+		attr, ok := function.Body.Attributes["runtime"]
+		if !ok {
+			continue
+		}
+
+		function := function
+		if err := evaluateString(runner, attr.Expr, func(runtime string) error {
+			if containsString(allowed, runtime) {
+				return nil
+			}
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s uses runtime `%s`, which isn't allowed; use one of %s", describeBlock(function), runtime, strings.Join(allowed, ", ")),
+				attr.Expr.Range(),
+			)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
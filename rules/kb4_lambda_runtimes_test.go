@@ -0,0 +1,94 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_Kb4LambdaRuntimesRule(t *testing.T) {
+	content := `
+variable "runtime" {
+  default = "nodejs12.x"
+}
+
+resource "aws_lambda_function" "api" {
+  runtime = "python3.9"
+}
+
+resource "aws_lambda_function" "report" {
+  runtime = "python3.6"
+}
+
+resource "aws_lambda_function" "worker" {
+  runtime = var.runtime
+}
+
+resource "aws_lambda_function" "image" {
+  package_type = "Image"
+}`
+
+	cases := []struct {
+		Name     string
+		Policy   *Policy
+		Expected helper.Issues
+	}{
+		{
+			Name:     "no allowed runtimes",
+			Expected: helper.Issues{},
+		},
+		{
+			Name:     "no lambda runtimes",
+			Policy:   &Policy{AllowedRuntimes: map[string][]string{}},
+			Expected: helper.Issues{},
+		},
+		{
+			Name:   "unapproved runtimes",
+			Policy: &Policy{AllowedRuntimes: map[string][]string{"lambda": {"python3.9", "nodejs16.x"}}},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4LambdaRuntimesRule(),
+					Message: "resource \"aws_lambda_function\" \"report\" uses runtime `python3.6`, which isn't allowed; use one of python3.9, nodejs16.x",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 13},
+						End:      hcl.Pos{Line: 11, Column: 24},
+					},
+				},
+				{
+					Rule:    NewKb4LambdaRuntimesRule(),
+					Message: "resource \"aws_lambda_function\" \"worker\" uses runtime `nodejs12.x`, which isn't allowed; use one of python3.9, nodejs16.x",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 15, Column: 13},
+						End:      hcl.Pos{Line: 15, Column: 24},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4LambdaRuntimesRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			testRunner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+			var runner tflint.Runner = testRunner
+			if tc.Policy != nil {
+				config := DefaultConfig()
+				config.policy = tc.Policy
+				runner = NewRunner(testRunner, config)
+			}
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, testRunner.Issues)
+		})
+	}
+}
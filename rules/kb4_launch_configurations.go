@@ -0,0 +1,102 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4LaunchConfigurationsRule checks that instances are launched from launch templates rather than deprecated launch configurations
+type Kb4LaunchConfigurationsRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4LaunchConfigurationsRule())
+}
+
+// NewKb4LaunchConfigurationsRule returns a new rule
+func NewKb4LaunchConfigurationsRule() *Kb4LaunchConfigurationsRule {
+	return &Kb4LaunchConfigurationsRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4LaunchConfigurationsRule) Name() string {
+	return "kb4_launch_configurations"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4LaunchConfigurationsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4LaunchConfigurationsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4LaunchConfigurationsRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4LaunchConfigurationsRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`aws_launch_configuration` resources, and `aws_autoscaling_group` resources that set `launch_configuration`, are not allowed since AWS has deprecated launch configurations. Use `aws_launch_template` instead.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "compute",
+		Example: `
+resource "aws_launch_configuration" "workers" {
+  image_id      = var.ami_id
+  instance_type = "m6i.large"
+}`,
+	}
+}
+
+// Check emits an issue for every launch configuration and every autoscaling group launching from one
+func (r *Kb4LaunchConfigurationsRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	configurations, err := runner.GetResourceContent("aws_launch_configuration", &hclext.BodySchema{}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, configuration := range configurations.Blocks {
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s is deprecated by AWS; use an aws_launch_template", describeBlock(configuration)),
+			configuration.DefRange,
+		); err != nil {
+			return err
+		}
+	}
+
+	groups, err := runner.GetResourceContent("aws_autoscaling_group", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "launch_configuration"}},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups.Blocks {
+		attr, ok := group.Body.Attributes["launch_configuration"]
+		if !ok {
+			continue
+		}
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s launches from a launch configuration; use a launch_template block instead", describeBlock(group)),
+			attr.Range,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
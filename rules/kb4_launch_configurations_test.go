@@ -0,0 +1,73 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4LaunchConfigurationsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "launch template",
+			Content: `
+resource "aws_launch_template" "workers" {}
+
+resource "aws_autoscaling_group" "workers" {
+  launch_template {
+    id = aws_launch_template.workers.id
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "launch configuration",
+			Content: `
+resource "aws_launch_configuration" "workers" {}
+
+resource "aws_autoscaling_group" "workers" {
+  launch_configuration = aws_launch_configuration.workers.name
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4LaunchConfigurationsRule(),
+					Message: "resource \"aws_launch_configuration\" \"workers\" is deprecated by AWS; use an aws_launch_template",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 46},
+					},
+				},
+				{
+					Rule:    NewKb4LaunchConfigurationsRule(),
+					Message: "resource \"aws_autoscaling_group\" \"workers\" launches from a launch configuration; use a launch_template block instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 63},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4LaunchConfigurationsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kb4LbListenerTLSRuleConfig is the rule's .tflint.hcl config
+type Kb4LbListenerTLSRuleConfig struct {
+	ApprovedSSLPolicies []string `hclext:"approved_ssl_policies,optional" doc:"SSL policies HTTPS and TLS listeners may use. Setting it replaces the defaults."`
+}
+
+func newKb4LbListenerTLSRuleConfig() *Kb4LbListenerTLSRuleConfig {
+	return &Kb4LbListenerTLSRuleConfig{
+		ApprovedSSLPolicies: []string{
+			"ELBSecurityPolicy-TLS13-1-2-2021-06",
+			"ELBSecurityPolicy-TLS13-1-2-Res-2021-06",
+			"ELBSecurityPolicy-TLS-1-2-2017-01",
+			"ELBSecurityPolicy-TLS-1-2-Ext-2018-06",
+			"ELBSecurityPolicy-FS-1-2-Res-2020-10",
+		},
+	}
+}
+
+// Validate rejects an empty allow-list, which would reject every listener
+func (c *Kb4LbListenerTLSRuleConfig) Validate() error {
+	if len(c.ApprovedSSLPolicies) == 0 {
+		return fmt.Errorf("approved_ssl_policies must list at least one policy")
+	}
+	return nil
+}
+
+// Kb4LbListenerTLSRule checks that load balancer listeners only serve TLS, with an approved SSL policy
+type Kb4LbListenerTLSRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4LbListenerTLSRule())
+}
+
+// NewKb4LbListenerTLSRule returns a new rule
+func NewKb4LbListenerTLSRule() *Kb4LbListenerTLSRule {
+	return &Kb4LbListenerTLSRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4LbListenerTLSRule) Name() string {
+	return "kb4_lb_listener_tls"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4LbListenerTLSRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4LbListenerTLSRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4LbListenerTLSRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4LbListenerTLSRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`aws_lb_listener` resources must not serve plain HTTP on port 80 or with `protocol = \"HTTP\"`, unless the default action redirects to HTTPS. HTTPS and TLS listeners must set `ssl_policy` to one of `approved_ssl_policies`.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "encryption",
+		Example: `
+resource "aws_lb_listener" "http" {
+  port     = 80
+  protocol = "HTTP"
+
+  default_action {
+    type             = "forward"
+    target_group_arn = aws_lb_target_group.api.arn
+  }
+}`,
+		Config: newKb4LbListenerTLSRuleConfig(),
+	}
+}
+
+// Check emits issues for plain HTTP listeners that don't redirect to HTTPS and TLS listeners without an approved SSL policy
+func (r *Kb4LbListenerTLSRule) Check(runner tflint.Runner) error {
+	config := newKb4LbListenerTLSRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetResourceContent("aws_lb_listener", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "port"}, {Name: "protocol"}, {Name: "ssl_policy"}},
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "default_action",
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "type"}},
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "redirect",
+							Body: &hclext.BodySchema{
+								Attributes: []hclext.AttributeSchema{{Name: "protocol"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, listener := range content.Blocks {
+		protocol := ""
+		if attr, ok := listener.Body.Attributes["protocol"]; ok {
+			err := evaluateString(runner, attr.Expr, func(value string) error {
+				protocol = strings.ToUpper(value)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		port := false
+		if attr, ok := listener.Body.Attributes["port"]; ok {
+			var value cty.Value
+			err := runner.EvaluateExpr(attr.Expr, &value, nil)
+			err = runner.EnsureNoError(err, func() error {
+				port = value.IsKnown() && !value.IsNull() && value.Equals(cty.NumberIntVal(80)).True()
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case protocol == "HTTP" || port:
+			redirects, err := r.redirectsToHTTPS(runner, listener)
+			if err != nil {
+				return err
+			}
+			if redirects {
+				continue
+			}
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s serves plain HTTP; use HTTPS, or redirect to HTTPS", describeBlock(listener)),
+				listener.DefRange,
+			); err != nil {
+				return err
+			}
+
+		case protocol == "HTTPS" || protocol == "TLS":
+			attr, ok := listener.Body.Attributes["ssl_policy"]
+			if !ok {
+				if err := runner.EmitIssue(
+					r,
+					fmt.Sprintf("%s should set ssl_policy to one of %s", describeBlock(listener), strings.Join(config.ApprovedSSLPolicies, ", ")),
+					listener.DefRange,
+				); err != nil {
+					return err
+				}
+				continue
+			}
+
+			err := evaluateString(runner, attr.Expr, func(value string) error {
+				if containsString(config.ApprovedSSLPolicies, value) {
+					return nil
+				}
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("`%s` is not an approved SSL policy; use one of %s", value, strings.Join(config.ApprovedSSLPolicies, ", ")),
+					attr.Expr.Range(),
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// redirectsToHTTPS reports whether the listener's default action is a redirect to HTTPS
+func (r *Kb4LbListenerTLSRule) redirectsToHTTPS(runner tflint.Runner, listener *hclext.Block) (bool, error) {
+	redirects := false
+
+	for _, action := range listener.Body.Blocks {
+		for _, redirect := range action.Body.Blocks {
+			attr, ok := redirect.Body.Attributes["protocol"]
+			if !ok {
+				continue
+			}
+			err := evaluateString(runner, attr.Expr, func(value string) error {
+				redirects = redirects || strings.ToUpper(value) == "HTTPS"
+				return nil
+			})
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+
+	return redirects, nil
+}
@@ -0,0 +1,138 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4LbListenerTLSRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "https and redirect",
+			Content: `
+resource "aws_lb_listener" "https" {
+  port       = 443
+  protocol   = "HTTPS"
+  ssl_policy = "ELBSecurityPolicy-TLS13-1-2-2021-06"
+}
+
+resource "aws_lb_listener" "http" {
+  port     = 80
+  protocol = "HTTP"
+
+  default_action {
+    type = "redirect"
+
+    redirect {
+      port        = "443"
+      protocol    = "HTTPS"
+      status_code = "HTTP_301"
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "plain http",
+			Content: `
+resource "aws_lb_listener" "http" {
+  port     = 80
+  protocol = "HTTP"
+
+  default_action {
+    type = "forward"
+  }
+}
+
+resource "aws_lb_listener" "api" {
+  port     = 8080
+  protocol = "HTTP"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4LbListenerTLSRule(),
+					Message: "resource \"aws_lb_listener\" \"http\" serves plain HTTP; use HTTPS, or redirect to HTTPS",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 34},
+					},
+				},
+				{
+					Rule:    NewKb4LbListenerTLSRule(),
+					Message: "resource \"aws_lb_listener\" \"api\" serves plain HTTP; use HTTPS, or redirect to HTTPS",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 1},
+						End:      hcl.Pos{Line: 11, Column: 33},
+					},
+				},
+			},
+		},
+		{
+			Name: "ssl policies",
+			Content: `
+resource "aws_lb_listener" "https" {
+  port       = 443
+  protocol   = "HTTPS"
+  ssl_policy = "ELBSecurityPolicy-2016-08"
+}
+
+resource "aws_lb_listener" "tls" {
+  port     = 5432
+  protocol = "TLS"
+}`,
+			Config: `
+rule "kb4_lb_listener_tls" {
+  enabled               = true
+  approved_ssl_policies = ["ELBSecurityPolicy-TLS13-1-2-2021-06"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4LbListenerTLSRule(),
+					Message: "`ELBSecurityPolicy-2016-08` is not an approved SSL policy; use one of ELBSecurityPolicy-TLS13-1-2-2021-06",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 16},
+						End:      hcl.Pos{Line: 5, Column: 43},
+					},
+				},
+				{
+					Rule:    NewKb4LbListenerTLSRule(),
+					Message: "resource \"aws_lb_listener\" \"tls\" should set ssl_policy to one of ELBSecurityPolicy-TLS13-1-2-2021-06",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 1},
+						End:      hcl.Pos{Line: 8, Column: 33},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4LbListenerTLSRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
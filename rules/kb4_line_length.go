@@ -0,0 +1,153 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4LineLengthRuleConfig is the rule's .tflint.hcl config
+type Kb4LineLengthRuleConfig struct {
+	MaxLength int `hclext:"max_length,optional" doc:"Longest line allowed, in characters."`
+}
+
+func newKb4LineLengthRuleConfig() *Kb4LineLengthRuleConfig {
+	return &Kb4LineLengthRuleConfig{MaxLength: 120}
+}
+
+// Validate rejects a limit below one
+func (c *Kb4LineLengthRuleConfig) Validate() error {
+	if c.MaxLength < 1 {
+		return fmt.Errorf("max_length must be at least 1")
+	}
+	return nil
+}
+
+// Kb4LineLengthRule checks that lines in .tf files stay short enough to review
+type Kb4LineLengthRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4LineLengthRule())
+}
+
+// NewKb4LineLengthRule returns a new rule
+func NewKb4LineLengthRule() *Kb4LineLengthRule {
+	return &Kb4LineLengthRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4LineLengthRule) Name() string {
+	return "kb4_line_length"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4LineLengthRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4LineLengthRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4LineLengthRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4LineLengthRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Lines in `.tf` files must not be longer than `max_length` characters. `terraform fmt` doesn't wrap long expressions, so split them over several lines or name their parts in locals. Comment lines holding a URL are allowed to run long, since URLs can't be wrapped, and files matching the plugin's `exclude_files` are skipped.",
+		Categories:  []string{CategoryStyle},
+		Anchor:      "formatting",
+		Example: `
+locals {
+  subnet_ids = concat(data.aws_subnets.private.ids, data.aws_subnets.public.ids, data.aws_subnets.database.ids, var.extra_subnet_ids)
+}`,
+		Config: newKb4LineLengthRuleConfig(),
+	}
+}
+
+// Check emits an issue for every line longer than the limit, except comment lines with a URL
+func (r *Kb4LineLengthRule) Check(runner tflint.Runner) error {
+	config := newKb4LineLengthRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if strings.HasSuffix(name, ".tf") && !ruleSetConfig(runner).excludesFile(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		src := files[name].Bytes
+		comments := commentLines(src, name)
+
+		offset := 0
+		for i, line := range bytes.Split(src, []byte("\n")) {
+			start := offset
+			offset += len(line) + 1
+
+			line = bytes.TrimSuffix(line, []byte("\r"))
+			length := utf8.RuneCount(line)
+			if length <= config.MaxLength || comments[i+1] && bytes.Contains(line, []byte("://")) {
+				continue
+			}
+
+			// The issue covers the characters past the limit
+			over := len(string([]rune(string(line))[:config.MaxLength]))
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("Line is %d characters long, more than the %d allowed", length, config.MaxLength),
+				hcl.Range{
+					Filename: name,
+					Start:    hcl.Pos{Line: i + 1, Column: config.MaxLength + 1, Byte: start + over},
+					End:      hcl.Pos{Line: i + 1, Column: length + 1, Byte: start + len(line)},
+				},
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// commentLines returns the lines of src that a comment is on
+func commentLines(src []byte, filename string) map[int]bool {
+	lines := map[int]bool{}
+	tokens, _ := hclsyntax.LexConfig(src, filename, hcl.InitialPos)
+	for _, token := range tokens {
+		if token.Type != hclsyntax.TokenComment {
+			continue
+		}
+		// Line comments end with their newline, which puts the end of the token on the next line
+		end := token.Range.End.Line
+		if bytes.HasSuffix(token.Bytes, []byte("\n")) {
+			end--
+		}
+		for line := token.Range.Start.Line; line <= end; line++ {
+			lines[line] = true
+		}
+	}
+	return lines
+}
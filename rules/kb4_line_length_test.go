@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4LineLengthRule(t *testing.T) {
+	long := strings.Repeat("a", 110)
+
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "short lines and long URLs",
+			Files: map[string]string{
+				"main.tf": fmt.Sprintf(`
+# See https://docs.aws.amazon.com/AmazonS3/latest/userguide/%s.html
+locals {
+  name = "short" // https://example.com/%s
+}
+`, long, long),
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "long lines",
+			Files: map[string]string{
+				"main.tf": fmt.Sprintf(`
+# A long comment without a link %s
+locals {
+  name = "%s"
+  city = "Zürich-%s"
+}
+`, long, long, long),
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4LineLengthRule(),
+					Message: "Line is 142 characters long, more than the 120 allowed",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 121},
+						End:      hcl.Pos{Line: 2, Column: 143},
+					},
+				},
+				{
+					Rule:    NewKb4LineLengthRule(),
+					Message: "Line is 121 characters long, more than the 120 allowed",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 121},
+						End:      hcl.Pos{Line: 4, Column: 122},
+					},
+				},
+				{
+					Rule:    NewKb4LineLengthRule(),
+					Message: "Line is 128 characters long, more than the 120 allowed",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 121},
+						End:      hcl.Pos{Line: 5, Column: 129},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured length",
+			Files: map[string]string{
+				"main.tf": `
+locals {
+  name = "a name that is long enough"
+}
+`,
+			},
+			Config: `
+rule "kb4_line_length" {
+  enabled    = true
+  max_length = 30
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4LineLengthRule(),
+					Message: "Line is 37 characters long, more than the 30 allowed",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 31},
+						End:      hcl.Pos{Line: 3, Column: 38},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4LineLengthRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{}
+			for name, src := range tc.Files {
+				files[name] = src
+			}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// secretTokenPattern matches strings made of the characters keys and tokens are usually encoded with
+var secretTokenPattern = regexp.MustCompile(`^[A-Za-z0-9+/=_-]+$`)
+
+// awsResourceIDPattern matches AWS resource IDs like ami-0123456789abcdef0, which are random but not secret
+var awsResourceIDPattern = regexp.MustCompile(`^[a-z]+(-[a-z]+)*-[0-9a-f]{8,}$`)
+
+// nonSecretSuffixes mark attributes that name, size or point at a secret rather than hold it, e.g. secret_id or password_length
+var nonSecretSuffixes = []string{"_arn", "_id", "_name", "_length", "_version", "_enabled", "_policy"}
+
+// Kb4LiteralSecretsRuleConfig is the rule's .tflint.hcl config
+type Kb4LiteralSecretsRuleConfig struct {
+	SecretAttributes []string `hclext:"secret_attributes,optional" doc:"Substrings of attribute and object key names that hold secrets. Setting it replaces the defaults."`
+	MinEntropy       float64  `hclext:"min_entropy,optional" doc:"Shannon entropy in bits per character above which a string literal looks like a key or token."`
+	MinLength        int      `hclext:"min_length,optional" doc:"Shortest string literal the entropy check applies to."`
+}
+
+func newKb4LiteralSecretsRuleConfig() *Kb4LiteralSecretsRuleConfig {
+	return &Kb4LiteralSecretsRuleConfig{
+		SecretAttributes: []string{"password", "passwd", "secret", "token", "api_key", "apikey", "private_key", "access_key"},
+		MinEntropy:       4.0,
+		MinLength:        20,
+	}
+}
+
+// Validate rejects entropy thresholds no string can reach and lengths below one
+func (c *Kb4LiteralSecretsRuleConfig) Validate() error {
+	if c.MinEntropy <= 0 || c.MinEntropy > 8 {
+		return fmt.Errorf("min_entropy must be between 0 and 8 bits per character")
+	}
+	if c.MinLength < 1 {
+		return fmt.Errorf("min_length must be at least 1")
+	}
+	return nil
+}
+
+// holdsSecret reports whether an attribute or key name says it holds a secret
+func (c *Kb4LiteralSecretsRuleConfig) holdsSecret(name string) bool {
+	name = strings.ToLower(name)
+	for _, suffix := range nonSecretSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return false
+		}
+	}
+	for _, attr := range c.SecretAttributes {
+		if strings.Contains(name, strings.ToLower(attr)) {
+			return true
+		}
+	}
+	return false
+}
+
+// looksRandom reports whether a string literal is long and random enough to be a key or token.
+// Names and AWS resource IDs are random-looking too, so the value must also mix letters and digits and not be an ID.
+func (c *Kb4LiteralSecretsRuleConfig) looksRandom(value string) bool {
+	if len(value) < c.MinLength || !secretTokenPattern.MatchString(value) || awsResourceIDPattern.MatchString(value) {
+		return false
+	}
+	hasDigit := strings.ContainsAny(value, "0123456789")
+	hasLetter := strings.ToLower(value) != strings.ToUpper(value)
+	if !hasDigit || !hasLetter {
+		return false
+	}
+	return shannonEntropy(value) >= c.MinEntropy
+}
+
+// shannonEntropy returns the Shannon entropy of s in bits per character
+func shannonEntropy(s string) float64 {
+	counts := map[rune]int{}
+	total := 0
+	for _, c := range s {
+		counts[c]++
+		total++
+	}
+
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// Kb4LiteralSecretsRule checks for probable secrets hard-coded in resource arguments
+type Kb4LiteralSecretsRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4LiteralSecretsRule())
+}
+
+// NewKb4LiteralSecretsRule returns a new rule
+func NewKb4LiteralSecretsRule() *Kb4LiteralSecretsRule {
+	return &Kb4LiteralSecretsRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4LiteralSecretsRule) Name() string {
+	return "kb4_literal_secrets"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4LiteralSecretsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4LiteralSecretsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4LiteralSecretsRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4LiteralSecretsRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Resource arguments must not hard-code secrets. String literals are flagged when the attribute or object key is named like a secret, e.g. `password` or `api_key`, or when they are long, token-like and high in entropy. False positives can be exempted with a `# kb4:exempt kb4_literal_secrets <justification>` comment on the line above.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "secrets",
+		Example: `
+resource "aws_db_instance" "main" {
+  password = "hunter2hunter2"
+}`,
+		Config: newKb4LiteralSecretsRuleConfig(),
+	}
+}
+
+// literalSecret is a string literal that looks like a secret
+type literalSecret struct {
+	name        string
+	entropy     bool
+	rng         hcl.Range
+	unjustified bool
+}
+
+// Check emits an issue for every string literal in a resource that looks like a secret and isn't exempt
+func (r *Kb4LiteralSecretsRule) Check(runner tflint.Runner) error {
+	config := newKb4LiteralSecretsRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	exempt, err := loadExemptions(runner, r)
+	if err != nil {
+		return err
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+
+	secrets := []literalSecret{}
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "resource" {
+				continue
+			}
+			resource := block.DefRange()
+
+			walkSecretCandidates(block.Body, func(name string, expr hclsyntax.Expression) {
+				value, ok := literalString(expr)
+				if !ok || value == "" {
+					return
+				}
+
+				secret := literalSecret{name: name, rng: expr.Range()}
+				switch {
+				case config.holdsSecret(name):
+				case config.looksRandom(value):
+					secret.entropy = true
+				default:
+					return
+				}
+
+				ok, unjustified := exempt.applies(expr.Range(), resource)
+				if ok {
+					return
+				}
+				secret.unjustified = unjustified
+				secrets = append(secrets, secret)
+			})
+		}
+	}
+
+	sort.SliceStable(secrets, func(i, j int) bool {
+		return rangeLess(secrets[i].rng, secrets[j].rng)
+	})
+
+	for _, secret := range secrets {
+		message := fmt.Sprintf("`%s` looks like a hard-coded secret; read it from Secrets Manager or SSM instead", secret.name)
+		if secret.entropy {
+			message = fmt.Sprintf("`%s` is set to a high-entropy string that looks like a key or token; read it from Secrets Manager or SSM instead", secret.name)
+		}
+
+		if err := runner.EmitIssue(r, exemptionMessage(message, secret.unjustified), secret.rng); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkSecretCandidates calls fn for every attribute in the body and its nested blocks,
+// and for every value in object constructors, with the name of the attribute or key it is assigned to
+func walkSecretCandidates(body *hclsyntax.Body, fn func(name string, expr hclsyntax.Expression)) {
+	for name, attr := range body.Attributes {
+		walkSecretExpr(name, attr.Expr, fn)
+	}
+	for _, block := range body.Blocks {
+		walkSecretCandidates(block.Body, fn)
+	}
+}
+
+func walkSecretExpr(name string, expr hclsyntax.Expression, fn func(name string, expr hclsyntax.Expression)) {
+	switch expr := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		for _, item := range expr.Items {
+			key := name
+			if k, ok := item.KeyExpr.(*hclsyntax.ObjectConsKeyExpr); ok {
+				if root := hcl.ExprAsKeyword(k.Wrapped); root != "" {
+					key = root
+				} else if value, ok := literalString(k.Wrapped); ok {
+					key = value
+				}
+			}
+			walkSecretExpr(key, item.ValueExpr, fn)
+		}
+	case *hclsyntax.TupleConsExpr:
+		for _, elem := range expr.Exprs {
+			walkSecretExpr(name, elem, fn)
+		}
+	default:
+		fn(name, expr)
+	}
+}
@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4LiteralSecretsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no secrets",
+			Content: `
+variable "db_password" {}
+
+resource "aws_db_instance" "main" {
+  password = var.db_password
+  name     = "my-application-bucket-logs"
+}
+
+resource "aws_instance" "bastion" {
+  ami       = "ami-0123456789abcdef0"
+  subnet_id = "subnet-0a1b2c3d4e5f67890"
+}
+
+resource "aws_secretsmanager_secret_version" "api" {
+  secret_id = "prod/api/token"
+}
+
+resource "random_password" "db" {
+  length = 24
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "named secrets",
+			Content: `
+resource "aws_db_instance" "main" {
+  password = "hunter2hunter2"
+}
+
+resource "aws_lambda_function" "api" {
+  environment {
+    variables = {
+      STRIPE_API_KEY = "not-a-real-key"
+      LOG_LEVEL      = "info"
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4LiteralSecretsRule(),
+					Message: "`password` looks like a hard-coded secret; read it from Secrets Manager or SSM instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 14},
+						End:      hcl.Pos{Line: 3, Column: 30},
+					},
+				},
+				{
+					Rule:    NewKb4LiteralSecretsRule(),
+					Message: "`STRIPE_API_KEY` looks like a hard-coded secret; read it from Secrets Manager or SSM instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 24},
+						End:      hcl.Pos{Line: 9, Column: 40},
+					},
+				},
+			},
+		},
+		{
+			Name: "high entropy",
+			Content: `
+resource "datadog_integration_aws" "main" {
+  external_id = "ignored"
+  role        = "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4LiteralSecretsRule(),
+					Message: "`role` is set to a high-entropy string that looks like a key or token; read it from Secrets Manager or SSM instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 17},
+						End:      hcl.Pos{Line: 4, Column: 59},
+					},
+				},
+			},
+		},
+		{
+			Name: "exemptions",
+			Content: `
+resource "aws_db_instance" "main" {
+  password = "hunter2hunter2" # kb4:exempt kb4_literal_secrets throwaway local database
+}
+
+resource "aws_db_instance" "replica" {
+  # kb4:exempt kb4_literal_secrets
+  password = "hunter2hunter2"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4LiteralSecretsRule(),
+					Message: "`password` looks like a hard-coded secret; read it from Secrets Manager or SSM instead (the kb4:exempt annotation needs a justification)",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 14},
+						End:      hcl.Pos{Line: 8, Column: 30},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4LiteralSecretsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
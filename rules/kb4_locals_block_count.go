@@ -0,0 +1,152 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4LocalsBlockCountRuleConfig is the rule's .tflint.hcl config
+type Kb4LocalsBlockCountRuleConfig struct {
+	MaxBlocks int `hclext:"max_blocks,optional" doc:"Most locals blocks a file may contain."`
+	// FileMaxBlocks overrides MaxBlocks for the named files, in either syntax
+	FileMaxBlocks map[string]int `hclext:"file_max_blocks,optional" doc:"Limits for specific files that override max_blocks, e.g. a higher one for _locals.tf."`
+}
+
+func newKb4LocalsBlockCountRuleConfig() *Kb4LocalsBlockCountRuleConfig {
+	return &Kb4LocalsBlockCountRuleConfig{
+		MaxBlocks:     1,
+		FileMaxBlocks: map[string]int{},
+	}
+}
+
+// Validate rejects limits below one block
+func (c *Kb4LocalsBlockCountRuleConfig) Validate() error {
+	if c.MaxBlocks < 1 {
+		return fmt.Errorf("max_blocks must be at least 1")
+	}
+	for name, max := range c.FileMaxBlocks {
+		if max < 1 {
+			return fmt.Errorf("file_max_blocks for %s must be at least 1", name)
+		}
+	}
+	return nil
+}
+
+// maxBlocks returns the limit for the file
+func (c *Kb4LocalsBlockCountRuleConfig) maxBlocks(filename string) int {
+	for name, max := range c.FileMaxBlocks {
+		if sameTerraformFile(filename, name) {
+			return max
+		}
+	}
+	return c.MaxBlocks
+}
+
+// Kb4LocalsBlockCountRule checks that files don't scatter their locals over several blocks
+type Kb4LocalsBlockCountRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4LocalsBlockCountRule())
+}
+
+// NewKb4LocalsBlockCountRule returns a new rule
+func NewKb4LocalsBlockCountRule() *Kb4LocalsBlockCountRule {
+	return &Kb4LocalsBlockCountRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4LocalsBlockCountRule) Name() string {
+	return "kb4_locals_block_count"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4LocalsBlockCountRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4LocalsBlockCountRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4LocalsBlockCountRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4LocalsBlockCountRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Files should have a single `locals` block. More are usually a merge artifact, and scatter related values over the file. `file_max_blocks` raises the limit for files that group locals on purpose, such as `_locals.tf`.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "locals",
+		Example: `
+locals {
+  name = "kb4-sre"
+}
+
+resource "aws_s3_bucket" "logs" {}
+
+locals {
+  tags = { Team = "sre" }
+}`,
+		Config: newKb4LocalsBlockCountRuleConfig(),
+	}
+}
+
+// Check emits an issue for every locals block past a file's limit
+func (r *Kb4LocalsBlockCountRule) Check(runner tflint.Runner) error {
+	config := newKb4LocalsBlockCountRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "locals",
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	byFile := map[string][]*hclext.Block{}
+	filenames := []string{}
+	for _, block := range content.Blocks {
+		filename := block.DefRange.Filename
+		if byFile[filename] == nil {
+			filenames = append(filenames, filename)
+		}
+		byFile[filename] = append(byFile[filename], block)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		blocks, max := byFile[filename], config.maxBlocks(filename)
+		if len(blocks) <= max {
+			continue
+		}
+
+		sort.SliceStable(blocks, func(i, j int) bool { return blocks[i].DefRange.Start.Byte < blocks[j].DefRange.Start.Byte })
+		for _, block := range blocks[max:] {
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s has %d locals blocks, more than the %d allowed; merge the %s into the one on line %d", filename, len(blocks), max, describeBlock(block), blocks[0].DefRange.Start.Line),
+				block.DefRange,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4LocalsBlockCountRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "one block per file",
+			Files: map[string]string{
+				"main.tf": `
+locals {
+  name = "kb4-sre"
+}`,
+				"network.tf": `
+locals {
+  cidr = "10.0.0.0/16"
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "extra blocks",
+			Files: map[string]string{
+				"main.tf": `
+locals {
+  name = "kb4-sre"
+}
+
+resource "aws_s3_bucket" "logs" {}
+
+locals {
+  tags = {}
+}
+
+locals {
+  region = "us-east-1"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4LocalsBlockCountRule(),
+					Message: "main.tf has 3 locals blocks, more than the 1 allowed; merge the locals block on line 8 into the one on line 2",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 1},
+						End:      hcl.Pos{Line: 8, Column: 7},
+					},
+				},
+				{
+					Rule:    NewKb4LocalsBlockCountRule(),
+					Message: "main.tf has 3 locals blocks, more than the 1 allowed; merge the locals block on line 12 into the one on line 2",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 12, Column: 1},
+						End:      hcl.Pos{Line: 12, Column: 7},
+					},
+				},
+			},
+		},
+		{
+			Name: "file limits",
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "kb4_locals_block_count" {
+  enabled         = true
+  file_max_blocks = { "_locals.tf" = 2 }
+}`,
+				"_locals.tf": `
+locals {
+  name = "kb4-sre"
+}
+
+locals {
+  tags = {}
+}
+
+locals {
+  region = "us-east-1"
+}`,
+				"main.tf": `
+locals {
+  name = "kb4-sre"
+}
+
+locals {
+  tags = {}
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4LocalsBlockCountRule(),
+					Message: "_locals.tf has 3 locals blocks, more than the 2 allowed; merge the locals block on line 10 into the one on line 2",
+					Range: hcl.Range{
+						Filename: "_locals.tf",
+						Start:    hcl.Pos{Line: 10, Column: 1},
+						End:      hcl.Pos{Line: 10, Column: 7},
+					},
+				},
+				{
+					Rule:    NewKb4LocalsBlockCountRule(),
+					Message: "main.tf has 2 locals blocks, more than the 1 allowed; merge the locals block on line 6 into the one on line 2",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 7},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4LocalsBlockCountRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.Files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			assertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4LocalsComplexityRuleConfig is the rule's .tflint.hcl config
+type Kb4LocalsComplexityRuleConfig struct {
+	MaxNodes int `hclext:"max_nodes,optional" doc:"Most expressions a local's value may be made of, counting every reference, literal, call and operator."`
+	MaxDepth int `hclext:"max_depth,optional" doc:"Most levels calls, for expressions, conditionals and collection constructors may be nested in a local's value."`
+}
+
+func newKb4LocalsComplexityRuleConfig() *Kb4LocalsComplexityRuleConfig {
+	return &Kb4LocalsComplexityRuleConfig{
+		MaxNodes: 60,
+		MaxDepth: 4,
+	}
+}
+
+// Validate rejects limits below one
+func (c *Kb4LocalsComplexityRuleConfig) Validate() error {
+	if c.MaxNodes < 1 || c.MaxDepth < 1 {
+		return fmt.Errorf("max_nodes and max_depth must be at least 1")
+	}
+	return nil
+}
+
+// Kb4LocalsComplexityRule checks that local values stay small enough to read
+type Kb4LocalsComplexityRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4LocalsComplexityRule())
+}
+
+// NewKb4LocalsComplexityRule returns a new rule
+func NewKb4LocalsComplexityRule() *Kb4LocalsComplexityRule {
+	return &Kb4LocalsComplexityRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4LocalsComplexityRule) Name() string {
+	return "kb4_locals_complexity"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4LocalsComplexityRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4LocalsComplexityRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4LocalsComplexityRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4LocalsComplexityRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Local values must stay within `max_nodes` expressions and `max_depth` levels of nested calls, for expressions, conditionals and collection constructors. Long `for` and `merge` pipelines read better split into named intermediate locals.",
+		Categories:  []string{CategoryStyle},
+		Anchor:      "locals",
+		Example: `
+locals {
+  subnets = merge([for az, cidrs in var.subnets : { for i, cidr in cidrs : "${az}-${i}" => { az = az, cidr = cidr } }]...)
+}`,
+		Config: newKb4LocalsComplexityRuleConfig(),
+	}
+}
+
+// Check emits an issue for every local value over either limit
+func (r *Kb4LocalsComplexityRule) Check(runner tflint.Runner) error {
+	config := newKb4LocalsComplexityRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	locals, err := localValues(runner)
+	if err != nil {
+		return err
+	}
+
+	for _, local := range locals {
+		measure := &expressionComplexity{}
+		hclsyntax.Walk(local.Expr, measure)
+
+		message := ""
+		switch {
+		case measure.nodes > config.MaxNodes:
+			message = fmt.Sprintf("`%s` local is made of %d expressions, more than the %d allowed; split it into named intermediate locals", local.Name, measure.nodes, config.MaxNodes)
+		case measure.deepest > config.MaxDepth:
+			message = fmt.Sprintf("`%s` local nests expressions %d levels deep, more than the %d allowed; split it into named intermediate locals", local.Name, measure.deepest, config.MaxDepth)
+		default:
+			continue
+		}
+
+		if err := runner.EmitIssue(r, message, local.NameRange); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expressionComplexity is an hclsyntax.Walker counting the expressions in a tree and how deeply the compound ones nest
+type expressionComplexity struct {
+	nodes, depth, deepest int
+}
+
+// Enter counts the node, and descends a level into compound expressions
+func (c *expressionComplexity) Enter(node hclsyntax.Node) hcl.Diagnostics {
+	if _, ok := node.(hclsyntax.Expression); ok {
+		c.nodes++
+	}
+	if compoundExpression(node) {
+		c.depth++
+		if c.depth > c.deepest {
+			c.deepest = c.depth
+		}
+	}
+	return nil
+}
+
+// Exit climbs back out of compound expressions
+func (c *expressionComplexity) Exit(node hclsyntax.Node) hcl.Diagnostics {
+	if compoundExpression(node) {
+		c.depth--
+	}
+	return nil
+}
+
+// compoundExpression reports whether the node is a call, for expression, conditional or collection constructor
+func compoundExpression(node hclsyntax.Node) bool {
+	switch node.(type) {
+	case *hclsyntax.FunctionCallExpr, *hclsyntax.ForExpr, *hclsyntax.ConditionalExpr, *hclsyntax.ObjectConsExpr, *hclsyntax.TupleConsExpr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4LocalsComplexityRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "simple locals",
+			Content: `
+locals {
+  name    = "kb4-${var.team}"
+  tags    = merge(var.tags, { Team = var.team })
+  subnets = { for az, cidr in var.subnets : az => { cidr = cidr } }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "complex locals",
+			Content: `
+locals {
+  subnets = merge([for az, cidrs in var.subnets : { for i, cidr in cidrs : "${az}-${i}" => { az = [az] } }]...)
+  rules = {
+    a = var.a.x.y, b = var.b, c = var.c, d = var.d, e = var.e,
+    f = var.f, g = var.g, h = var.h, i = var.i, j = var.j,
+    k = var.k, l = var.l, m = var.m, n = var.n, o = var.o,
+    p = var.p, q = var.q, r = var.r, s = var.s, t = var.t,
+    u = var.u, v = var.v, w = var.w, x = var.x, y = var.y,
+    z = var.z, aa = var.aa, ab = var.ab, ac = var.ac, ad = var.ad,
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4LocalsComplexityRule(),
+					Message: "`subnets` local nests expressions 5 levels deep, more than the 4 allowed; split it into named intermediate locals",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 10},
+					},
+				},
+				{
+					Rule:    NewKb4LocalsComplexityRule(),
+					Message: "`rules` local is made of 61 expressions, more than the 60 allowed; split it into named intermediate locals",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 8},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured limits",
+			Content: `
+locals {
+  tags = merge(var.tags, { Team = var.team })
+}`,
+			Config: `
+rule "kb4_locals_complexity" {
+  enabled   = true
+  max_nodes = 4
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4LocalsComplexityRule(),
+					Message: "`tags` local is made of 5 expressions, more than the 4 allowed; split it into named intermediate locals",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 7},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4LocalsComplexityRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
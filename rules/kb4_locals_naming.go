@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4LocalsNamingRuleConfig is the rule's .tflint.hcl config
+type Kb4LocalsNamingRuleConfig struct {
+	BannedPrefixes []string `hclext:"banned_prefixes,optional" doc:"Prefixes local names must not start with."`
+	// CanonicalNames maps each variant spelling of a shared local to the name it must use
+	CanonicalNames map[string]string `hclext:"canonical_names,optional" doc:"Variant names of shared locals, each mapped to the canonical name to use instead. Setting it replaces the defaults."`
+}
+
+func newKb4LocalsNamingRuleConfig() *Kb4LocalsNamingRuleConfig {
+	return &Kb4LocalsNamingRuleConfig{
+		BannedPrefixes: []string{"tmp_", "temp_", "x_"},
+		CanonicalNames: map[string]string{
+			"common_tags":     "tags",
+			"default_tags":    "tags",
+			"all_tags":        "tags",
+			"prefix":          "name_prefix",
+			"resource_prefix": "name_prefix",
+			"naming_prefix":   "name_prefix",
+		},
+	}
+}
+
+// Validate rejects empty prefixes and canonical names that aren't snake_case themselves
+func (c *Kb4LocalsNamingRuleConfig) Validate() error {
+	for _, prefix := range c.BannedPrefixes {
+		if prefix == "" {
+			return fmt.Errorf("banned_prefixes must not be empty")
+		}
+	}
+	for variant, canonical := range c.CanonicalNames {
+		if !snakeCasePattern.MatchString(canonical) {
+			return fmt.Errorf("canonical name %q for %q must be snake_case", canonical, variant)
+		}
+	}
+	return nil
+}
+
+// Kb4LocalsNamingRule checks that local names follow the naming conventions
+type Kb4LocalsNamingRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4LocalsNamingRule())
+}
+
+// NewKb4LocalsNamingRule returns a new rule
+func NewKb4LocalsNamingRule() *Kb4LocalsNamingRule {
+	return &Kb4LocalsNamingRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4LocalsNamingRule) Name() string {
+	return "kb4_locals_naming"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4LocalsNamingRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4LocalsNamingRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4LocalsNamingRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4LocalsNamingRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Local names must be snake_case and must not start with a prefix from `banned_prefixes`. Locals shared across modules use their canonical names, so `local.tags` is always the common tags rather than `local.common_tags` in one module and `local.default_tags` in the next.",
+		Categories:  []string{CategoryNaming},
+		Anchor:      "locals",
+		Example: `
+locals {
+  tmp_subnets = ["10.0.0.0/24"]
+  common_tags = { Team = "sre" }
+}`,
+		Config: newKb4LocalsNamingRuleConfig(),
+	}
+}
+
+// Check emits an issue for every local name breaking the convention
+func (r *Kb4LocalsNamingRule) Check(runner tflint.Runner) error {
+	config := newKb4LocalsNamingRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	locals, err := localValues(runner)
+	if err != nil {
+		return err
+	}
+
+	for _, local := range locals {
+		message := ""
+		if !snakeCasePattern.MatchString(local.Name) {
+			message = fmt.Sprintf("`%s` local name should be snake_case", local.Name)
+		} else if canonical, ok := config.CanonicalNames[local.Name]; ok {
+			message = fmt.Sprintf("`%s` local should use the canonical name `%s`", local.Name, canonical)
+		} else {
+			for _, prefix := range config.BannedPrefixes {
+				if strings.HasPrefix(local.Name, prefix) {
+					message = fmt.Sprintf("`%s` local name should not start with %s; name it after what it holds", local.Name, prefix)
+					break
+				}
+			}
+		}
+		if message == "" {
+			continue
+		}
+
+		if err := runner.EmitIssue(r, message, local.NameRange); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4LocalsNamingRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "conventional names",
+			Content: `
+locals {
+  tags        = { Team = "sre" }
+  name_prefix = "kb4-sre"
+  subnets_v2  = ["10.0.0.0/24"]
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "unconventional names",
+			Content: `
+locals {
+  commonTags  = { Team = "sre" }
+  default_tags = { Team = "sre" }
+  tmp_subnets = ["10.0.0.0/24"]
+}
+
+locals {
+  prefix = "kb4-sre"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4LocalsNamingRule(),
+					Message: "`commonTags` local name should be snake_case",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 13},
+					},
+				},
+				{
+					Rule:    NewKb4LocalsNamingRule(),
+					Message: "`default_tags` local should use the canonical name `tags`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 15},
+					},
+				},
+				{
+					Rule:    NewKb4LocalsNamingRule(),
+					Message: "`tmp_subnets` local name should not start with tmp_; name it after what it holds",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 14},
+					},
+				},
+				{
+					Rule:    NewKb4LocalsNamingRule(),
+					Message: "`prefix` local should use the canonical name `name_prefix`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 3},
+						End:      hcl.Pos{Line: 9, Column: 9},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured names",
+			Content: `
+locals {
+  tmp_subnets  = ["10.0.0.0/24"]
+  default_tags = { Team = "sre" }
+  base_tags    = { Team = "sre" }
+}`,
+			Config: `
+rule "kb4_locals_naming" {
+  enabled         = true
+  banned_prefixes = ["base_"]
+  canonical_names = { base_tags = "default_tags" }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4LocalsNamingRule(),
+					Message: "`base_tags` local should use the canonical name `default_tags`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 12},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4LocalsNamingRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
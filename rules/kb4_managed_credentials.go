@@ -0,0 +1,209 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4ManagedCredentialsRuleConfig is the rule's .tflint.hcl config
+type Kb4ManagedCredentialsRuleConfig struct {
+	Attributes []string `hclext:"attributes,optional" doc:"Resource arguments that hold credentials, as type.argument. Setting it replaces the defaults."`
+	Sources    []string `hclext:"sources,optional" doc:"Resource, data source and ephemeral resource types credentials may be read from."`
+}
+
+func newKb4ManagedCredentialsRuleConfig() *Kb4ManagedCredentialsRuleConfig {
+	return &Kb4ManagedCredentialsRuleConfig{
+		Attributes: []string{
+			"aws_db_instance.password",
+			"aws_rds_cluster.master_password",
+			"aws_docdb_cluster.master_password",
+			"aws_redshift_cluster.master_password",
+			"aws_elasticache_replication_group.auth_token",
+			"aws_opensearch_domain.master_user_password",
+		},
+		Sources: []string{"aws_secretsmanager_secret_version", "aws_ssm_parameter", "random_password"},
+	}
+}
+
+// Validate rejects attributes that aren't written as type.argument
+func (c *Kb4ManagedCredentialsRuleConfig) Validate() error {
+	for _, attr := range c.Attributes {
+		if parts := strings.Split(attr, "."); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("attribute %q must be written as type.argument, e.g. aws_db_instance.password", attr)
+		}
+	}
+	return nil
+}
+
+// byType groups the configured arguments by resource type
+func (c *Kb4ManagedCredentialsRuleConfig) byType() map[string][]string {
+	types := map[string][]string{}
+	for _, attr := range c.Attributes {
+		parts := strings.Split(attr, ".")
+		types[parts[0]] = append(types[parts[0]], parts[1])
+	}
+	return types
+}
+
+// Kb4ManagedCredentialsRule checks that credentials are read from a secret store instead of being written into the configuration
+type Kb4ManagedCredentialsRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4ManagedCredentialsRule())
+}
+
+// NewKb4ManagedCredentialsRule returns a new rule
+func NewKb4ManagedCredentialsRule() *Kb4ManagedCredentialsRule {
+	return &Kb4ManagedCredentialsRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4ManagedCredentialsRule) Name() string {
+	return "kb4_managed_credentials"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4ManagedCredentialsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4ManagedCredentialsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4ManagedCredentialsRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4ManagedCredentialsRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Arguments that hold credentials, like `aws_db_instance.password`, must reference one of the `sources`, by default Secrets Manager, SSM parameters or `random_password`, or a variable marked `sensitive` or `ephemeral`.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "secrets",
+		Example: `
+variable "db_password" {}
+
+resource "aws_db_instance" "main" {
+  password = var.db_password
+}`,
+		Config: newKb4ManagedCredentialsRuleConfig(),
+	}
+}
+
+// Check emits an issue for every credential argument that doesn't reference a secret store or a sensitive variable
+func (r *Kb4ManagedCredentialsRule) Check(runner tflint.Runner) error {
+	config := newKb4ManagedCredentialsRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	sensitive, err := r.sensitiveVariables(runner)
+	if err != nil {
+		return err
+	}
+
+	types := config.byType()
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		schema := &hclext.BodySchema{}
+		for _, attr := range types[name] {
+			schema.Attributes = append(schema.Attributes, hclext.AttributeSchema{Name: attr})
+		}
+
+		content, err := runner.GetResourceContent(name, schema, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range content.Blocks {
+			for _, argument := range types[name] {
+				attr, ok := resource.Body.Attributes[argument]
+				if !ok || readsCredential(attr.Expr, config.Sources, sensitive) {
+					continue
+				}
+
+				if err := runner.EmitIssue(
+					r,
+					fmt.Sprintf("%s.%s must come from %s or a sensitive variable", name, argument, strings.Join(config.Sources, ", ")),
+					attr.Expr.Range(),
+				); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// sensitiveVariables returns the variables that set sensitive or ephemeral to true
+func (r *Kb4ManagedCredentialsRule) sensitiveVariables(runner tflint.Runner) (map[string]bool, error) {
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "sensitive"}, {Name: "ephemeral"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sensitive := map[string]bool{}
+	for _, variable := range content.Blocks {
+		for _, attr := range variable.Body.Attributes {
+			name := variable.Labels[0]
+			err := evaluateBool(runner, attr.Expr, func(value bool) error {
+				sensitive[name] = sensitive[name] || value
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return sensitive, nil
+}
+
+// readsCredential reports whether the expression references one of the source types or a sensitive variable
+func readsCredential(expr hcl.Expression, sources []string, sensitive map[string]bool) bool {
+	for _, traversal := range expr.Variables() {
+		switch traversal.RootName() {
+		case "var":
+			if sensitive[traversalAttr(traversal)] {
+				return true
+			}
+		case "data", "ephemeral":
+			if containsString(sources, traversalAttr(traversal)) {
+				return true
+			}
+		default:
+			if containsString(sources, traversal.RootName()) {
+				return true
+			}
+		}
+	}
+	return false
+}
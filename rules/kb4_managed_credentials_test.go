@@ -0,0 +1,122 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4ManagedCredentialsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "managed credentials",
+			Content: `
+variable "db_password" {
+  sensitive = true
+}
+
+resource "aws_db_instance" "main" {
+  password = var.db_password
+}
+
+resource "aws_db_instance" "replica" {
+  password = jsondecode(data.aws_secretsmanager_secret_version.db.secret_string)["password"]
+}
+
+resource "aws_rds_cluster" "main" {
+  master_password = random_password.db.result
+}
+
+resource "aws_elasticache_replication_group" "sessions" {
+  auth_token = ephemeral.aws_ssm_parameter.redis.value
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "unmanaged credentials",
+			Content: `
+variable "db_password" {}
+
+resource "aws_db_instance" "main" {
+  password = var.db_password
+}
+
+resource "aws_rds_cluster" "main" {
+  master_password = "hunter2hunter2"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ManagedCredentialsRule(),
+					Message: "aws_db_instance.password must come from aws_secretsmanager_secret_version, aws_ssm_parameter, random_password or a sensitive variable",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 14},
+						End:      hcl.Pos{Line: 5, Column: 29},
+					},
+				},
+				{
+					Rule:    NewKb4ManagedCredentialsRule(),
+					Message: "aws_rds_cluster.master_password must come from aws_secretsmanager_secret_version, aws_ssm_parameter, random_password or a sensitive variable",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 21},
+						End:      hcl.Pos{Line: 9, Column: 37},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured attributes",
+			Content: `
+resource "aws_mq_broker" "events" {
+  console_password = "hunter2hunter2"
+}
+
+resource "aws_db_instance" "main" {
+  password = "hunter2hunter2"
+}`,
+			Config: `
+rule "kb4_managed_credentials" {
+  enabled    = true
+  attributes = ["aws_mq_broker.console_password"]
+  sources    = ["aws_secretsmanager_secret_version"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ManagedCredentialsRule(),
+					Message: "aws_mq_broker.console_password must come from aws_secretsmanager_secret_version or a sensitive variable",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 22},
+						End:      hcl.Pos{Line: 3, Column: 38},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4ManagedCredentialsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// Kb4ModuleDefaultInputsRule checks that module calls don't pass the called module's defaults
+type Kb4ModuleDefaultInputsRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4ModuleDefaultInputsRule())
+}
+
+// NewKb4ModuleDefaultInputsRule returns a new rule
+func NewKb4ModuleDefaultInputsRule() *Kb4ModuleDefaultInputsRule {
+	return &Kb4ModuleDefaultInputsRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4ModuleDefaultInputsRule) Name() string {
+	return "kb4_module_default_inputs"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4ModuleDefaultInputsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4ModuleDefaultInputsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4ModuleDefaultInputsRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4ModuleDefaultInputsRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Module calls must not pass arguments equal to the called module's default, which only add noise and hide the inputs that matter. The rule only runs with `deep_check = true` in the plugin block. Local modules are read from their source directory and others from `.terraform/modules`, so remote modules are only checked after `terraform init`.",
+		Categories:  []string{CategoryStyle},
+		Anchor:      "dependencies",
+		Example: `
+module "queue" {
+  source = "./modules/queue"
+
+  # The module's own default
+  visibility_timeout = 30
+}`,
+	}
+}
+
+// Check emits an issue for every module argument that equals the variable's literal default
+func (r *Kb4ModuleDefaultInputsRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	if !ruleSetConfig(runner).DeepCheck {
+		return nil
+	}
+
+	calls, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "module",
+				LabelNames: []string{"name"},
+				Body:       &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "source"}}},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	defaults := map[string]map[string]cty.Value{}
+	inputs := map[string]bool{}
+	for _, call := range calls.Blocks {
+		source, ok := call.Body.Attributes["source"]
+		if !ok {
+			continue
+		}
+
+		err := evaluateString(runner, source.Expr, func(raw string) error {
+			dir, ok := calledModuleDir(call.DefRange.Filename, call.Labels[0], raw)
+			if !ok {
+				return nil
+			}
+			found, err := moduleVariableDefaults(dir)
+			if err != nil {
+				logWarn("failed to read %s: %s; skipping %s", dir, err, describeBlock(call))
+				return nil
+			}
+			defaults[call.Labels[0]] = found
+			for name := range found {
+				inputs[name] = true
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	// Every call is read with the inputs of all called modules, so the host is asked once
+	schema := &hclext.BodySchema{}
+	for name := range inputs {
+		schema.Attributes = append(schema.Attributes, hclext.AttributeSchema{Name: name})
+	}
+	sort.Slice(schema.Attributes, func(i, j int) bool { return schema.Attributes[i].Name < schema.Attributes[j].Name })
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{{Type: "module", LabelNames: []string{"name"}, Body: schema}},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, call := range content.Blocks {
+		attrs := make([]*hclext.Attribute, 0, len(call.Body.Attributes))
+		for name, attr := range call.Body.Attributes {
+			if _, ok := defaults[call.Labels[0]][name]; ok {
+				attrs = append(attrs, attr)
+			}
+		}
+		sort.Slice(attrs, func(i, j int) bool { return attrs[i].Range.Start.Byte < attrs[j].Range.Start.Byte })
+
+		for _, attr := range attrs {
+			var value cty.Value
+			err := runner.EvaluateExpr(attr.Expr, &value, nil)
+			err = runner.EnsureNoError(err, func() error {
+				if !restatesDefault(value, defaults[call.Labels[0]][attr.Name]) {
+					return nil
+				}
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("%s passes the module's default for %s; drop the argument", describeBlock(call), attr.Name),
+					attr.Range,
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// restatesDefault reports whether a known argument equals the default once converted to the default's type,
+// the way Terraform would convert it, e.g. "30" for a default of 30
+func restatesDefault(value, def cty.Value) bool {
+	if !value.IsWhollyKnown() {
+		return false
+	}
+	if value.IsNull() || def.IsNull() {
+		return value.IsNull() && def.IsNull()
+	}
+
+	converted, err := convert.Convert(value, def.Type())
+	if err != nil {
+		return false
+	}
+	return converted.Equals(def).True()
+}
@@ -0,0 +1,179 @@
+package rules
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4ModuleDefaultInputsRule(t *testing.T) {
+	dir := t.TempDir()
+	modules := map[string]string{
+		"modules/queue/_variables.tf": `
+variable "name" {}
+
+variable "visibility_timeout" {
+  type    = number
+  default = 30
+}
+
+variable "tags" {
+  type    = map(string)
+  default = {}
+}
+
+variable "dead_letter" {
+  type = object({ enabled = bool, max_receives = number })
+  default = {
+    enabled      = true
+    max_receives = 5
+  }
+}`,
+		".terraform/modules/modules.json":           `{"Modules": [{"Key": "", "Source": "", "Dir": "."}, {"Key": "vpc", "Source": "app.terraform.io/knowbe4/vpc/aws", "Dir": ".terraform/modules/vpc"}]}`,
+		".terraform/modules/vpc/_variables.tf.json": `{"variable": {"cidr_block": {"default": "10.0.0.0/16"}, "single_nat_gateway": {"default": false}}}`,
+	}
+	for name, src := range modules {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	cases := []struct {
+		Name      string
+		Content   string
+		DeepCheck bool
+		Expected  helper.Issues
+	}{
+		{
+			Name: "restated defaults",
+			Content: `
+module "queue" {
+  source = "./modules/queue"
+
+  name               = "jobs"
+  visibility_timeout = "30"
+  tags               = {}
+  dead_letter = {
+    enabled      = true
+    max_receives = 5
+  }
+}
+
+module "vpc" {
+  source  = "app.terraform.io/knowbe4/vpc/aws"
+  version = "1.0.0"
+
+  cidr_block         = "10.0.0.0/16"
+  single_nat_gateway = true
+}`,
+			DeepCheck: true,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ModuleDefaultInputsRule(),
+					Message: "module \"queue\" passes the module's default for visibility_timeout; drop the argument",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 3},
+						End:      hcl.Pos{Line: 6, Column: 28},
+					},
+				},
+				{
+					Rule:    NewKb4ModuleDefaultInputsRule(),
+					Message: "module \"queue\" passes the module's default for tags; drop the argument",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 26},
+					},
+				},
+				{
+					Rule:    NewKb4ModuleDefaultInputsRule(),
+					Message: "module \"queue\" passes the module's default for dead_letter; drop the argument",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 3},
+						End:      hcl.Pos{Line: 11, Column: 4},
+					},
+				},
+				{
+					Rule:    NewKb4ModuleDefaultInputsRule(),
+					Message: "module \"vpc\" passes the module's default for cidr_block; drop the argument",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 18, Column: 3},
+						End:      hcl.Pos{Line: 18, Column: 37},
+					},
+				},
+			},
+		},
+		{
+			Name: "overridden defaults and unknown modules",
+			Content: `
+module "queue" {
+  source = "./modules/queue"
+
+  name               = "jobs"
+  visibility_timeout = 60
+  dead_letter = {
+    enabled      = true
+    max_receives = 3
+  }
+}
+
+module "uninstalled" {
+  source  = "app.terraform.io/knowbe4/rds/aws"
+  version = "1.0.0"
+
+  cidr_block = "10.0.0.0/16"
+}`,
+			DeepCheck: true,
+			Expected:  helper.Issues{},
+		},
+		{
+			Name: "deep check off",
+			Content: `
+module "queue" {
+  source = "./modules/queue"
+
+  visibility_timeout = 30
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewKb4ModuleDefaultInputsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			testRunner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			config := DefaultConfig()
+			config.DeepCheck = tc.DeepCheck
+			runner := NewRunner(testRunner, config)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, testRunner.Issues)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4ModuleOutputsRule checks that child modules creating resources export at least one output from the outputs file
+type Kb4ModuleOutputsRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4ModuleOutputsRule())
+}
+
+// NewKb4ModuleOutputsRule returns a new rule
+func NewKb4ModuleOutputsRule() *Kb4ModuleOutputsRule {
+	return &Kb4ModuleOutputsRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4ModuleOutputsRule) Name() string {
+	return "kb4_module_outputs"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4ModuleOutputsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4ModuleOutputsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4ModuleOutputsRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4ModuleOutputsRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Child modules that create resources must declare at least one output in the `outputs_file` of `terraform_kb4_file_structure`, `_outputs.tf` by default, so downstream stacks don't fall back to remote state or data lookups to find them. Modules configuring a backend, Terraform Cloud or a provider are root modules and are skipped; provider blocks setting only `alias` don't count.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "outputs",
+		Example: `
+resource "aws_sqs_queue" "this" {
+  name = "jobs"
+}`,
+	}
+}
+
+// Check emits an issue on the first resource of a child module without outputs in the outputs file
+func (r *Kb4ModuleOutputsRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	structure, err := fileStructureConfig(runner)
+	if err != nil {
+		return err
+	}
+
+	root, err := isRootModule(runner)
+	if err != nil || root {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+			},
+			{
+				Type:       "output",
+				LabelNames: []string{"name"},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	resources := []*hclext.Block{}
+	for _, block := range content.Blocks {
+		if block.Type == "resource" {
+			resources = append(resources, block)
+		} else if sameTerraformFile(block.DefRange.Filename, structure.OutputsFile) {
+			return nil
+		}
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		return rangeLess(resources[i].DefRange, resources[j].DefRange)
+	})
+
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("Module creates resources but %s declares no outputs; export what downstream stacks need there", structure.OutputsFile),
+		resources[0].DefRange,
+	)
+}
@@ -0,0 +1,147 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4ModuleOutputsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "with outputs",
+			Files: map[string]string{
+				"main.tf": `
+resource "aws_sqs_queue" "this" {
+  name = "jobs"
+}`,
+				"_outputs.tf": `
+output "queue_url" {
+  value = aws_sqs_queue.this.url
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "no resources",
+			Files: map[string]string{
+				"main.tf": `
+module "queue" {
+  source = "./queue"
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "root module",
+			Files: map[string]string{
+				"_init.tf": `
+terraform {
+  backend "s3" {}
+}`,
+				"main.tf": `
+resource "aws_sqs_queue" "this" {
+  name = "jobs"
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "no outputs",
+			Files: map[string]string{
+				"queue.tf": `
+resource "aws_sqs_queue" "dead_letter" {
+  name = "jobs-dlq"
+}`,
+				"main.tf": `
+resource "aws_sqs_queue" "this" {
+  name = "jobs"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ModuleOutputsRule(),
+					Message: "Module creates resources but _outputs.tf declares no outputs; export what downstream stacks need there",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			Name: "outputs outside the outputs file",
+			Files: map[string]string{
+				"main.tf": `
+resource "aws_sqs_queue" "this" {
+  name = "jobs"
+}
+
+output "queue_url" {
+  value = aws_sqs_queue.this.url
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ModuleOutputsRule(),
+					Message: "Module creates resources but _outputs.tf declares no outputs; export what downstream stacks need there",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured outputs file",
+			Files: map[string]string{
+				"main.tf": `
+resource "aws_sqs_queue" "this" {
+  name = "jobs"
+}`,
+				"_outputs.tf": `
+output "queue_url" {
+  value = aws_sqs_queue.this.url
+}`,
+				".tflint.hcl": `
+rule "terraform_kb4_file_structure" {
+  enabled      = true
+  outputs_file = "outputs.tf"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ModuleOutputsRule(),
+					Message: "Module creates resources but outputs.tf declares no outputs; export what downstream stacks need there",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 32},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4ModuleOutputsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.Files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4ModulePathsRule checks that child modules only refer to files relative to themselves
+type Kb4ModulePathsRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4ModulePathsRule())
+}
+
+// NewKb4ModulePathsRule returns a new rule
+func NewKb4ModulePathsRule() *Kb4ModulePathsRule {
+	return &Kb4ModulePathsRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4ModulePathsRule) Name() string {
+	return "kb4_module_paths"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4ModulePathsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4ModulePathsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4ModulePathsRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4ModulePathsRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Child modules must not use `path.root` or `path.cwd`, which point wherever the calling stack happens to run and break the module when it's reused. Use `path.module`.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "standard-files-names-and-usage",
+		Example: `
+locals {
+  policy = file("${path.root}/policies/bucket.json")
+}`,
+	}
+}
+
+// Check emits an issue for every path.root and path.cwd reference in a child module
+func (r *Kb4ModulePathsRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	root, err := isRootModule(runner)
+	if err != nil || root {
+		return err
+	}
+
+	found, err := references(runner, "path")
+	if err != nil {
+		return err
+	}
+
+	for _, traversal := range found {
+		attr := traversalAttr(traversal)
+		if attr != "root" && attr != "cwd" {
+			continue
+		}
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("path.%s must not be used in child modules; use path.module", attr),
+			traversal.SourceRange(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4ModulePathsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "path.module",
+			Files: map[string]string{
+				"main.tf": `
+locals {
+  policy = file("${path.module}/policies/bucket.json")
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "root module",
+			Files: map[string]string{
+				"_init.tf": `
+terraform {
+  backend "s3" {}
+}`,
+				"main.tf": `
+locals {
+  policy = file("${path.root}/policies/bucket.json")
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "child module",
+			Files: map[string]string{
+				"main.tf": `
+locals {
+  policy = file("${path.root}/policies/bucket.json")
+  dir    = path.cwd
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ModulePathsRule(),
+					Message: "path.root must not be used in child modules; use path.module",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 20},
+						End:      hcl.Pos{Line: 3, Column: 29},
+					},
+				},
+				{
+					Rule:    NewKb4ModulePathsRule(),
+					Message: "path.cwd must not be used in child modules; use path.module",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 12},
+						End:      hcl.Pos{Line: 4, Column: 20},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4ModulePathsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.Files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4ModuleSourcesRule checks that modules come from the sources the org policy approves
+type Kb4ModuleSourcesRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4ModuleSourcesRule())
+}
+
+// NewKb4ModuleSourcesRule returns a new rule
+func NewKb4ModuleSourcesRule() *Kb4ModuleSourcesRule {
+	return &Kb4ModuleSourcesRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4ModuleSourcesRule) Name() string {
+	return "kb4_module_sources"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4ModuleSourcesRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4ModuleSourcesRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4ModuleSourcesRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4ModuleSourcesRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "The `source` of `module` blocks must start with one of the org policy's `approved_module_sources`, so stacks only run modules the platform team vets. Local paths starting with `./` or `../` are always allowed. Without `approved_module_sources` the rule checks nothing.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "dependencies",
+		Example: `
+# policy.yaml: approved_module_sources: [app.terraform.io/knowbe4/]
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "3.14.0"
+}`,
+	}
+}
+
+// Check emits an issue for every module whose source isn't approved
+func (r *Kb4ModuleSourcesRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	approved := orgPolicy(runner).ApprovedModuleSources
+	if len(approved) == 0 {
+		return nil
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "module",
+				LabelNames: []string{"name"},
+				Body:       &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "source"}}},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	modules := append([]*hclext.Block{}, content.Blocks...)
+	sort.SliceStable(modules, func(i, j int) bool {
+		return rangeLess(modules[i].DefRange, modules[j].DefRange)
+	})
+
+	for _, module := range modules {
+		attr, ok := module.Body.Attributes["source"]
+		if !ok {
+			continue
+		}
+
+		module := module
+		if err := evaluateString(runner, attr.Expr, func(source string) error {
+			if isApprovedModuleSource(source, approved) {
+				return nil
+			}
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s uses module source `%s`, which isn't approved; use a module from %s", describeBlock(module), source, strings.Join(approved, ", ")),
+				attr.Expr.Range(),
+			)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isApprovedModuleSource reports whether source is a local path or starts with one of the approved prefixes
+func isApprovedModuleSource(source string, approved []string) bool {
+	if strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") {
+		return true
+	}
+	for _, prefix := range approved {
+		if strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_Kb4ModuleSourcesRule(t *testing.T) {
+	content := `
+module "network" {
+  source  = "app.terraform.io/knowbe4/network/aws"
+  version = "1.2.0"
+}
+
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "3.14.0"
+}
+
+module "local" {
+  source = "../modules/local"
+}`
+
+	cases := []struct {
+		Name     string
+		Policy   *Policy
+		Expected helper.Issues
+	}{
+		{
+			Name:     "no approved sources",
+			Expected: helper.Issues{},
+		},
+		{
+			Name:   "unapproved source",
+			Policy: &Policy{ApprovedModuleSources: []string{"app.terraform.io/knowbe4/", "git::https://github.com/knowbe4/"}},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ModuleSourcesRule(),
+					Message: "module \"vpc\" uses module source `terraform-aws-modules/vpc/aws`, which isn't approved; use a module from app.terraform.io/knowbe4/, git::https://github.com/knowbe4/",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 13},
+						End:      hcl.Pos{Line: 8, Column: 44},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4ModuleSourcesRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			testRunner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+			var runner tflint.Runner = testRunner
+			if tc.Policy != nil {
+				config := DefaultConfig()
+				config.policy = tc.Policy
+				runner = NewRunner(testRunner, config)
+			}
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, testRunner.Issues)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4ModuleVersionFreshnessRuleConfig is the rule's .tflint.hcl config
+type Kb4ModuleVersionFreshnessRuleConfig struct {
+	MaxReleasesBehind int `hclext:"max_releases_behind,optional" doc:"How many releases a pinned module version may fall behind the latest."`
+}
+
+func newKb4ModuleVersionFreshnessRuleConfig() *Kb4ModuleVersionFreshnessRuleConfig {
+	return &Kb4ModuleVersionFreshnessRuleConfig{MaxReleasesBehind: 3}
+}
+
+// Validate rejects a negative limit
+func (c *Kb4ModuleVersionFreshnessRuleConfig) Validate() error {
+	if c.MaxReleasesBehind < 0 {
+		return fmt.Errorf("max_releases_behind must not be negative")
+	}
+	return nil
+}
+
+// Kb4ModuleVersionFreshnessRule checks that pinned module versions keep up with their releases
+type Kb4ModuleVersionFreshnessRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4ModuleVersionFreshnessRule())
+}
+
+// NewKb4ModuleVersionFreshnessRule returns a new rule
+func NewKb4ModuleVersionFreshnessRule() *Kb4ModuleVersionFreshnessRule {
+	return &Kb4ModuleVersionFreshnessRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4ModuleVersionFreshnessRule) Name() string {
+	return "kb4_module_version_freshness"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4ModuleVersionFreshnessRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4ModuleVersionFreshnessRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4ModuleVersionFreshnessRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4ModuleVersionFreshnessRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Modules pinned to an exact version, a registry `version` or a git `?ref=` tag, must be no more than `max_releases_behind` releases behind the latest. The rule only runs with `deep_check = true` in the plugin block, since it queries the registry, found by service discovery, or lists the git remote's tags. Registry credentials are read from `TF_TOKEN_<host>` like Terraform does. Modules whose versions can't be looked up are skipped with a warning in the log.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "dependencies",
+		Example: `
+module "vpc" {
+  source  = "app.terraform.io/knowbe4/vpc/aws"
+  version = "1.2.0"
+}`,
+		Config: newKb4ModuleVersionFreshnessRuleConfig(),
+	}
+}
+
+// Check emits an issue for every pinned module version too far behind the latest release
+func (r *Kb4ModuleVersionFreshnessRule) Check(runner tflint.Runner) error {
+	config := newKb4ModuleVersionFreshnessRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	if !ruleSetConfig(runner).DeepCheck {
+		return nil
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "module",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "source"}, {Name: "version"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	// Modules called more than once are looked up once
+	looked := map[string][]string{}
+
+	for _, module := range content.Blocks {
+		attr, ok := module.Body.Attributes["source"]
+		if !ok {
+			continue
+		}
+
+		err := evaluateString(runner, attr.Expr, func(raw string) error {
+			source, ok := parseModuleSource(raw)
+			if !ok {
+				return nil
+			}
+
+			pinned, rng, ok := r.pinnedVersion(runner, module, source, attr.Expr.Range())
+			if !ok {
+				return nil
+			}
+
+			versions, ok := looked[source.String()]
+			if !ok {
+				var err error
+				if versions, err = moduleVersions(source); err != nil {
+					logWarn("%s; skipping %s", err, describeBlock(module))
+				}
+				looked[source.String()] = versions
+			}
+
+			newer := releasesSince(pinned, versions)
+			if len(newer) <= config.MaxReleasesBehind {
+				return nil
+			}
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s pins %s, %d releases behind the latest %s; at most %d are allowed", describeBlock(module), pinned, len(newer), newer[0], config.MaxReleasesBehind),
+				rng,
+			)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pinnedVersion returns the exact version a module pins and where: its registry version, or its git ref.
+// Version constraints and refs that aren't versions, e.g. branches, don't pin a release.
+func (r *Kb4ModuleVersionFreshnessRule) pinnedVersion(runner tflint.Runner, module *hclext.Block, source moduleSource, sourceRange hcl.Range) (moduleVersion, hcl.Range, bool) {
+	if source.Remote != "" {
+		version, ok := parseModuleVersion(source.Ref)
+		return version, sourceRange, ok
+	}
+
+	attr, ok := module.Body.Attributes["version"]
+	if !ok {
+		return moduleVersion{}, hcl.Range{}, false
+	}
+
+	var pinned moduleVersion
+	found := false
+	err := evaluateString(runner, attr.Expr, func(constraint string) error {
+		pinned, found = parseModuleVersion(strings.TrimPrefix(strings.TrimSpace(constraint), "="))
+		return nil
+	})
+	if err != nil {
+		return moduleVersion{}, hcl.Range{}, false
+	}
+	return pinned, attr.Expr.Range(), found
+}
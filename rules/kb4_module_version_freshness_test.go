@@ -0,0 +1,172 @@
+package rules
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4ModuleVersionFreshnessRule(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/terraform.json":
+			w.Write([]byte(`{"modules.v1": "/v1/modules/"}`))
+		case "/v1/modules/knowbe4/vpc/aws/versions":
+			w.Write([]byte(`{"modules": [{"versions": [{"version": "1.0.0"}, {"version": "1.1.0"}, {"version": "1.2.0"}, {"version": "1.3.0"}, {"version": "2.0.0"}]}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := moduleRegistryClient
+	moduleRegistryClient = server.Client()
+	defer func() { moduleRegistryClient = client }()
+
+	gitTags := moduleGitTags
+	moduleGitTags = func(remote string) ([]string, error) {
+		if remote != "https://github.com/knowbe4/terraform-aws-iam.git" {
+			return nil, fmt.Errorf("failed to list tags of %s: exit status 128", remote)
+		}
+		return []string{"v0.9.0", "v1.0.0", "v1.1.0", "v1.2.0", "v1.3.0", "v1.4.0-rc1"}, nil
+	}
+	defer func() { moduleGitTags = gitTags }()
+
+	registryHost := strings.TrimPrefix(server.URL, "https://")
+
+	cases := []struct {
+		Name      string
+		Content   string
+		Config    string
+		DeepCheck bool
+		Expected  helper.Issues
+	}{
+		{
+			Name: "stale modules",
+			Content: `
+module "vpc" {
+  source  = "REGISTRY/knowbe4/vpc/aws"
+  version = "1.0.0"
+}
+
+module "iam" {
+  source = "git::https://github.com/knowbe4/terraform-aws-iam.git?ref=v0.9.0"
+}`,
+			DeepCheck: true,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ModuleVersionFreshnessRule(),
+					Message: "module \"vpc\" pins 1.0.0, 4 releases behind the latest 2.0.0; at most 3 are allowed",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 13},
+						End:      hcl.Pos{Line: 4, Column: 20},
+					},
+				},
+				{
+					Rule:    NewKb4ModuleVersionFreshnessRule(),
+					Message: "module \"iam\" pins 0.9.0, 4 releases behind the latest 1.3.0; at most 3 are allowed",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 12},
+						End:      hcl.Pos{Line: 8, Column: 78},
+					},
+				},
+			},
+		},
+		{
+			Name: "recent, unpinned and unreachable modules",
+			Content: `
+module "vpc" {
+  source  = "REGISTRY/knowbe4/vpc/aws"
+  version = "= 1.2.0"
+}
+
+module "constrained" {
+  source  = "REGISTRY/knowbe4/vpc/aws"
+  version = "~> 1.0"
+}
+
+module "missing" {
+  source  = "REGISTRY/knowbe4/missing/aws"
+  version = "1.0.0"
+}
+
+module "branch" {
+  source = "git::https://github.com/knowbe4/terraform-aws-iam.git?ref=main"
+}
+
+module "private" {
+  source = "git::https://github.com/knowbe4/terraform-aws-private.git?ref=v0.1.0"
+}
+
+module "local" {
+  source = "./modules/app"
+}`,
+			DeepCheck: true,
+			Expected:  helper.Issues{},
+		},
+		{
+			Name: "configured limit",
+			Content: `
+module "vpc" {
+  source  = "REGISTRY/knowbe4/vpc/aws"
+  version = "1.2.0"
+}`,
+			Config: `
+rule "kb4_module_version_freshness" {
+  enabled             = true
+  max_releases_behind = 1
+}`,
+			DeepCheck: true,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ModuleVersionFreshnessRule(),
+					Message: "module \"vpc\" pins 1.2.0, 2 releases behind the latest 2.0.0; at most 1 are allowed",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 13},
+						End:      hcl.Pos{Line: 4, Column: 20},
+					},
+				},
+			},
+		},
+		{
+			Name: "deep check off",
+			Content: `
+module "vpc" {
+  source  = "REGISTRY/knowbe4/vpc/aws"
+  version = "1.0.0"
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewKb4ModuleVersionFreshnessRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": strings.ReplaceAll(tc.Content, "REGISTRY", registryHost)}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			testRunner := helper.TestRunner(t, files)
+
+			config := DefaultConfig()
+			config.DeepCheck = tc.DeepCheck
+			runner := NewRunner(testRunner, config)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, testRunner.Issues)
+		})
+	}
+}
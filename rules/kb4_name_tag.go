@@ -0,0 +1,204 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kb4NameTagRuleConfig is the rule's .tflint.hcl config
+type Kb4NameTagRuleConfig struct {
+	Pattern        string `hclext:"pattern,optional" doc:"Regular expression Name tags must match. {environment} stands for any of the canonical environments and {service} for service_pattern."`
+	ServicePattern string `hclext:"service_pattern,optional" doc:"Regular expression {service} matches."`
+}
+
+func newKb4NameTagRuleConfig() *Kb4NameTagRuleConfig {
+	return &Kb4NameTagRuleConfig{
+		Pattern:        `^{environment}-{service}(-[a-z0-9]+)*$`,
+		ServicePattern: `[a-z][a-z0-9]*`,
+	}
+}
+
+// Validate rejects patterns that don't compile once expanded
+func (c *Kb4NameTagRuleConfig) Validate() error {
+	if _, err := regexp.Compile(c.ServicePattern); err != nil {
+		return fmt.Errorf("service_pattern is invalid: %s", err)
+	}
+	if _, err := regexp.Compile(c.expandedPattern([]string{"dev"})); err != nil {
+		return fmt.Errorf("pattern is invalid: %s", err)
+	}
+	return nil
+}
+
+// expandedPattern returns the pattern with its placeholders replaced by the expressions they stand for
+func (c *Kb4NameTagRuleConfig) expandedPattern(environments []string) string {
+	quoted := make([]string, len(environments))
+	for i, environment := range environments {
+		quoted[i] = regexp.QuoteMeta(environment)
+	}
+
+	return strings.NewReplacer(
+		"{environment}", "(?:"+strings.Join(quoted, "|")+")",
+		"{service}", "(?:"+c.ServicePattern+")",
+	).Replace(c.Pattern)
+}
+
+// Kb4NameTagRule checks that Name tags say which environment and service a resource belongs to
+type Kb4NameTagRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4NameTagRule())
+}
+
+// NewKb4NameTagRule returns a new rule
+func NewKb4NameTagRule() *Kb4NameTagRule {
+	return &Kb4NameTagRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4NameTagRule) Name() string {
+	return "kb4_name_tag"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4NameTagRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4NameTagRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4NameTagRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4NameTagRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Where a resource sets a `Name` tag, in `tags` or a `tag` block, it must match `pattern`, which by default starts with one of the canonical environments and the service, e.g. `prod-payments-db`. The tag is evaluated, so `\"${var.environment}-${var.service}-db\"` is checked with the variables' values, and names that aren't known until apply are skipped.",
+		Categories:  []string{CategoryNaming},
+		Anchor:      "tags",
+		Example: `
+resource "aws_instance" "this" {
+  tags = {
+    Name = "test"
+  }
+}`,
+		Config: newKb4NameTagRuleConfig(),
+	}
+}
+
+// Check emits an issue for every Name tag that doesn't match the pattern
+func (r *Kb4NameTagRule) Check(runner tflint.Runner) error {
+	config := newKb4NameTagRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+	pattern := regexp.MustCompile(config.expandedPattern(environments(runner)))
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "tags"}},
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "tag",
+							Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "key"}, {Name: "value"}}},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	resources := append([]*hclext.Block{}, content.Blocks...)
+	sort.SliceStable(resources, func(i, j int) bool {
+		return rangeLess(resources[i].DefRange, resources[j].DefRange)
+	})
+
+	for _, resource := range resources {
+		names := []hcl.Expression{}
+		if tags, ok := resource.Body.Attributes["tags"]; ok {
+			if name := nameTagExpr(tags.Expr); name != nil {
+				names = append(names, name)
+			}
+		}
+		for _, tag := range resource.Body.Blocks {
+			key, value := tag.Body.Attributes["key"], tag.Body.Attributes["value"]
+			if key == nil || value == nil {
+				continue
+			}
+			if k, diags := key.Expr.Value(nil); !diags.HasErrors() && k.Type() == cty.String && k.IsKnown() && !k.IsNull() && k.AsString() == "Name" {
+				names = append(names, value.Expr)
+			}
+		}
+
+		for _, name := range names {
+			resource, name := resource, name
+			if err := evaluateString(runner, name, func(value string) error {
+				if pattern.MatchString(value) {
+					return nil
+				}
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("%s has Name tag `%s`, which should match %s", describeBlock(resource), value, config.Pattern),
+					name.Range(),
+				)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// nameTagExpr returns the value expression of the literal Name key in a tags map, including the maps merged by merge().
+// The last merged map setting it wins, as in merge() itself.
+func nameTagExpr(expr hcl.Expression) hcl.Expression {
+	switch e := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		var found hcl.Expression
+		for _, item := range e.Items {
+			key, diags := item.KeyExpr.Value(nil)
+			if !diags.HasErrors() && key.Type() == cty.String && key.IsKnown() && !key.IsNull() && key.AsString() == "Name" {
+				found = item.ValueExpr
+			}
+		}
+		return found
+
+	case *hclsyntax.FunctionCallExpr:
+		if e.Name != "merge" {
+			return nil
+		}
+		var found hcl.Expression
+		for _, arg := range e.Args {
+			if name := nameTagExpr(arg); name != nil {
+				found = name
+			}
+		}
+		return found
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4NameTagRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "conventional names",
+			Content: `
+variable "environment" {
+  default = "prod"
+}
+
+variable "service" {
+  default = "payments"
+}
+
+variable "suffix" {}
+
+resource "aws_instance" "db" {
+  tags = merge(var.tags, { Name = "${var.environment}-${var.service}-db" })
+}
+
+resource "aws_instance" "web" {
+  tags = {
+    Name = "staging-payments"
+  }
+}
+
+resource "aws_autoscaling_group" "workers" {
+  tag {
+    key   = "Name"
+    value = "dev-payments-worker-2"
+  }
+}
+
+resource "aws_instance" "unknown" {
+  tags = {
+    Name = var.suffix
+  }
+}
+
+resource "aws_instance" "untagged" {
+  tags = {
+    Team = "sre"
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "unconventional names",
+			Content: `
+variable "environment" {
+  default = "prod"
+}
+
+resource "aws_instance" "db" {
+  tags = merge({ Name = "prod-payments" }, { Name = "test" })
+}
+
+resource "aws_instance" "web" {
+  tags = {
+    Name = "${var.environment}_web"
+  }
+}
+
+resource "aws_autoscaling_group" "workers" {
+  tag {
+    key   = "Name"
+    value = "qa-payments"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4NameTagRule(),
+					Message: "resource \"aws_instance\" \"db\" has Name tag `test`, which should match ^{environment}-{service}(-[a-z0-9]+)*$",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 53},
+						End:      hcl.Pos{Line: 7, Column: 59},
+					},
+				},
+				{
+					Rule:    NewKb4NameTagRule(),
+					Message: "resource \"aws_instance\" \"web\" has Name tag `prod_web`, which should match ^{environment}-{service}(-[a-z0-9]+)*$",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 12, Column: 12},
+						End:      hcl.Pos{Line: 12, Column: 36},
+					},
+				},
+				{
+					Rule:    NewKb4NameTagRule(),
+					Message: "resource \"aws_autoscaling_group\" \"workers\" has Name tag `qa-payments`, which should match ^{environment}-{service}(-[a-z0-9]+)*$",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 19, Column: 13},
+						End:      hcl.Pos{Line: 19, Column: 26},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured pattern",
+			Content: `
+resource "aws_instance" "db" {
+  tags = {
+    Name = "prod-payments"
+  }
+}
+
+resource "aws_instance" "web" {
+  tags = {
+    Name = "payments-prod"
+  }
+}`,
+			Config: `
+rule "kb4_name_tag" {
+  enabled         = true
+  pattern         = "^{service}-{environment}$"
+  service_pattern = "payments|billing"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4NameTagRule(),
+					Message: "resource \"aws_instance\" \"db\" has Name tag `prod-payments`, which should match ^{service}-{environment}$",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 12},
+						End:      hcl.Pos{Line: 4, Column: 27},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4NameTagRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
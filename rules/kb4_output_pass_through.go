@@ -0,0 +1,121 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4OutputPassThroughRule checks that outputs expose what the module creates rather than echo its inputs
+type Kb4OutputPassThroughRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4OutputPassThroughRule())
+}
+
+// NewKb4OutputPassThroughRule returns a new rule
+func NewKb4OutputPassThroughRule() *Kb4OutputPassThroughRule {
+	return &Kb4OutputPassThroughRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4OutputPassThroughRule) Name() string {
+	return "kb4_output_pass_through"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4OutputPassThroughRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4OutputPassThroughRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4OutputPassThroughRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4OutputPassThroughRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Outputs must not be exactly `var.<name>`. Echoing an input back makes consumers think the module owns the value; read it from where it's set instead.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "outputs",
+		Example: `
+output "vpc_id" {
+  value = var.vpc_id
+}`,
+	}
+}
+
+// Check emits issues for outputs whose value is a bare variable reference
+func (r *Kb4OutputPassThroughRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "output",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "value"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, output := range content.Blocks {
+		value, ok := output.Body.Attributes["value"]
+		if !ok {
+			continue
+		}
+
+		variable, ok := passedThroughVariable(value.Expr)
+		if !ok {
+			continue
+		}
+
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("`%s` output passes `var.%s` straight through; output what the module creates instead", output.Labels[0], variable),
+			value.Expr.Range(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// passedThroughVariable returns the variable name when the expression is exactly var.<name>, also written as "${var.<name>}"
+func passedThroughVariable(expr hcl.Expression) (string, bool) {
+	if wrap, ok := expr.(*hclsyntax.TemplateWrapExpr); ok {
+		expr = wrap.Wrapped
+	}
+
+	traversal, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok || len(traversal.Traversal) != 2 || traversal.Traversal.RootName() != "var" {
+		return "", false
+	}
+
+	attr, ok := traversal.Traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return "", false
+	}
+	return attr.Name, true
+}
@@ -0,0 +1,79 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4OutputPassThroughRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "created values",
+			Content: `
+output "id" {
+  value = aws_instance.this.id
+}
+
+output "subnet" {
+  value = var.subnets[0]
+}
+
+output "name" {
+  value = "${var.name}-${var.environment}"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "pass-through",
+			Content: `
+output "vpc_id" {
+  value = var.vpc_id
+}
+
+output "name" {
+  value = "${var.name}"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4OutputPassThroughRule(),
+					Message: "`vpc_id` output passes `var.vpc_id` straight through; output what the module creates instead",
+					Range: hcl.Range{
+						Filename: "outputs.tf",
+						Start:    hcl.Pos{Line: 3, Column: 11},
+						End:      hcl.Pos{Line: 3, Column: 21},
+					},
+				},
+				{
+					Rule:    NewKb4OutputPassThroughRule(),
+					Message: "`name` output passes `var.name` straight through; output what the module creates instead",
+					Range: hcl.Range{
+						Filename: "outputs.tf",
+						Start:    hcl.Pos{Line: 7, Column: 11},
+						End:      hcl.Pos{Line: 7, Column: 24},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4OutputPassThroughRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"outputs.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
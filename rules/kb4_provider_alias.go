@@ -0,0 +1,128 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kb4ProviderAliasRuleConfig is the rule's .tflint.hcl config
+type Kb4ProviderAliasRuleConfig struct {
+	AllowedAliases []string `hclext:"allowed_aliases,optional" doc:"Aliases provider blocks may use. Setting it replaces the defaults."`
+}
+
+func newKb4ProviderAliasRuleConfig() *Kb4ProviderAliasRuleConfig {
+	return &Kb4ProviderAliasRuleConfig{
+		AllowedAliases: append(regionAliases(), "dns"),
+	}
+}
+
+// Validate rejects an empty allow-list, which would ban every alias
+func (c *Kb4ProviderAliasRuleConfig) Validate() error {
+	if len(c.AllowedAliases) == 0 {
+		return fmt.Errorf("allowed_aliases must list at least one alias")
+	}
+	return nil
+}
+
+// Kb4ProviderAliasRule checks that provider aliases come from the shared allow-list
+type Kb4ProviderAliasRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4ProviderAliasRule())
+}
+
+// NewKb4ProviderAliasRule returns a new rule
+func NewKb4ProviderAliasRule() *Kb4ProviderAliasRule {
+	return &Kb4ProviderAliasRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4ProviderAliasRule) Name() string {
+	return "kb4_provider_alias"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4ProviderAliasRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4ProviderAliasRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4ProviderAliasRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4ProviderAliasRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Provider `alias` names must come from `allowed_aliases`, so multi-region code refers to the same provider by the same name in every stack.",
+		Categories:  []string{CategoryNaming},
+		Anchor:      "providers",
+		Example: `
+provider "aws" {
+  alias  = "virginia"
+  region = "us-east-1"
+}`,
+		Config: newKb4ProviderAliasRuleConfig(),
+	}
+}
+
+// Check emits issues for provider aliases missing from the allow-list
+func (r *Kb4ProviderAliasRule) Check(runner tflint.Runner) error {
+	config := newKb4ProviderAliasRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "provider",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "alias"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, provider := range content.Blocks {
+		alias, ok := provider.Body.Attributes["alias"]
+		if !ok {
+			continue
+		}
+
+		value, diags := alias.Expr.Value(nil)
+		if diags.HasErrors() || value.Type() != cty.String || !value.IsKnown() || value.IsNull() {
+			continue
+		}
+		if containsString(config.AllowedAliases, value.AsString()) {
+			continue
+		}
+
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("`%s` is not an allowed alias for %s; use one of %s", value.AsString(), describeBlock(provider), strings.Join(config.AllowedAliases, ", ")),
+			alias.Expr.Range(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
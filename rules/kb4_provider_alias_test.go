@@ -0,0 +1,96 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4ProviderAliasRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "allowed aliases",
+			Content: `
+provider "aws" {
+  region = "us-east-1"
+}
+
+provider "aws" {
+  alias  = "usw2"
+  region = "us-west-2"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "unknown alias",
+			Content: `
+provider "aws" {
+  alias  = "virginia"
+  region = "us-east-1"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ProviderAliasRule(),
+					Message: "`virginia` is not an allowed alias for provider \"aws\"; use one of use1, use2, usw1, usw2, euw1, euc1, apse1, apse2, dns",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 3, Column: 12},
+						End:      hcl.Pos{Line: 3, Column: 22},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured aliases",
+			Content: `
+provider "aws" {
+  alias = "use1"
+}
+
+provider "cloudflare" {
+  alias = "edge"
+}`,
+			Config: `
+rule "kb4_provider_alias" {
+  enabled         = true
+  allowed_aliases = ["edge"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ProviderAliasRule(),
+					Message: "`use1` is not an allowed alias for provider \"aws\"; use one of edge",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 3, Column: 11},
+						End:      hcl.Pos{Line: 3, Column: 17},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4ProviderAliasRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"_init.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
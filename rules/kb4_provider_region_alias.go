@@ -0,0 +1,155 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4ProviderRegionAliasRuleConfig is the rule's .tflint.hcl config
+type Kb4ProviderRegionAliasRuleConfig struct {
+	RegionAliases map[string]string `hclext:"region_aliases,optional" doc:"Alias each region's region-specific providers must use. Setting it replaces the defaults."`
+}
+
+func newKb4ProviderRegionAliasRuleConfig() *Kb4ProviderRegionAliasRuleConfig {
+	return &Kb4ProviderRegionAliasRuleConfig{RegionAliases: regionAliasMap()}
+}
+
+// Validate rejects empty aliases and aliases shared by two regions
+func (c *Kb4ProviderRegionAliasRuleConfig) Validate() error {
+	regions := map[string]string{}
+	for region, alias := range c.RegionAliases {
+		if alias == "" {
+			return fmt.Errorf("region_aliases must not map %s to an empty alias", region)
+		}
+		if other, ok := regions[alias]; ok {
+			return fmt.Errorf("region_aliases maps both %s and %s to %s", other, region, alias)
+		}
+		regions[alias] = region
+	}
+	return nil
+}
+
+// isRegionAlias reports whether the alias is one of the region-specific aliases
+func (c *Kb4ProviderRegionAliasRuleConfig) isRegionAlias(alias string) bool {
+	for _, a := range c.RegionAliases {
+		if a == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// Kb4ProviderRegionAliasRule checks that region-specific provider aliases name the region they pin
+type Kb4ProviderRegionAliasRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4ProviderRegionAliasRule())
+}
+
+// NewKb4ProviderRegionAliasRule returns a new rule
+func NewKb4ProviderRegionAliasRule() *Kb4ProviderRegionAliasRule {
+	return &Kb4ProviderRegionAliasRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4ProviderRegionAliasRule) Name() string {
+	return "kb4_provider_region_alias"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4ProviderRegionAliasRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4ProviderRegionAliasRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4ProviderRegionAliasRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4ProviderRegionAliasRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Providers aliased with a region short code, e.g. `use1`, must set the `region` that `region_aliases` maps to it, so `aws.use1` always means us-east-1. Aliases that don't name a region, like `dns`, are skipped.",
+		Categories:  []string{CategoryNaming},
+		Anchor:      "providers",
+		Example: `
+provider "aws" {
+  alias  = "use1"
+  region = "us-west-2"
+}`,
+		Config: newKb4ProviderRegionAliasRuleConfig(),
+	}
+}
+
+// Check emits an issue for every region-specific alias on a provider pinned to another region
+func (r *Kb4ProviderRegionAliasRule) Check(runner tflint.Runner) error {
+	config := newKb4ProviderRegionAliasRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "provider",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "alias"}, {Name: "region"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, provider := range content.Blocks {
+		aliasAttr, ok := provider.Body.Attributes["alias"]
+		if !ok {
+			continue
+		}
+		regionAttr, ok := provider.Body.Attributes["region"]
+		if !ok {
+			continue
+		}
+
+		alias := ""
+		if err := evaluateString(runner, aliasAttr.Expr, func(value string) error {
+			alias = value
+			return nil
+		}); err != nil {
+			return err
+		}
+		if !config.isRegionAlias(alias) {
+			continue
+		}
+
+		err := evaluateString(runner, regionAttr.Expr, func(region string) error {
+			expected, ok := config.RegionAliases[region]
+			if !ok || expected == alias {
+				return nil
+			}
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s` is the alias for another region; %s pins %s, so alias it `%s`", alias, describeBlock(provider), region, expected),
+				aliasAttr.Expr.Range(),
+			)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4ProviderRegionAliasRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "matching aliases",
+			Content: `
+variable "region" {}
+
+provider "aws" {
+  region = var.region
+}
+
+provider "aws" {
+  alias  = "use1"
+  region = "us-east-1"
+}
+
+provider "aws" {
+  alias  = "dns"
+  region = "us-east-1"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "mismatched alias",
+			Content: `
+provider "aws" {
+  alias  = "use1"
+  region = "us-west-2"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ProviderRegionAliasRule(),
+					Message: "`use1` is the alias for another region; provider \"aws\" pins us-west-2, so alias it `usw2`",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 3, Column: 12},
+						End:      hcl.Pos{Line: 3, Column: 18},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured regions",
+			Content: `
+provider "aws" {
+  alias  = "cac1"
+  region = "us-east-1"
+}`,
+			Config: `
+rule "kb4_provider_region_alias" {
+  enabled        = true
+  region_aliases = { "us-east-1" = "use1", "ca-central-1" = "cac1" }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ProviderRegionAliasRule(),
+					Message: "`cac1` is the alias for another region; provider \"aws\" pins us-east-1, so alias it `use1`",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 3, Column: 12},
+						End:      hcl.Pos{Line: 3, Column: 18},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4ProviderRegionAliasRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"_init.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
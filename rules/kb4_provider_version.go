@@ -0,0 +1,97 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4ProviderVersionRule checks that provider version constraints live in required_providers
+type Kb4ProviderVersionRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4ProviderVersionRule())
+}
+
+// NewKb4ProviderVersionRule returns a new rule
+func NewKb4ProviderVersionRule() *Kb4ProviderVersionRule {
+	return &Kb4ProviderVersionRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4ProviderVersionRule) Name() string {
+	return "kb4_provider_version"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4ProviderVersionRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4ProviderVersionRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4ProviderVersionRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4ProviderVersionRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Provider blocks must not set `version`. Terraform deprecated it; declare the constraint in `terraform.required_providers` instead.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "providers",
+		Example: `
+provider "aws" {
+  version = "~> 4.0"
+  region  = "us-east-1"
+}`,
+	}
+}
+
+// Check emits issues for version attributes in provider blocks
+func (r *Kb4ProviderVersionRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "provider",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "version"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, provider := range content.Blocks {
+		version, ok := provider.Body.Attributes["version"]
+		if !ok {
+			continue
+		}
+
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s sets a deprecated version; move the constraint to terraform.required_providers.%s", describeBlock(provider), provider.Labels[0]),
+			version.Range,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4ProviderVersionRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "required_providers",
+			Content: `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 4.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = "us-east-1"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "version in provider",
+			Content: `
+provider "aws" {
+  version = "~> 4.0"
+  region  = "us-east-1"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ProviderVersionRule(),
+					Message: "provider \"aws\" sets a deprecated version; move the constraint to terraform.required_providers.aws",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 21},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4ProviderVersionRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"_init.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4RandomPasswordRuleConfig is the rule's .tflint.hcl config
+type Kb4RandomPasswordRuleConfig struct {
+	MinLength int `hclext:"min_length,optional" doc:"Shortest length random_password resources may generate."`
+}
+
+func newKb4RandomPasswordRuleConfig() *Kb4RandomPasswordRuleConfig {
+	return &Kb4RandomPasswordRuleConfig{MinLength: 24}
+}
+
+// Validate rejects lengths below one
+func (c *Kb4RandomPasswordRuleConfig) Validate() error {
+	if c.MinLength < 1 {
+		return fmt.Errorf("min_length must be at least 1")
+	}
+	return nil
+}
+
+// Kb4RandomPasswordRule checks that generated passwords are long and use special characters
+type Kb4RandomPasswordRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4RandomPasswordRule())
+}
+
+// NewKb4RandomPasswordRule returns a new rule
+func NewKb4RandomPasswordRule() *Kb4RandomPasswordRule {
+	return &Kb4RandomPasswordRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4RandomPasswordRule) Name() string {
+	return "kb4_random_password"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4RandomPasswordRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4RandomPasswordRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4RandomPasswordRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4RandomPasswordRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`random_password` resources must set `length` to at least `min_length` and must not set `special = false`. Where a consumer can't take special characters, exempt the resource with a `# kb4:exempt kb4_random_password <justification>` comment on the line above.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "secrets",
+		Example: `
+resource "random_password" "db" {
+  length  = 16
+  special = false
+}`,
+		Config: newKb4RandomPasswordRuleConfig(),
+	}
+}
+
+// Check emits issues for short passwords and, unless exempt, passwords without special characters
+func (r *Kb4RandomPasswordRule) Check(runner tflint.Runner) error {
+	config := newKb4RandomPasswordRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	exempt, err := loadExemptions(runner, r)
+	if err != nil {
+		return err
+	}
+
+	content, err := runner.GetResourceContent("random_password", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "length"}, {Name: "special"}},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range content.Blocks {
+		if length, ok := resource.Body.Attributes["length"]; ok {
+			var value int
+			err := runner.EvaluateExpr(length.Expr, &value, nil)
+			err = runner.EnsureNoError(err, func() error {
+				if value >= config.MinLength {
+					return nil
+				}
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("random_password length %d is shorter than the minimum of %d", value, config.MinLength),
+					length.Expr.Range(),
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if special, ok := resource.Body.Attributes["special"]; ok {
+			err := evaluateBool(runner, special.Expr, func(value bool) error {
+				if value {
+					return nil
+				}
+				ok, unjustified := exempt.applies(resource.DefRange)
+				if ok {
+					return nil
+				}
+				return runner.EmitIssue(
+					r,
+					exemptionMessage("random_password must include special characters", unjustified),
+					special.Expr.Range(),
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
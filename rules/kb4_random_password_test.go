@@ -0,0 +1,93 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4RandomPasswordRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "strong password",
+			Content: `
+variable "password_length" {
+  default = 32
+}
+
+resource "random_password" "db" {
+  length  = var.password_length
+  special = true
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "weak password",
+			Content: `
+resource "random_password" "db" {
+  length  = 16
+  special = false
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4RandomPasswordRule(),
+					Message: "random_password length 16 is shorter than the minimum of 24",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 13},
+						End:      hcl.Pos{Line: 3, Column: 15},
+					},
+				},
+				{
+					Rule:    NewKb4RandomPasswordRule(),
+					Message: "random_password must include special characters",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 13},
+						End:      hcl.Pos{Line: 4, Column: 18},
+					},
+				},
+			},
+		},
+		{
+			Name: "exempt from special characters",
+			Content: `
+# kb4:exempt kb4_random_password the legacy SMTP relay rejects symbols
+resource "random_password" "smtp" {
+  length  = 16
+  special = false
+}`,
+			Config: `
+rule "kb4_random_password" {
+  enabled    = true
+  min_length = 16
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewKb4RandomPasswordRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
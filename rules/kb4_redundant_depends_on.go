@@ -0,0 +1,183 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4RedundantDependsOnRule checks that depends_on only lists dependencies Terraform can't infer
+type Kb4RedundantDependsOnRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4RedundantDependsOnRule())
+}
+
+// NewKb4RedundantDependsOnRule returns a new rule
+func NewKb4RedundantDependsOnRule() *Kb4RedundantDependsOnRule {
+	return &Kb4RedundantDependsOnRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4RedundantDependsOnRule) Name() string {
+	return "kb4_redundant_depends_on"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4RedundantDependsOnRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4RedundantDependsOnRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4RedundantDependsOnRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4RedundantDependsOnRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`depends_on` in resources, data sources and module calls must not list addresses the block already references. Terraform infers those dependencies, and the redundant entries slow the graph and hide the ordering that really needs `depends_on`.",
+		Categories:  []string{CategoryStyle},
+		Anchor:      "dependencies",
+		Example: `
+resource "aws_iam_role_policy_attachment" "this" {
+  role       = aws_iam_role.this.name
+  policy_arn = aws_iam_policy.this.arn
+
+  depends_on = [aws_iam_role.this]
+}`,
+	}
+}
+
+// Check emits an issue for every depends_on entry the block already references
+func (r *Kb4RedundantDependsOnRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+
+	type redundancy struct {
+		block   *hclsyntax.Block
+		address string
+		rng     hcl.Range
+	}
+	found := []redundancy{}
+
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "resource" && block.Type != "data" && block.Type != "module" {
+				continue
+			}
+			dependsOn, ok := block.Body.Attributes["depends_on"]
+			if !ok {
+				continue
+			}
+			entries, ok := dependsOn.Expr.(*hclsyntax.TupleConsExpr)
+			if !ok {
+				continue
+			}
+
+			referenced := blockReferences(block.Body)
+			for _, entry := range entries.Exprs {
+				traversal, diags := hcl.AbsTraversalForExpr(entry)
+				if diags.HasErrors() {
+					continue
+				}
+				if address := dependencyAddress(traversal); address != "" && referenced[address] {
+					found = append(found, redundancy{block, address, entry.Range()})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return rangeLess(found[i].rng, found[j].rng)
+	})
+
+	for _, f := range found {
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("depends_on lists %s, which %s already references", f.address, describeBlock(&hclext.Block{Type: f.block.Type, Labels: f.block.Labels})),
+			f.rng,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// blockReferences returns the addresses of the objects a block body refers to outside its depends_on
+func blockReferences(body *hclsyntax.Body) map[string]bool {
+	referenced := map[string]bool{}
+
+	visit := func(node hclsyntax.Node) hcl.Diagnostics {
+		if expr, ok := node.(*hclsyntax.ScopeTraversalExpr); ok {
+			if address := dependencyAddress(expr.Traversal); address != "" {
+				referenced[address] = true
+			}
+		}
+		return nil
+	}
+
+	for name, attr := range body.Attributes {
+		if name != "depends_on" {
+			hclsyntax.VisitAll(attr.Expr, visit)
+		}
+	}
+	for _, block := range body.Blocks {
+		hclsyntax.VisitAll(block.Body, visit)
+	}
+
+	return referenced
+}
+
+// dependencyAddress returns the address of the resource, data source or module call a reference points into,
+// e.g. aws_iam_role.this for aws_iam_role.this[0].arn. Other references, like var.name, have none.
+func dependencyAddress(traversal hcl.Traversal) string {
+	parts := 2
+	switch traversal.RootName() {
+	case "var", "local", "each", "count", "path", "terraform", "self":
+		return ""
+	case "data":
+		parts = 3
+	}
+
+	names := []string{traversal.RootName()}
+	for _, step := range traversal[1:] {
+		if len(names) == parts {
+			break
+		}
+		attr, ok := step.(hcl.TraverseAttr)
+		if !ok {
+			return ""
+		}
+		names = append(names, attr.Name)
+	}
+	if len(names) < parts {
+		return ""
+	}
+	return strings.Join(names, ".")
+}
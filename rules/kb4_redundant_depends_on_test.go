@@ -0,0 +1,107 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4RedundantDependsOnRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "needed dependencies",
+			Content: `
+resource "aws_instance" "this" {
+  subnet_id = var.subnet_id
+
+  depends_on = [aws_iam_role_policy.this, module.network]
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "redundant dependencies",
+			Content: `
+resource "aws_iam_role_policy_attachment" "this" {
+  role       = aws_iam_role.this[0].name
+  policy_arn = data.aws_iam_policy.readonly.arn
+
+  dynamic "tag" {
+    for_each = module.labels.tags
+    content {}
+  }
+
+  depends_on = [aws_iam_role.this, data.aws_iam_policy.readonly, module.labels, aws_iam_policy.other]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4RedundantDependsOnRule(),
+					Message: "depends_on lists aws_iam_role.this, which resource \"aws_iam_role_policy_attachment\" \"this\" already references",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 17},
+						End:      hcl.Pos{Line: 11, Column: 34},
+					},
+				},
+				{
+					Rule:    NewKb4RedundantDependsOnRule(),
+					Message: "depends_on lists data.aws_iam_policy.readonly, which resource \"aws_iam_role_policy_attachment\" \"this\" already references",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 36},
+						End:      hcl.Pos{Line: 11, Column: 64},
+					},
+				},
+				{
+					Rule:    NewKb4RedundantDependsOnRule(),
+					Message: "depends_on lists module.labels, which resource \"aws_iam_role_policy_attachment\" \"this\" already references",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 66},
+						End:      hcl.Pos{Line: 11, Column: 79},
+					},
+				},
+			},
+		},
+		{
+			Name: "module call",
+			Content: `
+module "app" {
+  source  = "./app"
+  db_host = aws_db_instance.this.address
+
+  depends_on = [aws_db_instance.this]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4RedundantDependsOnRule(),
+					Message: "depends_on lists aws_db_instance.this, which module \"app\" already references",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 17},
+						End:      hcl.Pos{Line: 6, Column: 37},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4RedundantDependsOnRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
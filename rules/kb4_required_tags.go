@@ -0,0 +1,214 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4RequiredTagsRule checks that taggable resources carry the tag keys the org policy or plugin block requires
+type Kb4RequiredTagsRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4RequiredTagsRule())
+}
+
+// NewKb4RequiredTagsRule returns a new rule
+func NewKb4RequiredTagsRule() *Kb4RequiredTagsRule {
+	return &Kb4RequiredTagsRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4RequiredTagsRule) Name() string {
+	return "kb4_required_tags"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4RequiredTagsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4RequiredTagsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4RequiredTagsRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4RequiredTagsRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Taggable resources must carry every tag key in the org policy's `required_tags`, or the plugin block's `default_tag_keys` when the policy lists none, either in their own `tags` or in the `default_tags` of an `aws` provider block. Resources like `aws_autoscaling_group` that tag through `tag` blocks must set the keys in those blocks. Tags are evaluated, so keys merged in from variables with known values count; resources whose tags aren't known until apply are skipped. Without either the rule checks nothing.",
+		Categories:  []string{CategoryCost},
+		Anchor:      "tags",
+		Example: `
+# policy.yaml: required_tags: [Team, Service]
+resource "aws_s3_bucket" "logs" {
+  tags = {
+    Team = "sre"
+  }
+}`,
+	}
+}
+
+// Check emits an issue for every taggable resource missing a required tag key
+func (r *Kb4RequiredTagsRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	required := orgPolicy(runner).RequiredTags
+	if len(required) == 0 {
+		required = ruleSetConfig(runner).DefaultTagKeys
+	}
+	if len(required) == 0 {
+		return nil
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "tags"}},
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "tag",
+							Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "key"}}},
+						},
+					},
+				},
+			},
+			{
+				Type:       "provider",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "default_tags",
+							Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "tags"}}},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	resources, providers := []*hclext.Block{}, []*hclext.Block{}
+	for _, block := range content.Blocks {
+		if block.Type == "resource" {
+			resources = append(resources, block)
+		} else {
+			providers = append(providers, block)
+		}
+	}
+
+	// Keys any aws provider applies by default count for every resource. When one of them isn't known, nothing is checked.
+	defaults := []string{}
+	for _, provider := range providers {
+		if provider.Labels[0] != "aws" {
+			continue
+		}
+		for _, block := range provider.Body.Blocks {
+			tags, ok := block.Body.Attributes["tags"]
+			if !ok {
+				continue
+			}
+
+			known := false
+			if err := evaluateTagKeys(runner, tags.Expr, func(keys []string) error {
+				known = true
+				defaults = append(defaults, keys...)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if !known {
+				return nil
+			}
+		}
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		return rangeLess(resources[i].DefRange, resources[j].DefRange)
+	})
+	taggable := taggableTypes(resources)
+
+	for _, resource := range resources {
+		// Resources like aws_autoscaling_group tag through `tag` blocks, which provider default tags don't reach
+		if len(resource.Body.Blocks) > 0 {
+			resource := resource
+			if err := evaluateTagBlockKeys(runner, resource.Body.Blocks, func(keys []string) error {
+				missing := missingTags(required, keys)
+				if len(missing) == 0 {
+					return nil
+				}
+				return r.emitMissing(runner, resource, missing, resource.DefRange)
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !taggable[resource.Labels[0]] {
+			continue
+		}
+
+		tags, ok := resource.Body.Attributes["tags"]
+		if !ok {
+			if missing := missingTags(required, defaults); len(missing) > 0 {
+				if err := r.emitMissing(runner, resource, missing, resource.DefRange); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		resource := resource
+		if err := evaluateTagKeys(runner, tags.Expr, func(keys []string) error {
+			missing := missingTags(required, append(keys, defaults...))
+			if len(missing) == 0 {
+				return nil
+			}
+			return r.emitMissing(runner, resource, missing, tags.Expr.Range())
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Kb4RequiredTagsRule) emitMissing(runner tflint.Runner, resource *hclext.Block, missing []string, rng hcl.Range) error {
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("%s is missing the required tags %s", describeBlock(resource), strings.Join(missing, ", ")),
+		rng,
+	)
+}
+
+// missingTags returns the required keys that aren't among keys, in the order they are required
+func missingTags(required []string, keys []string) []string {
+	missing := []string{}
+	for _, key := range required {
+		if !containsString(keys, key) {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
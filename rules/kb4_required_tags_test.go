@@ -0,0 +1,229 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_Kb4RequiredTagsRule(t *testing.T) {
+	policy := &Policy{RequiredTags: []string{"Team", "Service"}}
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Policy   *Policy
+		TagKeys  []string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no required tags",
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  tags = {}
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "missing keys",
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  tags = {
+    Team = "sre"
+  }
+}
+
+resource "aws_s3_bucket" "assets" {
+  tags = var.tags
+}
+
+variable "tags" {
+  default = {
+    Team    = "sre"
+    Service = "web"
+  }
+}`,
+			Policy: policy,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4RequiredTagsRule(),
+					Message: `resource "aws_s3_bucket" "logs" is missing the required tags Service`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 5, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			Name: "no tags",
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  bucket = "kb4-logs"
+}
+
+resource "aws_iam_role_policy_attachment" "logs" {
+  role = "logs"
+}
+
+resource "aws_glue_job" "etl" {
+  tags = {
+    Team    = "sre"
+    Service = "etl"
+  }
+}
+
+resource "aws_glue_job" "report" {
+  name = "report"
+}`,
+			Policy: policy,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4RequiredTagsRule(),
+					Message: `resource "aws_s3_bucket" "logs" is missing the required tags Team, Service`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 32},
+					},
+				},
+				{
+					Rule:    NewKb4RequiredTagsRule(),
+					Message: `resource "aws_glue_job" "report" is missing the required tags Team, Service`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 17, Column: 1},
+						End:      hcl.Pos{Line: 17, Column: 33},
+					},
+				},
+			},
+		},
+		{
+			Name: "provider default tags",
+			Content: `
+provider "aws" {
+  default_tags {
+    tags = {
+      Team = "sre"
+    }
+  }
+}
+
+resource "aws_s3_bucket" "logs" {}
+
+resource "aws_s3_bucket" "assets" {
+  tags = {
+    Service = "web"
+  }
+}`,
+			Policy: policy,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4RequiredTagsRule(),
+					Message: `resource "aws_s3_bucket" "logs" is missing the required tags Service`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 10, Column: 1},
+						End:      hcl.Pos{Line: 10, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			Name: "tag blocks",
+			Content: `
+resource "aws_autoscaling_group" "web" {
+  tag {
+    key                 = "Team"
+    value               = "sre"
+    propagate_at_launch = true
+  }
+  tag {
+    key                 = "Service"
+    value               = "web"
+    propagate_at_launch = true
+  }
+}
+
+resource "aws_autoscaling_group" "worker" {
+  tag {
+    key                 = "Team"
+    value               = "sre"
+    propagate_at_launch = true
+  }
+}`,
+			Policy: policy,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4RequiredTagsRule(),
+					Message: `resource "aws_autoscaling_group" "worker" is missing the required tags Service`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 15, Column: 1},
+						End:      hcl.Pos{Line: 15, Column: 42},
+					},
+				},
+			},
+		},
+		{
+			Name: "default tag keys",
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  tags = {
+    Team = "sre"
+  }
+}`,
+			TagKeys: []string{"Team", "Environment"},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4RequiredTagsRule(),
+					Message: `resource "aws_s3_bucket" "logs" is missing the required tags Environment`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 5, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			Name: "policy over default tag keys",
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  tags = {
+    Team    = "sre"
+    Service = "web"
+  }
+}`,
+			Policy:   policy,
+			TagKeys:  []string{"Environment"},
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewKb4RequiredTagsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			testRunner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			var runner tflint.Runner = testRunner
+			if tc.Policy != nil || tc.TagKeys != nil {
+				config := DefaultConfig()
+				config.DefaultTagKeys = tc.TagKeys
+				config.policy = tc.Policy
+				runner = NewRunner(testRunner, config)
+			}
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, testRunner.Issues)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4ResourceTypeFilesRuleConfig is the rule's .tflint.hcl config
+type Kb4ResourceTypeFilesRuleConfig struct {
+	MaxFiles int `hclext:"max_files,optional" doc:"Most files resources of one type may be spread across."`
+}
+
+func newKb4ResourceTypeFilesRuleConfig() *Kb4ResourceTypeFilesRuleConfig {
+	return &Kb4ResourceTypeFilesRuleConfig{MaxFiles: 2}
+}
+
+// Validate rejects limits below one
+func (c *Kb4ResourceTypeFilesRuleConfig) Validate() error {
+	if c.MaxFiles < 1 {
+		return fmt.Errorf("max_files must be at least 1")
+	}
+	return nil
+}
+
+// Kb4ResourceTypeFilesRule checks that resources of one type are kept together
+type Kb4ResourceTypeFilesRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4ResourceTypeFilesRule())
+}
+
+// NewKb4ResourceTypeFilesRule returns a new rule
+func NewKb4ResourceTypeFilesRule() *Kb4ResourceTypeFilesRule {
+	return &Kb4ResourceTypeFilesRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4ResourceTypeFilesRule) Name() string {
+	return "kb4_resource_type_files"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4ResourceTypeFilesRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4ResourceTypeFilesRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4ResourceTypeFilesRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4ResourceTypeFilesRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Resources of one type should live in at most `max_files` files. The style guide organizes modules by service, so `aws_iam_role` resources spread across many files usually belong in one `iam.tf`.",
+		Categories:  []string{CategoryStyle},
+		Anchor:      "standard-files-names-and-usage",
+		Example: `
+# api.tf
+resource "aws_iam_role" "api" {}
+
+# worker.tf
+resource "aws_iam_role" "worker" {}
+
+# scheduler.tf
+resource "aws_iam_role" "scheduler" {}`,
+		Config: newKb4ResourceTypeFilesRuleConfig(),
+	}
+}
+
+// Check emits an issue for every resource type spread across too many files, on its first resource
+func (r *Kb4ResourceTypeFilesRule) Check(runner tflint.Runner) error {
+	config := newKb4ResourceTypeFilesRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	resources := []*hclext.Block{}
+	for _, resource := range content.Blocks {
+		if !ruleSetConfig(runner).excludesFile(resource.DefRange.Filename) {
+			resources = append(resources, resource)
+		}
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		return rangeLess(resources[i].DefRange, resources[j].DefRange)
+	})
+
+	types := []string{}
+	first := map[string]*hclext.Block{}
+	files := map[string][]string{}
+	for _, resource := range resources {
+		ty := resource.Labels[0]
+		if _, ok := first[ty]; !ok {
+			types = append(types, ty)
+			first[ty] = resource
+		}
+		if !containsString(files[ty], resource.DefRange.Filename) {
+			files[ty] = append(files[ty], resource.DefRange.Filename)
+		}
+	}
+
+	for _, ty := range types {
+		if len(files[ty]) <= config.MaxFiles {
+			continue
+		}
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s resources are spread across %d files (%s); keep them in one file per service", ty, len(files[ty]), strings.Join(files[ty], ", ")),
+			first[ty].DefRange,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
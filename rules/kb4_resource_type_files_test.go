@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4ResourceTypeFilesRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "two files",
+			Files: map[string]string{
+				"iam.tf": `
+resource "aws_iam_role" "api" {}
+
+resource "aws_iam_role" "worker" {}`,
+				"scheduler.tf": `resource "aws_iam_role" "scheduler" {}`,
+				"override.tf":  `resource "aws_iam_role" "api" {}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "three files",
+			Files: map[string]string{
+				"api.tf":       `resource "aws_iam_role" "api" {}`,
+				"worker.tf":    `resource "aws_iam_role" "worker" {}`,
+				"scheduler.tf": `resource "aws_iam_role" "scheduler" {}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ResourceTypeFilesRule(),
+					Message: "aws_iam_role resources are spread across 3 files (api.tf, scheduler.tf, worker.tf); keep them in one file per service",
+					Range: hcl.Range{
+						Filename: "api.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 30},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured limit",
+			Files: map[string]string{
+				"api.tf":    `resource "aws_sqs_queue" "api" {}`,
+				"worker.tf": `resource "aws_sqs_queue" "worker" {}`,
+				".tflint.hcl": `
+rule "kb4_resource_type_files" {
+  enabled   = true
+  max_files = 1
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4ResourceTypeFilesRule(),
+					Message: "aws_sqs_queue resources are spread across 2 files (api.tf, worker.tf); keep them in one file per service",
+					Range: hcl.Range{
+						Filename: "api.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 31},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4ResourceTypeFilesRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.Files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
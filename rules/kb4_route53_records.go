@@ -0,0 +1,151 @@
+package rules
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kb4Route53RecordsRuleConfig is the rule's .tflint.hcl config
+type Kb4Route53RecordsRuleConfig struct {
+	MinTTL int `hclext:"min_ttl,optional" doc:"Shortest TTL, in seconds, records may set."`
+	MaxTTL int `hclext:"max_ttl,optional" doc:"Longest TTL, in seconds, records may set."`
+}
+
+func newKb4Route53RecordsRuleConfig() *Kb4Route53RecordsRuleConfig {
+	return &Kb4Route53RecordsRuleConfig{MinTTL: 60, MaxTTL: 86400}
+}
+
+// Validate rejects negative and inverted ranges
+func (c *Kb4Route53RecordsRuleConfig) Validate() error {
+	if c.MinTTL < 0 || c.MaxTTL < c.MinTTL {
+		return fmt.Errorf("min_ttl must be at least 0 and no greater than max_ttl")
+	}
+	return nil
+}
+
+// Kb4Route53RecordsRule checks that Route53 records use sensible TTLs and take their domain from the zone
+type Kb4Route53RecordsRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4Route53RecordsRule())
+}
+
+// NewKb4Route53RecordsRule returns a new rule
+func NewKb4Route53RecordsRule() *Kb4Route53RecordsRule {
+	return &Kb4Route53RecordsRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4Route53RecordsRule) Name() string {
+	return "kb4_route53_records"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4Route53RecordsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4Route53RecordsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4Route53RecordsRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4Route53RecordsRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`aws_route53_record` resources must set a `ttl` between `min_ttl` and `max_ttl`, and their `name` must not end in a hard-coded domain. End it with the zone's domain variable, or use a name relative to the zone, so the record moves with the zone in sub-environments.",
+		Categories:  []string{CategoryStyle},
+		Anchor:      "networking",
+		Example: `
+resource "aws_route53_record" "api" {
+  name = "api.${var.environment}.knowbe4.com"
+  ttl  = 5
+}`,
+		Config: newKb4Route53RecordsRuleConfig(),
+	}
+}
+
+// Check emits issues for TTLs outside the range and names ending in a literal domain
+func (r *Kb4Route53RecordsRule) Check(runner tflint.Runner) error {
+	config := newKb4Route53RecordsRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetResourceContent("aws_route53_record", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "name"}, {Name: "ttl"}},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, record := range content.Blocks {
+		if attr, ok := record.Body.Attributes["ttl"]; ok {
+			var value cty.Value
+			err := runner.EvaluateExpr(attr.Expr, &value, nil)
+			err = runner.EnsureNoError(err, func() error {
+				if value.Type() != cty.Number || !value.IsKnown() || value.IsNull() {
+					return nil
+				}
+				ttl := value.AsBigFloat()
+				if ttl.Cmp(big.NewFloat(float64(config.MinTTL))) >= 0 && ttl.Cmp(big.NewFloat(float64(config.MaxTTL))) <= 0 {
+					return nil
+				}
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("TTL %s is outside the allowed range of %d to %d seconds", ttl.Text('f', -1), config.MinTTL, config.MaxTTL),
+					attr.Expr.Range(),
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if attr, ok := record.Body.Attributes["name"]; ok {
+			expr, ok := attr.Expr.(hclsyntax.Expression)
+			if !ok || !hardCodesDomain(expr) {
+				continue
+			}
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s hard-codes its domain; end the name with the zone's domain variable or make it relative to the zone", describeBlock(record)),
+				attr.Expr.Range(),
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// hardCodesDomain reports whether a record name ends in literal text with a dot in it, e.g. "api.knowbe4.com"
+// or "api.${var.environment}.knowbe4.com". A lone trailing dot, as in "${var.domain}.", doesn't count.
+func hardCodesDomain(expr hclsyntax.Expression) bool {
+	last := expr
+	if template, ok := expr.(*hclsyntax.TemplateExpr); ok && len(template.Parts) > 0 {
+		last = template.Parts[len(template.Parts)-1]
+	}
+
+	value, ok := literalString(last)
+	if !ok {
+		return false
+	}
+	return strings.Contains(strings.TrimSuffix(value, "."), ".")
+}
@@ -0,0 +1,124 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4Route53RecordsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "zone relative names",
+			Content: `
+variable "domain" {}
+
+resource "aws_route53_record" "api" {
+  name = "api.${var.domain}"
+  ttl  = 300
+}
+
+resource "aws_route53_record" "apex" {
+  name = "${data.aws_route53_zone.main.name}."
+}
+
+resource "aws_route53_record" "www" {
+  name = "www"
+  ttl  = 3600
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "hard-coded domains and TTLs",
+			Content: `
+variable "environment" {}
+
+resource "aws_route53_record" "api" {
+  name = "api.${var.environment}.knowbe4.com"
+  ttl  = 5
+}
+
+resource "aws_route53_record" "www" {
+  name = "www.knowbe4.com"
+  ttl  = 604800
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4Route53RecordsRule(),
+					Message: "TTL 5 is outside the allowed range of 60 to 86400 seconds",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 10},
+						End:      hcl.Pos{Line: 6, Column: 11},
+					},
+				},
+				{
+					Rule:    NewKb4Route53RecordsRule(),
+					Message: "resource \"aws_route53_record\" \"api\" hard-codes its domain; end the name with the zone's domain variable or make it relative to the zone",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 10},
+						End:      hcl.Pos{Line: 5, Column: 46},
+					},
+				},
+				{
+					Rule:    NewKb4Route53RecordsRule(),
+					Message: "TTL 604800 is outside the allowed range of 60 to 86400 seconds",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 10},
+						End:      hcl.Pos{Line: 11, Column: 16},
+					},
+				},
+				{
+					Rule:    NewKb4Route53RecordsRule(),
+					Message: "resource \"aws_route53_record\" \"www\" hard-codes its domain; end the name with the zone's domain variable or make it relative to the zone",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 10, Column: 10},
+						End:      hcl.Pos{Line: 10, Column: 27},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured TTL range",
+			Content: `
+resource "aws_route53_record" "www" {
+  name = "www"
+  ttl  = 30
+}`,
+			Config: `
+rule "kb4_route53_records" {
+  enabled = true
+  min_ttl = 10
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewKb4Route53RecordsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
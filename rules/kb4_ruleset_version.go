@@ -0,0 +1,148 @@
+package rules
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kb4RulesetVersionRuleConfig is the rule's .tflint.hcl config
+type Kb4RulesetVersionRuleConfig struct {
+	ConfigFile string `hclext:"config_file,optional" doc:"TFLint config holding the plugin block, relative to where tflint runs."`
+}
+
+func newKb4RulesetVersionRuleConfig() *Kb4RulesetVersionRuleConfig {
+	return &Kb4RulesetVersionRuleConfig{ConfigFile: ".tflint.hcl"}
+}
+
+// Validate rejects an empty config file
+func (c *Kb4RulesetVersionRuleConfig) Validate() error {
+	if c.ConfigFile == "" {
+		return fmt.Errorf("config_file must not be empty")
+	}
+	return nil
+}
+
+// Kb4RulesetVersionRule checks that the repo pins the kb4 ruleset at the version running, or a newer one
+type Kb4RulesetVersionRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4RulesetVersionRule())
+}
+
+// NewKb4RulesetVersionRule returns a new rule
+func NewKb4RulesetVersionRule() *Kb4RulesetVersionRule {
+	return &Kb4RulesetVersionRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4RulesetVersionRule) Name() string {
+	return "kb4_ruleset_version"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4RulesetVersionRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4RulesetVersionRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4RulesetVersionRule) Link() string {
+	return "https://github.com/kb4sre/tflint-ruleset-kb4/blob/main/README.md#installation"
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4RulesetVersionRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "The `version` pinned in the `plugin \"kb4\"` block of `.tflint.hcl` must not be older than the ruleset running, so repos running a newer plugin, e.g. from a CI image, upgrade their pin and get the same rules locally. A config without a pinned version is not checked. The rule reports regardless of `changed_files`.",
+		Categories:  []string{CategoryStructure},
+		Example: `
+# .tflint.hcl, checked by version 0.4.0 of the ruleset
+plugin "kb4" {
+  enabled = true
+  version = "0.3.8"
+  source  = "github.com/kb4sre/tflint-ruleset-kb4"
+}`,
+		Config: newKb4RulesetVersionRuleConfig(),
+	}
+}
+
+// Check emits an issue when the plugin block pins an older version than the one running.
+// The host only hands plugins the module's files, so the config is read from disk.
+func (r *Kb4RulesetVersionRule) Check(runner tflint.Runner) error {
+	config := newKb4RulesetVersionRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	running, ok := parseModuleVersion(rulesetVersion(runner))
+	if !ok {
+		return nil
+	}
+
+	src, err := ioutil.ReadFile(config.ConfigFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %s", config.ConfigFile, err)
+	}
+
+	attr, ok := pinnedVersionAttribute(src, config.ConfigFile)
+	if !ok {
+		return nil
+	}
+
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || value.IsNull() || !value.IsKnown() || value.Type() != cty.String {
+		return nil
+	}
+	pinned, ok := parseModuleVersion(value.AsString())
+	if !ok || !running.newerThan(pinned) {
+		return nil
+	}
+
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("%s pins the %s ruleset at %s, older than the running %s; bump `version` in the plugin block", config.ConfigFile, RuleSetName, pinned, running),
+		attr.Expr.Range(),
+	)
+}
+
+// pinnedVersionAttribute returns the version attribute of the kb4 plugin block in a TFLint config.
+// A config that doesn't parse is left to tflint, which reports it before any rule runs.
+func pinnedVersionAttribute(src []byte, filename string) (*hcl.Attribute, bool) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, false
+	}
+
+	content, _, _ := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "plugin", LabelNames: []string{"name"}}},
+	})
+	for _, block := range content.Blocks {
+		if block.Labels[0] != RuleSetName {
+			continue
+		}
+
+		plugin, _, _ := block.Body.PartialContent(&hcl.BodySchema{
+			Attributes: []hcl.AttributeSchema{{Name: "version"}},
+		})
+		if attr, ok := plugin.Attributes["version"]; ok {
+			return attr, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,142 @@
+package rules
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4RulesetVersionRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   string
+		Running  string
+		Expected func(path string) helper.Issues
+	}{
+		{
+			Name: "older pin",
+			Config: `
+plugin "aws" {
+  enabled = true
+  version = "0.1.0"
+}
+
+plugin "kb4" {
+  enabled = true
+  version = "0.3.8"
+  source  = "github.com/kb4sre/tflint-ruleset-kb4"
+}`,
+			Running: "0.4.0",
+			Expected: func(path string) helper.Issues {
+				return helper.Issues{
+					{
+						Rule:    NewKb4RulesetVersionRule(),
+						Message: fmt.Sprintf("%s pins the kb4 ruleset at 0.3.8, older than the running 0.4.0; bump `version` in the plugin block", path),
+						Range: hcl.Range{
+							Filename: path,
+							Start:    hcl.Pos{Line: 9, Column: 13},
+							End:      hcl.Pos{Line: 9, Column: 20},
+						},
+					},
+				}
+			},
+		},
+		{
+			Name: "current pin",
+			Config: `
+plugin "kb4" {
+  enabled = true
+  version = "0.4.0"
+}`,
+			Running: "0.4.0",
+		},
+		{
+			Name: "newer pin",
+			Config: `
+plugin "kb4" {
+  enabled = true
+  version = "0.5.0"
+}`,
+			Running: "0.4.0",
+		},
+		{
+			Name: "prerelease build",
+			Config: `
+plugin "kb4" {
+  enabled = true
+  version = "0.4.0"
+}`,
+			Running: "0.4.0-rc.1",
+		},
+		{
+			Name: "no pin",
+			Config: `
+plugin "kb4" {
+  enabled = true
+}`,
+			Running: "0.4.0",
+		},
+		{
+			Name: "version unknown",
+			Config: `
+plugin "kb4" {
+  enabled = true
+  version = "0.3.8"
+}`,
+			Running: "",
+		},
+	}
+
+	rule := NewKb4RulesetVersionRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".tflint.hcl")
+			if err := ioutil.WriteFile(path, []byte(tc.Config), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			host := helper.TestRunner(t, map[string]string{
+				".tflint.hcl": fmt.Sprintf(`
+rule "kb4_ruleset_version" {
+  enabled     = true
+  config_file = %q
+}`, path),
+			})
+			runner := NewRunner(host, nil)
+			runner.version = tc.Running
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			expected := helper.Issues{}
+			if tc.Expected != nil {
+				expected = tc.Expected(path)
+			}
+			helper.AssertIssues(t, expected, host.Issues)
+		})
+	}
+}
+
+func Test_Kb4RulesetVersionRule_missingConfig(t *testing.T) {
+	host := helper.TestRunner(t, map[string]string{
+		".tflint.hcl": fmt.Sprintf(`
+rule "kb4_ruleset_version" {
+  enabled     = true
+  config_file = %q
+}`, filepath.Join(t.TempDir(), ".tflint.hcl")),
+	})
+	runner := NewRunner(host, nil)
+	runner.version = "0.4.0"
+
+	if err := NewKb4RulesetVersionRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	helper.AssertIssues(t, helper.Issues{}, host.Issues)
+}
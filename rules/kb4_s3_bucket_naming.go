@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4S3BucketNamingRuleConfig is the rule's .tflint.hcl config
+type Kb4S3BucketNamingRuleConfig struct {
+	Pattern string `hclext:"pattern,optional" doc:"Regular expression bucket names must match."`
+}
+
+func newKb4S3BucketNamingRuleConfig() *Kb4S3BucketNamingRuleConfig {
+	return &Kb4S3BucketNamingRuleConfig{Pattern: bucketNamePattern("kb4")}
+}
+
+// bucketNamePattern matches lowercase, hyphenated bucket names with no dots that start with prefix
+func bucketNamePattern(prefix string) string {
+	return "^" + regexp.QuoteMeta(prefix) + `-[a-z0-9]+(-[a-z0-9]+)*$`
+}
+
+// defaultBucketPattern returns the pattern used when the rule block sets none:
+// the org policy's naming pattern, else one prefixed with the plugin block's organization, else the rule's own default
+func defaultBucketPattern(runner tflint.Runner) string {
+	if pattern, ok := orgPolicy(runner).Naming["aws_s3_bucket"]; ok {
+		return pattern
+	}
+	if org := ruleSetConfig(runner).Organization; org != "" {
+		return bucketNamePattern(org)
+	}
+	return newKb4S3BucketNamingRuleConfig().Pattern
+}
+
+// Validate rejects a pattern that doesn't compile
+func (c *Kb4S3BucketNamingRuleConfig) Validate() error {
+	if _, err := regexp.Compile(c.Pattern); err != nil {
+		return fmt.Errorf("pattern is invalid: %s", err)
+	}
+	return nil
+}
+
+// Kb4S3BucketNamingRule checks that S3 bucket names follow the naming convention
+type Kb4S3BucketNamingRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4S3BucketNamingRule())
+}
+
+// NewKb4S3BucketNamingRule returns a new rule
+func NewKb4S3BucketNamingRule() *Kb4S3BucketNamingRule {
+	return &Kb4S3BucketNamingRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4S3BucketNamingRule) Name() string {
+	return "kb4_s3_bucket_naming"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4S3BucketNamingRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4S3BucketNamingRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4S3BucketNamingRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4S3BucketNamingRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "The `bucket` of `aws_s3_bucket` resources must match `pattern`, by default the org policy's `naming` pattern for `aws_s3_bucket`. Without one, names must be the plugin block's `organization` followed by lowercase, hyphenated words with no dots, e.g. `knowbe4-logs`, or start with `kb4-` when no organization is set. Interpolated variables are resolved where their values are known, e.g. from defaults or tfvars; names that can't be resolved are skipped.",
+		Categories:  []string{CategoryNaming},
+		Anchor:      "naming",
+		Example: `
+resource "aws_s3_bucket" "logs" {
+  bucket = "KB4.${var.environment}_logs"
+}`,
+		Config: newKb4S3BucketNamingRuleConfig(),
+	}
+}
+
+// Check emits an issue for every resolvable bucket name that doesn't match the pattern
+func (r *Kb4S3BucketNamingRule) Check(runner tflint.Runner) error {
+	config := &Kb4S3BucketNamingRuleConfig{Pattern: defaultBucketPattern(runner)}
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+	pattern := regexp.MustCompile(config.Pattern)
+
+	content, err := runner.GetResourceContent("aws_s3_bucket", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "bucket"}},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, bucket := range content.Blocks {
+		attr, ok := bucket.Body.Attributes["bucket"]
+		if !ok {
+			continue
+		}
+
+		err := evaluateString(runner, attr.Expr, func(name string) error {
+			if pattern.MatchString(name) {
+				return nil
+			}
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s` bucket name should match %s", name, config.Pattern),
+				attr.Expr.Range(),
+			)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_Kb4S3BucketNamingRule(t *testing.T) {
+	cases := []struct {
+		Name         string
+		Content      string
+		Config       string
+		Policy       *Policy
+		Organization string
+		Expected     helper.Issues
+	}{
+		{
+			Name: "conventional names",
+			Content: `
+variable "environment" {
+  default = "dev"
+}
+
+variable "name" {}
+
+resource "aws_s3_bucket" "logs" {
+  bucket = "kb4-${var.environment}-logs"
+}
+
+resource "aws_s3_bucket" "unresolved" {
+  bucket = var.name
+}
+
+resource "aws_s3_bucket" "generated" {
+  bucket_prefix = "kb4-"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "unconventional names",
+			Content: `
+variable "environment" {
+  default = "Dev"
+}
+
+resource "aws_s3_bucket" "logs" {
+  bucket = "kb4-${var.environment}-logs"
+}
+
+resource "aws_s3_bucket" "assets" {
+  bucket = "assets.knowbe4.com"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4S3BucketNamingRule(),
+					Message: "`kb4-Dev-logs` bucket name should match ^kb4-[a-z0-9]+(-[a-z0-9]+)*$",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 12},
+						End:      hcl.Pos{Line: 7, Column: 41},
+					},
+				},
+				{
+					Rule:    NewKb4S3BucketNamingRule(),
+					Message: "`assets.knowbe4.com` bucket name should match ^kb4-[a-z0-9]+(-[a-z0-9]+)*$",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 12},
+						End:      hcl.Pos{Line: 11, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			Name: "custom pattern",
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  bucket = "kb4-logs"
+}`,
+			Config: `
+rule "kb4_s3_bucket_naming" {
+  enabled = true
+  pattern = "^knowbe4-[a-z0-9-]+$"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4S3BucketNamingRule(),
+					Message: "`kb4-logs` bucket name should match ^knowbe4-[a-z0-9-]+$",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 12},
+						End:      hcl.Pos{Line: 3, Column: 22},
+					},
+				},
+			},
+		},
+		{
+			Name: "organization prefix",
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  bucket = "kb4-logs"
+}
+
+resource "aws_s3_bucket" "assets" {
+  bucket = "knowbe4-assets"
+}`,
+			Organization: "knowbe4",
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4S3BucketNamingRule(),
+					Message: "`kb4-logs` bucket name should match ^knowbe4-[a-z0-9]+(-[a-z0-9]+)*$",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 12},
+						End:      hcl.Pos{Line: 3, Column: 22},
+					},
+				},
+			},
+		},
+		{
+			Name: "policy pattern over organization prefix",
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  bucket = "kb4-logs"
+}`,
+			Policy:       &Policy{Naming: map[string]string{"aws_s3_bucket": "^knowbe4-"}},
+			Organization: "example",
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4S3BucketNamingRule(),
+					Message: "`kb4-logs` bucket name should match ^knowbe4-",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 12},
+						End:      hcl.Pos{Line: 3, Column: 22},
+					},
+				},
+			},
+		},
+		{
+			Name: "rule pattern over policy pattern",
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  bucket = "kb4-logs"
+}`,
+			Config: `
+rule "kb4_s3_bucket_naming" {
+  enabled = true
+  pattern = "^kb4-"
+}`,
+			Policy:   &Policy{Naming: map[string]string{"aws_s3_bucket": "^knowbe4-"}},
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewKb4S3BucketNamingRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			testRunner := helper.TestRunner(t, files)
+
+			var runner tflint.Runner = testRunner
+			if tc.Policy != nil || tc.Organization != "" {
+				config := DefaultConfig()
+				config.Organization = tc.Organization
+				config.policy = tc.Policy
+				runner = NewRunner(testRunner, config)
+			}
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, testRunner.Issues)
+		})
+	}
+}
@@ -0,0 +1,404 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// publicCannedACLs are the canned ACLs that open a bucket to everyone
+var publicCannedACLs = []string{"public-read", "public-read-write"}
+
+// Kb4S3PublicAccessRuleConfig is the rule's .tflint.hcl config
+type Kb4S3PublicAccessRuleConfig struct {
+	AllowedConditionKeys []string `hclext:"allowed_condition_keys,optional" doc:"Condition keys that scope a statement allowing Principal \"*\" enough to accept it, matched case-insensitively."`
+}
+
+func newKb4S3PublicAccessRuleConfig() *Kb4S3PublicAccessRuleConfig {
+	return &Kb4S3PublicAccessRuleConfig{
+		AllowedConditionKeys: []string{"aws:SourceVpce", "aws:SourceVpc", "aws:PrincipalOrgID", "aws:SourceArn", "aws:SourceAccount"},
+	}
+}
+
+// allowsCondition reports whether any of the keys is allow-listed
+func (c *Kb4S3PublicAccessRuleConfig) allowsCondition(keys []string) bool {
+	for _, key := range keys {
+		for _, allowed := range c.AllowedConditionKeys {
+			if strings.EqualFold(key, allowed) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Kb4S3PublicAccessRule checks that S3 buckets aren't opened to everyone by ACLs or bucket policies
+type Kb4S3PublicAccessRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4S3PublicAccessRule())
+}
+
+// NewKb4S3PublicAccessRule returns a new rule
+func NewKb4S3PublicAccessRule() *Kb4S3PublicAccessRule {
+	return &Kb4S3PublicAccessRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4S3PublicAccessRule) Name() string {
+	return "kb4_s3_public_access"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4S3PublicAccessRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4S3PublicAccessRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4S3PublicAccessRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4S3PublicAccessRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "S3 buckets must not set the `public-read` or `public-read-write` canned ACL, and bucket policies must not allow `Principal: \"*\"` unless the statement has a condition on one of `allowed_condition_keys`. Policies are read from `jsonencode()`, JSON strings and `aws_iam_policy_document` data sources. Intentionally public buckets can be exempted with a `# kb4:exempt kb4_s3_public_access <justification>` comment on the line above.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "storage",
+		Example: `
+resource "aws_s3_bucket_acl" "assets" {
+  bucket = aws_s3_bucket.assets.id
+  acl    = "public-read"
+}
+
+resource "aws_s3_bucket_policy" "assets" {
+  bucket = aws_s3_bucket.assets.id
+  policy = jsonencode({
+    Statement = [{
+      Effect    = "Allow"
+      Principal = "*"
+      Action    = "s3:GetObject"
+      Resource  = "${aws_s3_bucket.assets.arn}/*"
+    }]
+  })
+}`,
+		Config: newKb4S3PublicAccessRuleConfig(),
+	}
+}
+
+// Check emits an issue for every public ACL and every unconditioned public policy statement that isn't exempt
+func (r *Kb4S3PublicAccessRule) Check(runner tflint.Runner) error {
+	config := newKb4S3PublicAccessRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	exempt, err := loadExemptions(runner, r)
+	if err != nil {
+		return err
+	}
+
+	documents, err := policyDocumentSources(runner)
+	if err != nil {
+		return err
+	}
+
+	resources := []*hclext.Block{}
+	for _, resourceType := range []string{"aws_s3_bucket", "aws_s3_bucket_acl", "aws_s3_bucket_policy"} {
+		content, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "acl"}, {Name: "policy"}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, content.Blocks...)
+	}
+
+	for _, resource := range resources {
+		if attr, ok := resource.Body.Attributes["acl"]; ok {
+			err := evaluateString(runner, attr.Expr, func(acl string) error {
+				for _, public := range publicCannedACLs {
+					if acl == public {
+						return r.emit(runner, exempt, fmt.Sprintf("%s sets the public `%s` ACL; keep the bucket private", describeBlock(resource), acl), resource, attr.Expr.Range())
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if attr, ok := resource.Body.Attributes["policy"]; ok {
+			for _, statement := range publicStatements(attr.Expr, documents, config) {
+				message := fmt.Sprintf("%s allows Principal \"*\" in %s without a condition on one of %s", describeBlock(resource), statement, strings.Join(config.AllowedConditionKeys, ", "))
+				if err := r.emit(runner, exempt, message, resource, attr.Expr.Range()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// emit reports the issue unless the resource or the attribute is exempt
+func (r *Kb4S3PublicAccessRule) emit(runner tflint.Runner, exempt *exemptions, message string, resource *hclext.Block, rng hcl.Range) error {
+	ok, unjustified := exempt.applies(resource.DefRange, rng)
+	if ok {
+		return nil
+	}
+	return runner.EmitIssue(r, exemptionMessage(message, unjustified), rng)
+}
+
+// policyDocumentSources returns the statement blocks of the module's aws_iam_policy_document data sources, by name
+func policyDocumentSources(runner tflint.Runner) (map[string][]*hclext.Block, error) {
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "data",
+				LabelNames: []string{"type", "name"},
+				Body: &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "statement",
+							Body: &hclext.BodySchema{
+								Attributes: []hclext.AttributeSchema{{Name: "sid"}, {Name: "effect"}},
+								Blocks: []hclext.BlockSchema{
+									{Type: "principals", Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "type"}, {Name: "identifiers"}}}},
+									{Type: "condition", Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "variable"}}}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	documents := map[string][]*hclext.Block{}
+	for _, data := range content.Blocks {
+		if data.Labels[0] == "aws_iam_policy_document" {
+			documents[data.Labels[1]] = data.Body.Blocks
+		}
+	}
+	return documents, nil
+}
+
+// publicStatements describes the statements of the policy that allow Principal "*" without an allow-listed condition.
+// Parts of the policy that can't be evaluated statically, e.g. a resource ARN, are unknown, and an unknown
+// effect, principal or condition is given the benefit of the doubt.
+func publicStatements(expr hcl.Expression, documents map[string][]*hclext.Block, config *Kb4S3PublicAccessRuleConfig) []string {
+	found := []string{}
+
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "data" || traversalAttr(traversal) != "aws_iam_policy_document" || len(traversal) < 3 {
+			continue
+		}
+		name, ok := traversal[2].(hcl.TraverseAttr)
+		if !ok {
+			continue
+		}
+		for _, statement := range documents[name.Name] {
+			if described, ok := publicSourceStatement(statement, config); ok {
+				found = append(found, fmt.Sprintf("%s of data.aws_iam_policy_document.%s", described, name.Name))
+			}
+		}
+	}
+
+	document := policyDocumentValue(expr)
+	statements := policyAttr(document, "Statement")
+	if statements.IsKnown() && !statements.IsNull() && !statements.Type().IsTupleType() && !statements.Type().IsListType() {
+		statements = cty.TupleVal([]cty.Value{statements})
+	}
+	for _, statement := range policyElements(statements) {
+		if !allowsEffect(policyAttr(statement, "Effect")) || !policyStrings(policyAttr(statement, "Principal"), "*") {
+			continue
+		}
+		keys := []string{}
+		for _, operator := range policyElements(policyAttr(statement, "Condition")) {
+			keys = append(keys, policyKeys(operator)...)
+		}
+		if !config.allowsCondition(keys) {
+			found = append(found, describeStatement(policyAttr(statement, "Sid")))
+		}
+	}
+
+	return found
+}
+
+// publicSourceStatement describes a statement block of an aws_iam_policy_document that allows Principal "*"
+// without an allow-listed condition
+func publicSourceStatement(statement *hclext.Block, config *Kb4S3PublicAccessRuleConfig) (string, bool) {
+	if attr, ok := statement.Body.Attributes["effect"]; ok && !allowsEffect(staticValue(attr.Expr)) {
+		return "", false
+	}
+
+	public := false
+	for _, principals := range statement.Body.Blocks {
+		if principals.Type != "principals" {
+			continue
+		}
+		kind, identifiers := principals.Body.Attributes["type"], principals.Body.Attributes["identifiers"]
+		if kind == nil || identifiers == nil {
+			continue
+		}
+		if policyStrings(staticValue(kind.Expr), "*") || policyStrings(staticValue(kind.Expr), "AWS") && policyStrings(staticValue(identifiers.Expr), "*") {
+			public = true
+		}
+	}
+	if !public {
+		return "", false
+	}
+
+	keys := []string{}
+	for _, condition := range statement.Body.Blocks {
+		if variable, ok := condition.Body.Attributes["variable"]; ok && condition.Type == "condition" {
+			value := staticValue(variable.Expr)
+			if !value.IsWhollyKnown() {
+				return "", false
+			}
+			if value.Type() == cty.String && !value.IsNull() {
+				keys = append(keys, value.AsString())
+			}
+		}
+	}
+	if config.allowsCondition(keys) {
+		return "", false
+	}
+
+	sid := cty.NilVal
+	if attr, ok := statement.Body.Attributes["sid"]; ok {
+		sid = staticValue(attr.Expr)
+	}
+	return describeStatement(sid), true
+}
+
+// describeStatement names a statement by its Sid, when it has a known one
+func describeStatement(sid cty.Value) string {
+	if sid != cty.NilVal && sid.IsKnown() && !sid.IsNull() && sid.Type() == cty.String && sid.AsString() != "" {
+		return fmt.Sprintf("statement `%s`", sid.AsString())
+	}
+	return "a statement"
+}
+
+// policyDocumentValue evaluates a policy document written as jsonencode() of an object or as a JSON string.
+// The object passed to jsonencode() is evaluated rather than the call, so unknown parts don't hide the rest.
+func policyDocumentValue(expr hcl.Expression) cty.Value {
+	if call, ok := expr.(*hclsyntax.FunctionCallExpr); ok && call.Name == "jsonencode" && len(call.Args) == 1 {
+		return staticValue(call.Args[0])
+	}
+
+	value := staticValue(expr)
+	if !value.IsKnown() || value.IsNull() || value.Type() != cty.String {
+		return cty.DynamicVal
+	}
+
+	raw := []byte(value.AsString())
+	ty, err := ctyjson.ImpliedType(raw)
+	if err != nil {
+		return cty.DynamicVal
+	}
+	document, err := ctyjson.Unmarshal(raw, ty)
+	if err != nil {
+		return cty.DynamicVal
+	}
+	return document
+}
+
+// staticValue evaluates the expression without the host, with every reference unknown.
+// An expression that fails to evaluate is unknown too.
+func staticValue(expr hcl.Expression) cty.Value {
+	variables := map[string]cty.Value{}
+	for _, traversal := range expr.Variables() {
+		variables[traversal.RootName()] = cty.DynamicVal
+	}
+
+	value, diags := expr.Value(&hcl.EvalContext{Variables: variables, Functions: terraformFunctions})
+	if diags.HasErrors() {
+		return cty.DynamicVal
+	}
+	return value
+}
+
+// policyAttr returns the named attribute of a known object or map, or an unknown value
+func policyAttr(value cty.Value, name string) cty.Value {
+	if !value.IsKnown() || value.IsNull() {
+		return cty.DynamicVal
+	}
+	switch {
+	case value.Type().IsObjectType() && value.Type().HasAttribute(name):
+		return value.GetAttr(name)
+	case value.Type().IsMapType() && value.HasIndex(cty.StringVal(name)).True():
+		return value.Index(cty.StringVal(name))
+	}
+	return cty.NullVal(cty.DynamicPseudoType)
+}
+
+// policyElements returns the elements of a known collection, object or map, or nothing
+func policyElements(value cty.Value) []cty.Value {
+	if !value.IsKnown() || value.IsNull() || !value.CanIterateElements() {
+		return nil
+	}
+	elements := []cty.Value{}
+	for it := value.ElementIterator(); it.Next(); {
+		_, element := it.Element()
+		elements = append(elements, element)
+	}
+	return elements
+}
+
+// policyKeys returns the keys of a known object or map, sorted
+func policyKeys(value cty.Value) []string {
+	if !value.IsKnown() || value.IsNull() || !(value.Type().IsObjectType() || value.Type().IsMapType()) {
+		return nil
+	}
+	keys := []string{}
+	for it := value.ElementIterator(); it.Next(); {
+		key, _ := it.Element()
+		keys = append(keys, key.AsString())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// allowsEffect reports whether a statement effect is Allow, which is also the default when it is omitted
+func allowsEffect(value cty.Value) bool {
+	return value.IsKnown() && (value.IsNull() || policyStrings(value, "Allow"))
+}
+
+// policyStrings reports whether the value is the given string, or holds it, searching collections and the values
+// of objects such as { AWS = "*" }
+func policyStrings(value cty.Value, want string) bool {
+	if !value.IsKnown() || value.IsNull() {
+		return false
+	}
+	if value.Type() == cty.String {
+		return value.AsString() == want
+	}
+	for _, element := range policyElements(value) {
+		if policyStrings(element, want) {
+			return true
+		}
+	}
+	return false
+}
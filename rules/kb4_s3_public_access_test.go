@@ -0,0 +1,235 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4S3PublicAccessRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "private buckets and scoped policies",
+			Content: `
+resource "aws_s3_bucket_acl" "logs" {
+  acl = "private"
+}
+
+resource "aws_s3_bucket_policy" "logs" {
+  policy = jsonencode({
+    Statement = [
+      {
+        Effect    = "Deny"
+        Principal = "*"
+        Action    = "s3:*"
+        Resource  = "${aws_s3_bucket.logs.arn}/*"
+        Condition = { Bool = { "aws:SecureTransport" = "false" } }
+      },
+      {
+        Effect    = "Allow"
+        Principal = { AWS = "*" }
+        Action    = "s3:GetObject"
+        Resource  = "${aws_s3_bucket.logs.arn}/*"
+        Condition = { StringEquals = { "aws:sourceVpce" = var.vpce_id } }
+      },
+    ]
+  })
+}
+
+resource "aws_s3_bucket_policy" "artifacts" {
+  policy = data.aws_iam_policy_document.artifacts.json
+}
+
+data "aws_iam_policy_document" "artifacts" {
+  statement {
+    actions = ["s3:GetObject"]
+
+    principals {
+      type        = "AWS"
+      identifiers = ["*"]
+    }
+
+    condition {
+      test     = "StringEquals"
+      variable = "aws:PrincipalOrgID"
+      values   = [var.org_id]
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "public ACLs",
+			Content: `
+resource "aws_s3_bucket" "legacy" {
+  acl = "public-read-write"
+}
+
+resource "aws_s3_bucket_acl" "assets" {
+  acl = "public-read"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4S3PublicAccessRule(),
+					Message: "resource \"aws_s3_bucket\" \"legacy\" sets the public `public-read-write` ACL; keep the bucket private",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 9},
+						End:      hcl.Pos{Line: 3, Column: 28},
+					},
+				},
+				{
+					Rule:    NewKb4S3PublicAccessRule(),
+					Message: "resource \"aws_s3_bucket_acl\" \"assets\" sets the public `public-read` ACL; keep the bucket private",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 9},
+						End:      hcl.Pos{Line: 7, Column: 22},
+					},
+				},
+			},
+		},
+		{
+			Name: "public policies",
+			Content: `
+resource "aws_s3_bucket_policy" "assets" {
+  policy = jsonencode({
+    Statement = [{
+      Sid       = "PublicRead"
+      Principal = "*"
+      Action    = "s3:GetObject"
+      Resource  = "${aws_s3_bucket.assets.arn}/*"
+    }]
+  })
+}
+
+resource "aws_s3_bucket_policy" "downloads" {
+  policy = <<EOF
+{
+  "Statement": {
+    "Effect": "Allow",
+    "Principal": {"AWS": ["*"]},
+    "Action": "s3:GetObject",
+    "Resource": "arn:aws:s3:::downloads/*",
+    "Condition": {"IpAddress": {"aws:SourceIp": "203.0.113.0/24"}}
+  }
+}
+EOF
+}
+
+resource "aws_s3_bucket_policy" "website" {
+  policy = data.aws_iam_policy_document.website.json
+}
+
+data "aws_iam_policy_document" "website" {
+  statement {
+    sid     = "Website"
+    actions = ["s3:GetObject"]
+
+    principals {
+      type        = "*"
+      identifiers = ["*"]
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4S3PublicAccessRule(),
+					Message: "resource \"aws_s3_bucket_policy\" \"assets\" allows Principal \"*\" in statement `PublicRead` without a condition on one of aws:SourceVpce, aws:SourceVpc, aws:PrincipalOrgID, aws:SourceArn, aws:SourceAccount",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 12},
+						End:      hcl.Pos{Line: 10, Column: 5},
+					},
+				},
+				{
+					Rule:    NewKb4S3PublicAccessRule(),
+					Message: "resource \"aws_s3_bucket_policy\" \"downloads\" allows Principal \"*\" in a statement without a condition on one of aws:SourceVpce, aws:SourceVpc, aws:PrincipalOrgID, aws:SourceArn, aws:SourceAccount",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 14, Column: 12},
+						End:      hcl.Pos{Line: 24, Column: 4},
+					},
+				},
+				{
+					Rule:    NewKb4S3PublicAccessRule(),
+					Message: "resource \"aws_s3_bucket_policy\" \"website\" allows Principal \"*\" in statement `Website` of data.aws_iam_policy_document.website without a condition on one of aws:SourceVpce, aws:SourceVpc, aws:PrincipalOrgID, aws:SourceArn, aws:SourceAccount",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 28, Column: 12},
+						End:      hcl.Pos{Line: 28, Column: 53},
+					},
+				},
+			},
+		},
+		{
+			Name: "allowed condition keys",
+			Content: `
+resource "aws_s3_bucket_policy" "downloads" {
+  policy = jsonencode({
+    Statement = [{
+      Effect    = "Allow"
+      Principal = "*"
+      Action    = "s3:GetObject"
+      Condition = { IpAddress = { "aws:SourceIp" = "203.0.113.0/24" } }
+    }]
+  })
+}`,
+			Config: `
+rule "kb4_s3_public_access" {
+  enabled                = true
+  allowed_condition_keys = ["aws:SourceIp"]
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "exempt",
+			Content: `
+# kb4:exempt kb4_s3_public_access serves the public marketing site
+resource "aws_s3_bucket_acl" "site" {
+  acl = "public-read"
+}
+
+resource "aws_s3_bucket_acl" "unjustified" {
+  # kb4:exempt kb4_s3_public_access
+  acl = "public-read"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4S3PublicAccessRule(),
+					Message: "resource \"aws_s3_bucket_acl\" \"unjustified\" sets the public `public-read` ACL; keep the bucket private (the kb4:exempt annotation needs a justification)",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 9},
+						End:      hcl.Pos{Line: 9, Column: 22},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4S3PublicAccessRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4SharedTagsRuleConfig is the rule's .tflint.hcl config
+type Kb4SharedTagsRuleConfig struct {
+	SharedTags []string `hclext:"shared_tags,optional" doc:"References to the shared tag maps, one of which resource tags must include."`
+}
+
+func newKb4SharedTagsRuleConfig() *Kb4SharedTagsRuleConfig {
+	return &Kb4SharedTagsRuleConfig{
+		SharedTags: []string{"local.tags", "var.tags"},
+	}
+}
+
+// Validate requires at least one shared tag map, each written as a plain reference like local.tags
+func (c *Kb4SharedTagsRuleConfig) Validate() error {
+	if len(c.SharedTags) == 0 {
+		return fmt.Errorf("shared_tags must not be empty")
+	}
+	for _, ref := range c.SharedTags {
+		traversal, diags := hclsyntax.ParseTraversalAbs([]byte(ref), "", hcl.InitialPos)
+		if diags.HasErrors() || traversalName(traversal) != ref {
+			return fmt.Errorf("shared tag map %q must be a reference like local.tags", ref)
+		}
+	}
+	return nil
+}
+
+// Kb4SharedTagsRule checks that resource tags include the shared tag map, so the tags every resource needs propagate
+type Kb4SharedTagsRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4SharedTagsRule())
+}
+
+// NewKb4SharedTagsRule returns a new rule
+func NewKb4SharedTagsRule() *Kb4SharedTagsRule {
+	return &Kb4SharedTagsRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4SharedTagsRule) Name() string {
+	return "kb4_shared_tags"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4SharedTagsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4SharedTagsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4SharedTagsRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4SharedTagsRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Resource `tags` must be one of the `shared_tags` maps, or merge one in as in `tags = merge(local.tags, { Name = \"logs\" })`. A map built from scratch drops the environment, owner and cost tags the caller passes down. Locals are followed, so `tags = local.bucket_tags` passes when `local.bucket_tags` merges `local.tags`. Taggable resources without `tags` are reported as well; a type counts as taggable when it is a common AWS type that takes a `tags` map or another resource in the module tags it. Resources that tag through `tag` blocks, like `aws_autoscaling_group`, aren't checked.",
+		Categories:  []string{CategoryCost},
+		Anchor:      "tags",
+		Example: `
+resource "aws_s3_bucket" "logs" {
+  tags = {
+    Name = "logs"
+  }
+}`,
+		Config: newKb4SharedTagsRuleConfig(),
+	}
+}
+
+// Check emits an issue for every resource whose tags leave out the shared tag maps, and every taggable resource without tags
+func (r *Kb4SharedTagsRule) Check(runner tflint.Runner) error {
+	config := newKb4SharedTagsRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "tags"}},
+					Blocks:     []hclext.BlockSchema{{Type: "tag", Body: &hclext.BodySchema{}}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	locals, err := localValues(runner)
+	if err != nil {
+		return err
+	}
+	byName := map[string]hcl.Expression{}
+	for _, local := range locals {
+		byName[local.Name] = local.Expr
+	}
+
+	resources := append([]*hclext.Block{}, content.Blocks...)
+	sort.SliceStable(resources, func(i, j int) bool {
+		return rangeLess(resources[i].DefRange, resources[j].DefRange)
+	})
+
+	taggable := taggableTypes(resources)
+
+	for _, resource := range resources {
+		tags, ok := resource.Body.Attributes["tags"]
+		if !ok {
+			// Resources like aws_autoscaling_group tag through `tag` blocks and have no tags map to merge into
+			if !taggable[resource.Labels[0]] || len(resource.Body.Blocks) > 0 {
+				continue
+			}
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s sets no tags; set them with merge(%s, {...})", describeBlock(resource), config.SharedTags[0]),
+				resource.DefRange,
+			); err != nil {
+				return err
+			}
+			continue
+		}
+		if includesSharedTags(tags.Expr, config.SharedTags, byName, map[string]bool{}) {
+			continue
+		}
+
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s sets tags without the shared tags; merge them in with merge(%s, {...})", describeBlock(resource), config.SharedTags[0]),
+			tags.Expr.Range(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// includesSharedTags reports whether the tags expression is a shared tag map, merges one in, or is a local that does.
+// Expressions it can't see into, like JSON syntax, pass. seen guards against locals that refer to each other.
+func includesSharedTags(expr hcl.Expression, shared []string, locals map[string]hcl.Expression, seen map[string]bool) bool {
+	switch e := expr.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		name := traversalName(e.Traversal)
+		if containsString(shared, name) {
+			return true
+		}
+		local := traversalAttr(e.Traversal)
+		if e.Traversal.RootName() != "local" || len(e.Traversal) != 2 || locals[local] == nil || seen[local] {
+			return false
+		}
+		seen[local] = true
+		return includesSharedTags(locals[local], shared, locals, seen)
+
+	case *hclsyntax.FunctionCallExpr:
+		if e.Name != "merge" {
+			return false
+		}
+		for _, arg := range e.Args {
+			if includesSharedTags(arg, shared, locals, seen) {
+				return true
+			}
+		}
+		return false
+
+	case *hclsyntax.ParenthesesExpr:
+		return includesSharedTags(e.Expression, shared, locals, seen)
+
+	case hclsyntax.Expression:
+		return false
+	}
+
+	return true
+}
+
+// traversalName renders a traversal of attribute steps as written, e.g. "local.tags".
+// Traversals with index steps render as "".
+func traversalName(traversal hcl.Traversal) string {
+	parts := []string{}
+	for _, step := range traversal {
+		switch s := step.(type) {
+		case hcl.TraverseRoot:
+			parts = append(parts, s.Name)
+		case hcl.TraverseAttr:
+			parts = append(parts, s.Name)
+		default:
+			return ""
+		}
+	}
+	return strings.Join(parts, ".")
+}
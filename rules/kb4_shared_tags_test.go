@@ -0,0 +1,185 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4SharedTagsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "shared tags",
+			Content: `
+locals {
+  tags        = merge(var.tags, { Team = "sre" })
+  bucket_tags = merge(local.tags, { Backup = "daily" })
+}
+
+resource "aws_s3_bucket" "logs" {
+  tags = merge(local.tags, { Name = "logs" })
+}
+
+resource "aws_s3_bucket" "assets" {
+  tags = var.tags
+}
+
+resource "aws_s3_bucket" "backups" {
+  tags = local.bucket_tags
+}
+
+resource "aws_s3_bucket_policy" "logs" {
+  bucket = aws_s3_bucket.logs.id
+}
+
+resource "aws_autoscaling_group" "web" {
+  tag {
+    key                 = "Team"
+    value               = "sre"
+    propagate_at_launch = true
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "no tags",
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  bucket = "kb4-logs"
+}
+
+resource "aws_glue_job" "etl" {
+  tags = local.tags
+}
+
+resource "aws_glue_job" "report" {}
+
+resource "aws_s3_bucket_public_access_block" "logs" {
+  bucket = aws_s3_bucket.logs.id
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4SharedTagsRule(),
+					Message: `resource "aws_s3_bucket" "logs" sets no tags; set them with merge(local.tags, {...})`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 32},
+					},
+				},
+				{
+					Rule:    NewKb4SharedTagsRule(),
+					Message: `resource "aws_glue_job" "report" sets no tags; set them with merge(local.tags, {...})`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 10, Column: 1},
+						End:      hcl.Pos{Line: 10, Column: 33},
+					},
+				},
+			},
+		},
+		{
+			Name: "standalone tags",
+			Content: `
+locals {
+  extra = { Team = "sre" }
+  loop  = local.loop
+}
+
+resource "aws_s3_bucket" "logs" {
+  tags = {
+    Name = "logs"
+  }
+}
+
+resource "aws_s3_bucket" "assets" {
+  tags = merge(local.extra, { Name = "assets" })
+}
+
+resource "aws_s3_bucket" "backups" {
+  tags = local.loop
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4SharedTagsRule(),
+					Message: `resource "aws_s3_bucket" "logs" sets tags without the shared tags; merge them in with merge(local.tags, {...})`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 10},
+						End:      hcl.Pos{Line: 10, Column: 4},
+					},
+				},
+				{
+					Rule:    NewKb4SharedTagsRule(),
+					Message: `resource "aws_s3_bucket" "assets" sets tags without the shared tags; merge them in with merge(local.tags, {...})`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 14, Column: 10},
+						End:      hcl.Pos{Line: 14, Column: 49},
+					},
+				},
+				{
+					Rule:    NewKb4SharedTagsRule(),
+					Message: `resource "aws_s3_bucket" "backups" sets tags without the shared tags; merge them in with merge(local.tags, {...})`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 18, Column: 10},
+						End:      hcl.Pos{Line: 18, Column: 20},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured shared tags",
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  tags = merge(var.default_tags, { Name = "logs" })
+}
+
+resource "aws_s3_bucket" "assets" {
+  tags = var.tags
+}`,
+			Config: `
+rule "kb4_shared_tags" {
+  enabled     = true
+  shared_tags = ["var.default_tags"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4SharedTagsRule(),
+					Message: `resource "aws_s3_bucket" "assets" sets tags without the shared tags; merge them in with merge(var.default_tags, {...})`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 10},
+						End:      hcl.Pos{Line: 7, Column: 18},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4SharedTagsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4SnsTopicEncryptionRuleConfig is the rule's .tflint.hcl config
+type Kb4SnsTopicEncryptionRuleConfig struct {
+	RequireCustomerManagedKey bool `hclext:"require_customer_managed_key,optional" doc:"Reject the AWS-managed alias/aws/sns key."`
+}
+
+func newKb4SnsTopicEncryptionRuleConfig() *Kb4SnsTopicEncryptionRuleConfig {
+	return &Kb4SnsTopicEncryptionRuleConfig{}
+}
+
+// Kb4SnsTopicEncryptionRule checks that SNS topics encrypt messages at rest
+type Kb4SnsTopicEncryptionRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4SnsTopicEncryptionRule())
+}
+
+// NewKb4SnsTopicEncryptionRule returns a new rule
+func NewKb4SnsTopicEncryptionRule() *Kb4SnsTopicEncryptionRule {
+	return &Kb4SnsTopicEncryptionRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4SnsTopicEncryptionRule) Name() string {
+	return "kb4_sns_topic_encryption"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4SnsTopicEncryptionRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4SnsTopicEncryptionRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4SnsTopicEncryptionRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4SnsTopicEncryptionRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`aws_sns_topic` resources must set `kms_master_key_id`. With `require_customer_managed_key`, the AWS-managed `alias/aws/sns` key is not accepted either.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "encryption",
+		Example: `
+resource "aws_sns_topic" "alerts" {
+  name = "alerts"
+}`,
+		Config: newKb4SnsTopicEncryptionRuleConfig(),
+	}
+}
+
+// Check emits an issue for every topic without a KMS key, or with the AWS-managed key when a customer-managed one is required
+func (r *Kb4SnsTopicEncryptionRule) Check(runner tflint.Runner) error {
+	config := newKb4SnsTopicEncryptionRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetResourceContent("aws_sns_topic", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "kms_master_key_id"}},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, topic := range content.Blocks {
+		key, ok := topic.Body.Attributes["kms_master_key_id"]
+		if !ok {
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s should set kms_master_key_id", describeBlock(topic)),
+				topic.DefRange,
+			); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !config.RequireCustomerManagedKey {
+			continue
+		}
+
+		err := evaluateString(runner, key.Expr, func(value string) error {
+			if !strings.HasSuffix(value, "alias/aws/sns") {
+				return nil
+			}
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s uses the AWS-managed key; use a customer-managed KMS key", describeBlock(topic)),
+				key.Expr.Range(),
+			)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
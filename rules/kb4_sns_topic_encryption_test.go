@@ -0,0 +1,93 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4SnsTopicEncryptionRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "encrypted",
+			Content: `
+resource "aws_sns_topic" "alerts" {
+  kms_master_key_id = "alias/aws/sns"
+}
+
+resource "aws_sns_topic" "events" {
+  kms_master_key_id = aws_kms_key.events.arn
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:    "unencrypted",
+			Content: `resource "aws_sns_topic" "alerts" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4SnsTopicEncryptionRule(),
+					Message: "resource \"aws_sns_topic\" \"alerts\" should set kms_master_key_id",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 34},
+					},
+				},
+			},
+		},
+		{
+			Name: "customer-managed key required",
+			Content: `
+variable "kms_key_arn" {}
+
+resource "aws_sns_topic" "alerts" {
+  kms_master_key_id = "alias/aws/sns"
+}
+
+resource "aws_sns_topic" "events" {
+  kms_master_key_id = var.kms_key_arn
+}`,
+			Config: `
+rule "kb4_sns_topic_encryption" {
+  enabled                      = true
+  require_customer_managed_key = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4SnsTopicEncryptionRule(),
+					Message: "resource \"aws_sns_topic\" \"alerts\" uses the AWS-managed key; use a customer-managed KMS key",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 23},
+						End:      hcl.Pos{Line: 5, Column: 38},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4SnsTopicEncryptionRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
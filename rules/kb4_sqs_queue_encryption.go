@@ -0,0 +1,98 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4SqsQueueEncryptionRule checks that SQS queues encrypt messages at rest
+type Kb4SqsQueueEncryptionRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4SqsQueueEncryptionRule())
+}
+
+// NewKb4SqsQueueEncryptionRule returns a new rule
+func NewKb4SqsQueueEncryptionRule() *Kb4SqsQueueEncryptionRule {
+	return &Kb4SqsQueueEncryptionRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4SqsQueueEncryptionRule) Name() string {
+	return "kb4_sqs_queue_encryption"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4SqsQueueEncryptionRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4SqsQueueEncryptionRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4SqsQueueEncryptionRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4SqsQueueEncryptionRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`aws_sqs_queue` resources must encrypt messages, either with a KMS key in `kms_master_key_id` or with `sqs_managed_sse_enabled = true`.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "encryption",
+		Example: `
+resource "aws_sqs_queue" "events" {
+  name = "events"
+}`,
+	}
+}
+
+// Check emits an issue for every queue without a KMS key that doesn't enable SQS-managed encryption
+func (r *Kb4SqsQueueEncryptionRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	content, err := runner.GetResourceContent("aws_sqs_queue", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "kms_master_key_id"}, {Name: "sqs_managed_sse_enabled"}},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, queue := range content.Blocks {
+		if _, ok := queue.Body.Attributes["kms_master_key_id"]; ok {
+			continue
+		}
+		message := fmt.Sprintf("%s should set kms_master_key_id or sqs_managed_sse_enabled = true", describeBlock(queue))
+
+		sse, ok := queue.Body.Attributes["sqs_managed_sse_enabled"]
+		if !ok {
+			if err := runner.EmitIssue(r, message, queue.DefRange); err != nil {
+				return err
+			}
+			continue
+		}
+
+		err := evaluateBool(runner, sse.Expr, func(value bool) error {
+			if value {
+				return nil
+			}
+			return runner.EmitIssue(r, message, sse.Expr.Range())
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4SqsQueueEncryptionRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "encrypted",
+			Content: `
+resource "aws_sqs_queue" "events" {
+  kms_master_key_id = aws_kms_key.events.arn
+}
+
+resource "aws_sqs_queue" "jobs" {
+  sqs_managed_sse_enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "unencrypted",
+			Content: `
+resource "aws_sqs_queue" "events" {}
+
+resource "aws_sqs_queue" "jobs" {
+  sqs_managed_sse_enabled = false
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4SqsQueueEncryptionRule(),
+					Message: "resource \"aws_sqs_queue\" \"events\" should set kms_master_key_id or sqs_managed_sse_enabled = true",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 34},
+					},
+				},
+				{
+					Rule:    NewKb4SqsQueueEncryptionRule(),
+					Message: "resource \"aws_sqs_queue\" \"jobs\" should set kms_master_key_id or sqs_managed_sse_enabled = true",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 29},
+						End:      hcl.Pos{Line: 5, Column: 34},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4SqsQueueEncryptionRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kb4TagKeyCasingRuleConfig is the rule's .tflint.hcl config
+type Kb4TagKeyCasingRuleConfig struct {
+	Casing string `hclext:"casing,optional" doc:"Convention tag keys follow: PascalCase, camelCase, snake_case or kebab-case."`
+}
+
+func newKb4TagKeyCasingRuleConfig() *Kb4TagKeyCasingRuleConfig {
+	return &Kb4TagKeyCasingRuleConfig{Casing: PascalCase}
+}
+
+// Validate rejects unknown conventions
+func (c *Kb4TagKeyCasingRuleConfig) Validate() error {
+	_, err := formatCase("", c.Casing)
+	return err
+}
+
+// Kb4TagKeyCasingRule checks that tag keys follow one casing convention
+type Kb4TagKeyCasingRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4TagKeyCasingRule())
+}
+
+// NewKb4TagKeyCasingRule returns a new rule
+func NewKb4TagKeyCasingRule() *Kb4TagKeyCasingRule {
+	return &Kb4TagKeyCasingRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4TagKeyCasingRule) Name() string {
+	return "kb4_tag_key_casing"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4TagKeyCasingRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4TagKeyCasingRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4TagKeyCasingRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4TagKeyCasingRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Tag keys in resource `tags`, provider `default_tags` and `tag` blocks must follow the `casing` convention, so cost and ownership reports don't split one tag into several. Acronyms stay in capitals in PascalCase and camelCase, so `CostCenterID` is PascalCase. Only the part after a prefix such as `kb4:` is checked, and keys starting with `aws:` are skipped.",
+		Categories:  []string{CategoryNaming},
+		Anchor:      "tags",
+		Example: `
+resource "aws_s3_bucket" "this" {
+  tags = {
+    cost_center = "platform"
+  }
+}`,
+		Config: newKb4TagKeyCasingRuleConfig(),
+	}
+}
+
+// tagKey is a literal tag key and where it was written
+type tagKey struct {
+	key string
+	rng hcl.Range
+}
+
+// Check emits an issue for every literal tag key that doesn't follow the convention
+func (r *Kb4TagKeyCasingRule) Check(runner tflint.Runner) error {
+	config := newKb4TagKeyCasingRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "tags"}},
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "tag",
+							Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "key"}}},
+						},
+					},
+				},
+			},
+			{
+				Type:       "provider",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "default_tags",
+							Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "tags"}}},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	keys := []tagKey{}
+	for _, block := range content.Blocks {
+		if tags, ok := block.Body.Attributes["tags"]; ok {
+			keys = append(keys, tagMapKeys(tags.Expr)...)
+		}
+
+		for _, nested := range block.Body.Blocks {
+			if tags, ok := nested.Body.Attributes["tags"]; ok {
+				keys = append(keys, tagMapKeys(tags.Expr)...)
+			}
+			if key, ok := nested.Body.Attributes["key"]; ok {
+				if value, diags := key.Expr.Value(nil); !diags.HasErrors() && value.Type() == cty.String && value.IsKnown() && !value.IsNull() {
+					keys = append(keys, tagKey{value.AsString(), key.Expr.Range()})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return rangeLess(keys[i].rng, keys[j].rng)
+	})
+
+	for _, key := range keys {
+		suggested := formatTagKey(key.key, config.Casing)
+		if suggested == key.key {
+			continue
+		}
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("Tag key `%s` should be %s: `%s`", key.key, config.Casing, suggested),
+			key.rng,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tagMapKeys returns the literal keys of a tags map, including the maps merged by merge()
+func tagMapKeys(expr hcl.Expression) []tagKey {
+	switch e := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		keys := []tagKey{}
+		for _, item := range e.Items {
+			value, diags := item.KeyExpr.Value(nil)
+			if diags.HasErrors() || value.Type() != cty.String || !value.IsKnown() || value.IsNull() {
+				continue
+			}
+			keys = append(keys, tagKey{value.AsString(), item.KeyExpr.Range()})
+		}
+		return keys
+
+	case *hclsyntax.FunctionCallExpr:
+		if e.Name != "merge" {
+			return nil
+		}
+		keys := []tagKey{}
+		for _, arg := range e.Args {
+			keys = append(keys, tagMapKeys(arg)...)
+		}
+		return keys
+	}
+
+	return nil
+}
+
+// formatTagKey rewrites a tag key in the convention. A prefix like `kb4:` is kept as written and `aws:` keys are left alone.
+func formatTagKey(key string, casing string) string {
+	if strings.HasPrefix(key, "aws:") {
+		return key
+	}
+
+	prefix, name := "", key
+	if i := strings.LastIndexAny(key, ":/"); i >= 0 {
+		prefix, name = key[:i+1], key[i+1:]
+	}
+
+	formatted, _ := formatCase(name, casing)
+	return prefix + formatted
+}
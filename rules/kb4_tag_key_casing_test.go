@@ -0,0 +1,149 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4TagKeyCasingRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "PascalCase keys",
+			Content: `
+provider "aws" {
+  default_tags {
+    tags = {
+      Team         = "sre"
+      "kb4:Owner"  = "sre"
+      "aws:source" = "ignored"
+    }
+  }
+}
+
+resource "aws_s3_bucket" "this" {
+  tags = merge(local.tags, { CostCenter = "platform" })
+}
+
+resource "aws_autoscaling_group" "this" {
+  tag {
+    key                 = "Name"
+    value               = "workers"
+    propagate_at_launch = true
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "other casings",
+			Content: `
+provider "aws" {
+  default_tags {
+    tags = {
+      team = "sre"
+    }
+  }
+}
+
+resource "aws_s3_bucket" "this" {
+  tags = merge(local.tags, { "cost-center" = "platform", "kb4:data_class" = "internal" })
+}
+
+resource "aws_autoscaling_group" "this" {
+  tag {
+    key   = "service_name"
+    value = "workers"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4TagKeyCasingRule(),
+					Message: "Tag key `team` should be PascalCase: `Team`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 7},
+						End:      hcl.Pos{Line: 5, Column: 11},
+					},
+				},
+				{
+					Rule:    NewKb4TagKeyCasingRule(),
+					Message: "Tag key `cost-center` should be PascalCase: `CostCenter`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 30},
+						End:      hcl.Pos{Line: 11, Column: 43},
+					},
+				},
+				{
+					Rule:    NewKb4TagKeyCasingRule(),
+					Message: "Tag key `kb4:data_class` should be PascalCase: `kb4:DataClass`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 58},
+						End:      hcl.Pos{Line: 11, Column: 74},
+					},
+				},
+				{
+					Rule:    NewKb4TagKeyCasingRule(),
+					Message: "Tag key `service_name` should be PascalCase: `ServiceName`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 16, Column: 13},
+						End:      hcl.Pos{Line: 16, Column: 27},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured casing",
+			Content: `
+resource "aws_s3_bucket" "this" {
+  tags = {
+    cost_center = "platform"
+    Team        = "sre"
+  }
+}`,
+			Config: `
+rule "kb4_tag_key_casing" {
+  enabled = true
+  casing  = "snake_case"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4TagKeyCasingRule(),
+					Message: "Tag key `Team` should be snake_case: `team`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 5},
+						End:      hcl.Pos{Line: 5, Column: 9},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4TagKeyCasingRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package rules
+
+import (
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4TerraformBackendRuleConfig is the rule's .tflint.hcl config
+type Kb4TerraformBackendRuleConfig struct {
+	AllowTerraformCloud bool `hclext:"allow_terraform_cloud,optional" doc:"Allow cloud blocks and the remote backend, for teams piloting HCP Terraform."`
+}
+
+func newKb4TerraformBackendRuleConfig() *Kb4TerraformBackendRuleConfig {
+	return &Kb4TerraformBackendRuleConfig{}
+}
+
+// Kb4TerraformBackendRule checks that state is kept in the standard S3 backend rather than Terraform Cloud
+type Kb4TerraformBackendRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4TerraformBackendRule())
+}
+
+// NewKb4TerraformBackendRule returns a new rule
+func NewKb4TerraformBackendRule() *Kb4TerraformBackendRule {
+	return &Kb4TerraformBackendRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4TerraformBackendRule) Name() string {
+	return "kb4_terraform_backend"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4TerraformBackendRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4TerraformBackendRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4TerraformBackendRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4TerraformBackendRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "State must be kept in the standard S3 backend with DynamoDB locking, so `cloud` blocks and the `remote` backend are not allowed unless `allow_terraform_cloud` is set.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "state",
+		Example: `
+terraform {
+  cloud {
+    organization = "knowbe4"
+  }
+}`,
+		Config: newKb4TerraformBackendRuleConfig(),
+	}
+}
+
+// Check emits issues for Terraform Cloud configuration
+func (r *Kb4TerraformBackendRule) Check(runner tflint.Runner) error {
+	config := newKb4TerraformBackendRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+	if config.AllowTerraformCloud {
+		return nil
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "terraform",
+				Body: &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{
+						{Type: "backend", LabelNames: []string{"type"}},
+						{Type: "cloud"},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, terraform := range content.Blocks {
+		for _, block := range terraform.Body.Blocks {
+			message := ""
+			switch {
+			case block.Type == "cloud":
+				message = "Terraform Cloud is not allowed; use the s3 backend"
+			case block.Labels[0] == "remote":
+				message = "The remote backend is not allowed; use the s3 backend"
+			default:
+				continue
+			}
+
+			if err := runner.EmitIssue(r, message, block.DefRange); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4TerraformBackendRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "s3 backend",
+			Content: `
+terraform {
+  backend "s3" {
+    bucket         = "kb4-terraform-state"
+    dynamodb_table = "terraform-locks"
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "cloud block",
+			Content: `
+terraform {
+  cloud {
+    organization = "knowbe4"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4TerraformBackendRule(),
+					Message: "Terraform Cloud is not allowed; use the s3 backend",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 8},
+					},
+				},
+			},
+		},
+		{
+			Name: "remote backend",
+			Content: `
+terraform {
+  backend "remote" {
+    organization = "knowbe4"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4TerraformBackendRule(),
+					Message: "The remote backend is not allowed; use the s3 backend",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 19},
+					},
+				},
+			},
+		},
+		{
+			Name: "terraform cloud allowed",
+			Content: `
+terraform {
+  cloud {
+    organization = "knowbe4"
+  }
+}`,
+			Config: `
+rule "kb4_terraform_backend" {
+  enabled               = true
+  allow_terraform_cloud = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewKb4TerraformBackendRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"_init.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4TerraformBlockCountRule checks that a module has a single terraform block
+type Kb4TerraformBlockCountRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4TerraformBlockCountRule())
+}
+
+// NewKb4TerraformBlockCountRule returns a new rule
+func NewKb4TerraformBlockCountRule() *Kb4TerraformBlockCountRule {
+	return &Kb4TerraformBlockCountRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4TerraformBlockCountRule) Name() string {
+	return "kb4_terraform_block_count"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4TerraformBlockCountRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4TerraformBlockCountRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4TerraformBlockCountRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4TerraformBlockCountRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Modules must have exactly one `terraform` block, in the file `terraform_kb4_file_structure` puts it in, `_init.tf` by default. Blocks in the plugin block's `exclude_files`, like `override.tf`, aren't counted, since Terraform merges them into the others. A second block is usually a merge artifact and splits the version constraints across files.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "standard-files-names-and-usage",
+		Example: `
+# _init.tf
+terraform {
+  required_version = ">= 1.0"
+}
+
+# main.tf
+terraform {
+  required_providers {
+    aws = {
+      source = "hashicorp/aws"
+    }
+  }
+}`,
+	}
+}
+
+// Check emits an issue for every terraform block after the first, preferring the one in the file the file structure rule puts it in.
+// Blocks in excluded files, like override.tf, are merged into the others by Terraform and aren't counted.
+func (r *Kb4TerraformBlockCountRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	structure, err := fileStructureConfig(runner)
+	if err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "terraform",
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	blocks := []*hclext.Block{}
+	for _, block := range content.Blocks {
+		if !ruleSetConfig(runner).excludesFile(block.DefRange.Filename) {
+			blocks = append(blocks, block)
+		}
+	}
+	if len(blocks) < 2 {
+		return nil
+	}
+
+	expected := structure.terraformFile(blocks)
+	sort.SliceStable(blocks, func(i, j int) bool {
+		a, b := blocks[i].DefRange, blocks[j].DefRange
+		if inA, inB := sameTerraformFile(a.Filename, expected), sameTerraformFile(b.Filename, expected); inA != inB {
+			return inA
+		}
+		return rangeLess(a, b)
+	})
+
+	for _, block := range blocks[1:] {
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("Module has %d terraform blocks; merge the %s of %s into the one in %s", len(blocks), describeBlock(block), block.DefRange.Filename, blocks[0].DefRange.Filename),
+			block.DefRange,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4TerraformBlockCountRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "one block",
+			Files: map[string]string{
+				"_init.tf": `
+terraform {
+  required_version = ">= 1.0"
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "extra blocks",
+			Files: map[string]string{
+				"_init.tf": `
+terraform {
+  required_version = ">= 1.0"
+}`,
+				"a.tf": `
+terraform {
+  required_providers {}
+}
+
+terraform {
+  experiments = []
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4TerraformBlockCountRule(),
+					Message: "Module has 3 terraform blocks; merge the terraform block on line 2 of a.tf into the one in _init.tf",
+					Range: hcl.Range{
+						Filename: "a.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 10},
+					},
+				},
+				{
+					Rule:    NewKb4TerraformBlockCountRule(),
+					Message: "Module has 3 terraform blocks; merge the terraform block on line 6 of a.tf into the one in _init.tf",
+					Range: hcl.Range{
+						Filename: "a.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 10},
+					},
+				},
+			},
+		},
+		{
+			Name: "no _init.tf",
+			Files: map[string]string{
+				"b.tf": `terraform {}`,
+				"a.tf": `terraform {}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4TerraformBlockCountRule(),
+					Message: "Module has 2 terraform blocks; merge the terraform block on line 1 of b.tf into the one in a.tf",
+					Range: hcl.Range{
+						Filename: "b.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 10},
+					},
+				},
+			},
+		},
+		{
+			Name: "override file",
+			Files: map[string]string{
+				"_init.tf": `
+terraform {
+  required_version = ">= 1.0"
+}`,
+				"override.tf": `
+terraform {
+  backend "local" {}
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "versions layout",
+			Files: map[string]string{
+				"_init.tf": `terraform {}`,
+				"_versions.tf": `
+terraform {
+  required_version = ">= 1.0"
+}`,
+				".tflint.hcl": `
+rule "terraform_kb4_file_structure" {
+  enabled = true
+  layout  = "versions"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4TerraformBlockCountRule(),
+					Message: "Module has 2 terraform blocks; merge the terraform block on line 1 of _init.tf into the one in _versions.tf",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 10},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4TerraformBlockCountRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.Files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			assertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
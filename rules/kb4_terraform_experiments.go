@@ -0,0 +1,89 @@
+package rules
+
+import (
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4TerraformExperimentsRule checks that modules don't opt into experimental language features
+type Kb4TerraformExperimentsRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4TerraformExperimentsRule())
+}
+
+// NewKb4TerraformExperimentsRule returns a new rule
+func NewKb4TerraformExperimentsRule() *Kb4TerraformExperimentsRule {
+	return &Kb4TerraformExperimentsRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4TerraformExperimentsRule) Name() string {
+	return "kb4_terraform_experiments"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4TerraformExperimentsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4TerraformExperimentsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4TerraformExperimentsRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4TerraformExperimentsRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`terraform` blocks must not set `experiments`. Experimental language features change between releases and must not reach shared modules.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "standard-files-names-and-usage",
+		Example: `
+terraform {
+  experiments = [module_variable_optional_attrs]
+}`,
+	}
+}
+
+// Check emits issues for experiments attributes in terraform blocks
+func (r *Kb4TerraformExperimentsRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "terraform",
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "experiments"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, terraform := range content.Blocks {
+		experiments, ok := terraform.Body.Attributes["experiments"]
+		if !ok {
+			continue
+		}
+
+		if err := runner.EmitIssue(r, "Experimental language features must not be enabled in modules", experiments.Range); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4TerraformExperimentsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no experiments",
+			Content: `
+terraform {
+  required_version = ">= 1.0"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "experiments",
+			Content: `
+terraform {
+  experiments = [module_variable_optional_attrs]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4TerraformExperimentsRule(),
+					Message: "Experimental language features must not be enabled in modules",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 49},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4TerraformExperimentsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"_init.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
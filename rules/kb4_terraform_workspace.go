@@ -0,0 +1,100 @@
+package rules
+
+import (
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4TerraformWorkspaceRuleConfig is the rule's .tflint.hcl config
+type Kb4TerraformWorkspaceRuleConfig struct {
+	AllowInRootModules bool `hclext:"allow_in_root_modules,optional" doc:"Allow terraform.workspace in root modules. Child modules may never use it."`
+}
+
+func newKb4TerraformWorkspaceRuleConfig() *Kb4TerraformWorkspaceRuleConfig {
+	return &Kb4TerraformWorkspaceRuleConfig{AllowInRootModules: true}
+}
+
+// Kb4TerraformWorkspaceRule checks that modules don't key their behavior off the workspace name
+type Kb4TerraformWorkspaceRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4TerraformWorkspaceRule())
+}
+
+// NewKb4TerraformWorkspaceRule returns a new rule
+func NewKb4TerraformWorkspaceRule() *Kb4TerraformWorkspaceRule {
+	return &Kb4TerraformWorkspaceRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4TerraformWorkspaceRule) Name() string {
+	return "kb4_terraform_workspace"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4TerraformWorkspaceRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4TerraformWorkspaceRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4TerraformWorkspaceRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4TerraformWorkspaceRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Child modules must not read `terraform.workspace`; take `var.environment` instead, since workspace names drift from environment names. Root modules may use it unless `allow_in_root_modules` is false.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "state",
+		Example: `
+locals {
+  instance_type = lookup(var.instance_types, terraform.workspace)
+}`,
+		Config: newKb4TerraformWorkspaceRuleConfig(),
+	}
+}
+
+// Check emits an issue for every terraform.workspace reference the module isn't allowed
+func (r *Kb4TerraformWorkspaceRule) Check(runner tflint.Runner) error {
+	config := newKb4TerraformWorkspaceRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	root, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if root && config.AllowInRootModules {
+		return nil
+	}
+
+	message := "terraform.workspace must not be used in child modules; pass the environment in as a variable"
+	if root {
+		message = "terraform.workspace must not be used; use var.environment"
+	}
+
+	found, err := references(runner, "terraform")
+	if err != nil {
+		return err
+	}
+
+	for _, traversal := range found {
+		if traversalAttr(traversal) != "workspace" {
+			continue
+		}
+		if err := runner.EmitIssue(r, message, traversal.SourceRange()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4TerraformWorkspaceRule(t *testing.T) {
+	backend := `
+terraform {
+  backend "s3" {}
+}`
+
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "root module",
+			Files: map[string]string{
+				"_init.tf": backend,
+				"main.tf": `
+locals {
+  environment = terraform.workspace
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "child module",
+			Files: map[string]string{
+				"main.tf": `
+locals {
+  instance_type = lookup(var.instance_types, terraform.workspace)
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4TerraformWorkspaceRule(),
+					Message: "terraform.workspace must not be used in child modules; pass the environment in as a variable",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 46},
+						End:      hcl.Pos{Line: 3, Column: 65},
+					},
+				},
+			},
+		},
+		{
+			Name: "forbidden in root modules",
+			Files: map[string]string{
+				"_init.tf": backend,
+				"main.tf": `
+locals {
+  environment = terraform.workspace
+}`,
+				".tflint.hcl": `
+rule "kb4_terraform_workspace" {
+  enabled               = true
+  allow_in_root_modules = false
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4TerraformWorkspaceRule(),
+					Message: "terraform.workspace must not be used; use var.environment",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 17},
+						End:      hcl.Pos{Line: 3, Column: 36},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4TerraformWorkspaceRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.Files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package rules
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4TimeHacksRule checks for sleeps and clock readings that stand in for real dependencies and cause perpetual diffs
+type Kb4TimeHacksRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4TimeHacksRule())
+}
+
+// NewKb4TimeHacksRule returns a new rule
+func NewKb4TimeHacksRule() *Kb4TimeHacksRule {
+	return &Kb4TimeHacksRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4TimeHacksRule) Name() string {
+	return "kb4_time_hacks"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4TimeHacksRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4TimeHacksRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4TimeHacksRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4TimeHacksRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`time_sleep` resources and `timestamp()` in resource arguments are not allowed. Sleeps paper over missing dependencies and `timestamp()` changes on every plan. Where one is unavoidable, exempt it with a `# kb4:exempt kb4_time_hacks <justification>` comment on the line above.",
+		Categories:  []string{CategoryStyle},
+		Anchor:      "dependencies",
+		Example: `
+resource "time_sleep" "iam" {
+  create_duration = "30s"
+}`,
+	}
+}
+
+// Check emits issues for time_sleep resources and timestamp() calls in resources that aren't exempt
+func (r *Kb4TimeHacksRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	exempt, err := loadExemptions(runner, r)
+	if err != nil {
+		return err
+	}
+
+	sleeps, err := runner.GetResourceContent("time_sleep", &hclext.BodySchema{}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, sleep := range sleeps.Blocks {
+		ok, unjustified := exempt.applies(sleep.DefRange)
+		if ok {
+			continue
+		}
+		if err := runner.EmitIssue(
+			r,
+			exemptionMessage("time_sleep must not be used; depend on the resource that needs to be ready instead", unjustified),
+			sleep.DefRange,
+		); err != nil {
+			return err
+		}
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+
+	type call struct {
+		rng         hcl.Range
+		unjustified bool
+	}
+	calls := []call{}
+
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "resource" {
+				continue
+			}
+			resource := block.DefRange()
+
+			hclsyntax.VisitAll(block.Body, func(node hclsyntax.Node) hcl.Diagnostics {
+				fn, ok := node.(*hclsyntax.FunctionCallExpr)
+				if !ok || fn.Name != "timestamp" {
+					return nil
+				}
+				if ok, unjustified := exempt.applies(fn.Range(), resource); !ok {
+					calls = append(calls, call{fn.Range(), unjustified})
+				}
+				return nil
+			})
+		}
+	}
+
+	sort.SliceStable(calls, func(i, j int) bool {
+		return rangeLess(calls[i].rng, calls[j].rng)
+	})
+
+	for _, c := range calls {
+		if err := runner.EmitIssue(
+			r,
+			exemptionMessage("timestamp() in resource arguments changes on every plan; use time_static or a variable", c.unjustified),
+			c.rng,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
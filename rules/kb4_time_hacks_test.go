@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4TimeHacksRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no time hacks",
+			Content: `
+resource "time_static" "created" {}
+
+locals {
+  generated_at = timestamp()
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "time hacks",
+			Content: `
+resource "time_sleep" "iam" {
+  create_duration = "30s"
+}
+
+resource "aws_s3_object" "marker" {
+  key     = "deployed"
+  content = timestamp()
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4TimeHacksRule(),
+					Message: "time_sleep must not be used; depend on the resource that needs to be ready instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 28},
+					},
+				},
+				{
+					Rule:    NewKb4TimeHacksRule(),
+					Message: "timestamp() in resource arguments changes on every plan; use time_static or a variable",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 13},
+						End:      hcl.Pos{Line: 8, Column: 24},
+					},
+				},
+			},
+		},
+		{
+			Name: "exempt",
+			Content: `
+# kb4:exempt kb4_time_hacks the vendor API needs a minute after the role is created
+resource "time_sleep" "iam" {
+  create_duration = "60s"
+}
+
+# kb4:exempt kb4_time_hacks rerun the provisioner on every apply
+resource "null_resource" "provision" {
+  triggers = {
+    always = timestamp()
+  }
+}
+
+resource "aws_s3_object" "marker" {
+  key     = "deployed"
+  content = timestamp() # kb4:exempt kb4_time_hacks
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4TimeHacksRule(),
+					Message: "timestamp() in resource arguments changes on every plan; use time_static or a variable (the kb4:exempt annotation needs a justification)",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 16, Column: 13},
+						End:      hcl.Pos{Line: 16, Column: 24},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4TimeHacksRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4TodoCommentsRuleConfig is the rule's .tflint.hcl config
+type Kb4TodoCommentsRuleConfig struct {
+	Markers       []string `hclext:"markers,optional" doc:"Words that mark a comment as a debt note, matched case-sensitively as whole words."`
+	TicketPattern string   `hclext:"ticket_pattern,optional" doc:"Regular expression for the ticket key a debt note must reference."`
+}
+
+func newKb4TodoCommentsRuleConfig() *Kb4TodoCommentsRuleConfig {
+	return &Kb4TodoCommentsRuleConfig{
+		Markers:       []string{"TODO", "FIXME", "HACK"},
+		TicketPattern: `\b[A-Z][A-Z0-9]+-[0-9]+\b`,
+	}
+}
+
+// Validate rejects empty markers and a ticket pattern that doesn't compile
+func (c *Kb4TodoCommentsRuleConfig) Validate() error {
+	for _, marker := range c.Markers {
+		if marker == "" {
+			return fmt.Errorf("markers must not be empty")
+		}
+	}
+	if _, err := regexp.Compile(c.TicketPattern); err != nil {
+		return fmt.Errorf("ticket_pattern is invalid: %s", err)
+	}
+	return nil
+}
+
+// markerPattern matches any of the markers as a whole word
+func (c *Kb4TodoCommentsRuleConfig) markerPattern() *regexp.Regexp {
+	quoted := make([]string, len(c.Markers))
+	for i, marker := range c.Markers {
+		quoted[i] = regexp.QuoteMeta(marker)
+	}
+	return regexp.MustCompile(`\b(?:` + strings.Join(quoted, "|") + `)\b`)
+}
+
+// Kb4TodoCommentsRule checks that debt notes in comments are tracked in a ticket
+type Kb4TodoCommentsRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4TodoCommentsRule())
+}
+
+// NewKb4TodoCommentsRule returns a new rule
+func NewKb4TodoCommentsRule() *Kb4TodoCommentsRule {
+	return &Kb4TodoCommentsRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4TodoCommentsRule) Name() string {
+	return "kb4_todo_comments"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4TodoCommentsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4TodoCommentsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4TodoCommentsRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4TodoCommentsRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Comment lines with a `TODO`, `FIXME` or `HACK` marker must reference a Jira ticket on the same line, e.g. `# TODO(SRE-123): drop once the migration is done`, so the debt is tracked somewhere other than the code.",
+		Categories:  []string{CategoryStyle},
+		Anchor:      "comments",
+		Example: `
+# TODO: remove after the migration
+resource "aws_s3_bucket" "legacy" {}`,
+		Config: newKb4TodoCommentsRuleConfig(),
+	}
+}
+
+// Check emits an issue on every marker in a comment line that doesn't reference a ticket
+func (r *Kb4TodoCommentsRule) Check(runner tflint.Runner) error {
+	config := newKb4TodoCommentsRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+	if len(config.Markers) == 0 {
+		return nil
+	}
+	markers, ticket := config.markerPattern(), regexp.MustCompile(config.TicketPattern)
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+
+	type issue struct {
+		marker string
+		rng    hcl.Range
+	}
+	issues := []issue{}
+
+	for filename, file := range files {
+		if _, ok := file.Body.(*hclsyntax.Body); !ok {
+			continue
+		}
+
+		tokens, _ := hclsyntax.LexConfig(file.Bytes, filename, hcl.InitialPos)
+		for _, token := range tokens {
+			if token.Type != hclsyntax.TokenComment {
+				continue
+			}
+
+			// Block comments span lines, and each line is a note of its own
+			pos := token.Range.Start
+			for _, line := range strings.SplitAfter(string(token.Bytes), "\n") {
+				if loc := markers.FindStringIndex(line); loc != nil && !ticket.MatchString(line) {
+					start := hcl.Pos{Line: pos.Line, Column: pos.Column + utf8.RuneCountInString(line[:loc[0]]), Byte: pos.Byte + loc[0]}
+					end := hcl.Pos{Line: pos.Line, Column: start.Column + utf8.RuneCountInString(line[loc[0]:loc[1]]), Byte: pos.Byte + loc[1]}
+					issues = append(issues, issue{line[loc[0]:loc[1]], hcl.Range{Filename: filename, Start: start, End: end}})
+				}
+				pos = hcl.Pos{Line: pos.Line + 1, Column: 1, Byte: pos.Byte + len(line)}
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return rangeLess(issues[i].rng, issues[j].rng)
+	})
+
+	for _, i := range issues {
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s comment should reference a ticket matching %s", i.marker, config.TicketPattern),
+			i.rng,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4TodoCommentsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "tracked notes",
+			Content: `
+# TODO(SRE-123): drop once the migration is done
+resource "aws_s3_bucket" "legacy" {
+  bucket = "kb4-legacy" // FIXME SRE-456 rename the bucket
+}
+
+/*
+ * HACK: the provider ignores tags here, see PLAT-78
+ */
+locals {
+  todo = "TODO: strings aren't comments"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "untracked notes",
+			Content: `
+# TODO: drop once the migration is done
+resource "aws_s3_bucket" "legacy" {
+  bucket = "kb4-legacy" // FIXME rename the bucket
+}
+
+/*
+ * Keeps the old name.
+ * HACK: the provider ignores tags here
+ */
+locals {
+  # Not a TODOLIST, nor a todo
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4TodoCommentsRule(),
+					Message: `TODO comment should reference a ticket matching \b[A-Z][A-Z0-9]+-[0-9]+\b`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3},
+						End:      hcl.Pos{Line: 2, Column: 7},
+					},
+				},
+				{
+					Rule:    NewKb4TodoCommentsRule(),
+					Message: `FIXME comment should reference a ticket matching \b[A-Z][A-Z0-9]+-[0-9]+\b`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 28},
+						End:      hcl.Pos{Line: 4, Column: 33},
+					},
+				},
+				{
+					Rule:    NewKb4TodoCommentsRule(),
+					Message: `HACK comment should reference a ticket matching \b[A-Z][A-Z0-9]+-[0-9]+\b`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 4},
+						End:      hcl.Pos{Line: 9, Column: 8},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured markers and pattern",
+			Content: `
+# TODO: drop once the migration is done
+# XXX see JIRA-1
+# XXX see #42
+`,
+			Config: `
+rule "kb4_todo_comments" {
+  enabled        = true
+  markers        = ["XXX"]
+  ticket_pattern = "#[0-9]+"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4TodoCommentsRule(),
+					Message: "XXX comment should reference a ticket matching #[0-9]+",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 6},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4TodoCommentsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
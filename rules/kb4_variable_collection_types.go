@@ -0,0 +1,145 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4VariableCollectionTypesRuleConfig is the rule's .tflint.hcl config
+type Kb4VariableCollectionTypesRuleConfig struct {
+	Exempt []string `hclext:"exempt,optional" doc:"Variable names, or globs of names, whose collections may leave the element type open."`
+}
+
+func newKb4VariableCollectionTypesRuleConfig() *Kb4VariableCollectionTypesRuleConfig {
+	return &Kb4VariableCollectionTypesRuleConfig{Exempt: []string{}}
+}
+
+// Validate rejects malformed globs
+func (c *Kb4VariableCollectionTypesRuleConfig) Validate() error {
+	for _, pattern := range c.Exempt {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("exempt pattern %q is invalid: %s", pattern, err)
+		}
+	}
+	return nil
+}
+
+// exempts reports whether the variable name matches one of the exempt names or globs
+func (c *Kb4VariableCollectionTypesRuleConfig) exempts(name string) bool {
+	for _, pattern := range c.Exempt {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Kb4VariableCollectionTypesRule checks that collection types in variables declare their element types
+type Kb4VariableCollectionTypesRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4VariableCollectionTypesRule())
+}
+
+// NewKb4VariableCollectionTypesRule returns a new rule
+func NewKb4VariableCollectionTypesRule() *Kb4VariableCollectionTypesRule {
+	return &Kb4VariableCollectionTypesRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4VariableCollectionTypesRule) Name() string {
+	return "kb4_variable_collection_types"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4VariableCollectionTypesRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4VariableCollectionTypesRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4VariableCollectionTypesRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4VariableCollectionTypesRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "`list`, `set` and `map` types in variables must declare an element type other than `any`, e.g. `list(string)` or `map(object({...}))`, so callers get type errors instead of surprises.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "variables",
+		Example: `
+variable "subnet_ids" {
+  type = list(any)
+}`,
+		Config: newKb4VariableCollectionTypesRuleConfig(),
+	}
+}
+
+// Check emits an issue for every collection type without a concrete element type
+func (r *Kb4VariableCollectionTypesRule) Check(runner tflint.Runner) error {
+	config := newKb4VariableCollectionTypesRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "type"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, variable := range content.Blocks {
+		name := variable.Labels[0]
+		typeAttr, ok := variable.Body.Attributes["type"]
+		if !ok || config.exempts(name) {
+			continue
+		}
+		ty, err := parseVariableType(typeAttr.Expr)
+		if err != nil {
+			continue
+		}
+
+		open := []*variableType{}
+		ty.walk(func(t *variableType, depth int) {
+			switch t.Name {
+			case "list", "set", "map":
+				if t.Element == nil || t.Element.Name == "any" {
+					open = append(open, t)
+				}
+			}
+		})
+
+		for _, t := range open {
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s` variable should declare the element type of its %s, e.g. %s(string)", name, t.Name, t.Name),
+				t.Range,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4VariableCollectionTypesRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "typed collections",
+			Content: `
+variable "subnet_ids" {
+  type = list(string)
+}
+
+variable "services" {
+  type = map(object({ ports = set(number) }))
+}
+
+variable "anything" {
+  type = any
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "bare and any collections",
+			Content: `
+variable "subnet_ids" {
+  type = list
+}
+
+variable "services" {
+  type = map(object({ ports = set(any) }))
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableCollectionTypesRule(),
+					Message: "`subnet_ids` variable should declare the element type of its list, e.g. list(string)",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 3, Column: 14},
+					},
+				},
+				{
+					Rule:    NewKb4VariableCollectionTypesRule(),
+					Message: "`services` variable should declare the element type of its set, e.g. set(string)",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 7, Column: 31},
+						End:      hcl.Pos{Line: 7, Column: 39},
+					},
+				},
+			},
+		},
+		{
+			Name: "exempt variables",
+			Content: `
+variable "tags" {
+  type = map(any)
+}
+
+variable "extra_settings" {
+  type = map
+}`,
+			Config: `
+rule "kb4_variable_collection_types" {
+  enabled = true
+  exempt  = ["tags", "extra_*"]
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewKb4VariableCollectionTypesRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"variables.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
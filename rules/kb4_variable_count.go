@@ -0,0 +1,108 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4VariableCountRuleConfig is the rule's .tflint.hcl config
+type Kb4VariableCountRuleConfig struct {
+	MaxVariables int `hclext:"max_variables,optional" doc:"Most variables a module may declare."`
+}
+
+func newKb4VariableCountRuleConfig() *Kb4VariableCountRuleConfig {
+	return &Kb4VariableCountRuleConfig{MaxVariables: 30}
+}
+
+// Validate rejects limits below one
+func (c *Kb4VariableCountRuleConfig) Validate() error {
+	if c.MaxVariables < 1 {
+		return fmt.Errorf("max_variables must be at least 1")
+	}
+	return nil
+}
+
+// Kb4VariableCountRule checks that modules keep their interface to a manageable number of variables
+type Kb4VariableCountRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4VariableCountRule())
+}
+
+// NewKb4VariableCountRule returns a new rule
+func NewKb4VariableCountRule() *Kb4VariableCountRule {
+	return &Kb4VariableCountRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4VariableCountRule) Name() string {
+	return "kb4_variable_count"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4VariableCountRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4VariableCountRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4VariableCountRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4VariableCountRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Modules may declare at most `max_variables` variables. A longer interface is a sign the module should be split, or related inputs grouped into typed objects.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "variables",
+		Config:      newKb4VariableCountRuleConfig(),
+	}
+}
+
+// Check emits an issue on the first variable over the limit
+func (r *Kb4VariableCountRule) Check(runner tflint.Runner) error {
+	config := newKb4VariableCountRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if len(content.Blocks) <= config.MaxVariables {
+		return nil
+	}
+
+	// Anchor the issue deterministically, whatever order the files were read in
+	variables := append([]*hclext.Block{}, content.Blocks...)
+	sort.SliceStable(variables, func(i, j int) bool {
+		return rangeLess(variables[i].DefRange, variables[j].DefRange)
+	})
+
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("Module declares %d variables, more than the %d allowed. Split the module or group related variables into objects.", len(variables), config.MaxVariables),
+		variables[config.MaxVariables].DefRange,
+	)
+}
@@ -0,0 +1,85 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4VariableCountRule(t *testing.T) {
+	variables := func(n int) string {
+		var b strings.Builder
+		for i := 0; i < n; i++ {
+			fmt.Fprintf(&b, "variable \"v%02d\" {}\n", i)
+		}
+		return b.String()
+	}
+
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name:     "at the limit",
+			Files:    map[string]string{"variables.tf": variables(30)},
+			Expected: helper.Issues{},
+		},
+		{
+			Name:  "over the limit",
+			Files: map[string]string{"variables.tf": variables(32)},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableCountRule(),
+					Message: "Module declares 32 variables, more than the 30 allowed. Split the module or group related variables into objects.",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 31, Column: 1},
+						End:      hcl.Pos{Line: 31, Column: 15},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured limit",
+			Files: map[string]string{
+				"a.tf": variables(2),
+				"b.tf": `variable "other" {}`,
+				".tflint.hcl": `
+rule "kb4_variable_count" {
+  enabled       = true
+  max_variables = 2
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableCountRule(),
+					Message: "Module declares 3 variables, more than the 2 allowed. Split the module or group related variables into objects.",
+					Range: hcl.Range{
+						Filename: "b.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 17},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4VariableCountRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.Files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// Kb4VariableDefaultValidationRule checks that variable defaults pass the variable's own validation blocks
+type Kb4VariableDefaultValidationRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4VariableDefaultValidationRule())
+}
+
+// NewKb4VariableDefaultValidationRule returns a new rule
+func NewKb4VariableDefaultValidationRule() *Kb4VariableDefaultValidationRule {
+	return &Kb4VariableDefaultValidationRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4VariableDefaultValidationRule) Name() string {
+	return "kb4_variable_default_validation"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4VariableDefaultValidationRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4VariableDefaultValidationRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4VariableDefaultValidationRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4VariableDefaultValidationRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Variable defaults must pass the variable's own `validation` blocks. Conditions that call functions the ruleset doesn't implement, or refer to anything but the variable, are skipped.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "validation",
+		Example: `
+variable "environment" {
+  type    = string
+  default = "development"
+
+  validation {
+    condition     = contains(["dev", "staging", "prod"], var.environment)
+    error_message = "Environment must be dev, staging or prod."
+  }
+}`,
+	}
+}
+
+// Check evaluates each validation condition with the variable's default in place of var.<name>
+func (r *Kb4VariableDefaultValidationRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "default"}, {Name: "type"}},
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "validation",
+							Body: &hclext.BodySchema{
+								Attributes: []hclext.AttributeSchema{{Name: "condition"}, {Name: "error_message"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, variable := range content.Blocks {
+		defaultAttr, ok := variable.Body.Attributes["default"]
+		if !ok || len(variable.Body.Blocks) == 0 {
+			continue
+		}
+
+		value, ok := variableDefault(variable)
+		if !ok {
+			continue
+		}
+
+		name := variable.Labels[0]
+		ctx := &hcl.EvalContext{
+			Variables: map[string]cty.Value{"var": cty.ObjectVal(map[string]cty.Value{name: value})},
+			Functions: terraformFunctions,
+		}
+
+		for _, validation := range variable.Body.Blocks {
+			condition, ok := validation.Body.Attributes["condition"]
+			if !ok || !refersOnlyTo(condition.Expr, name) || !callsOnlyKnownFunctions(condition.Expr) {
+				continue
+			}
+
+			result, diags := condition.Expr.Value(ctx)
+			if diags.HasErrors() || !result.IsKnown() || result.IsNull() || result.Type() != cty.Bool || result.True() {
+				continue
+			}
+
+			message := fmt.Sprintf("The default value of `%s` fails its validation", name)
+			if errorMessage, ok := validation.Body.Attributes["error_message"]; ok {
+				if text, diags := errorMessage.Expr.Value(ctx); !diags.HasErrors() && text.Type() == cty.String && text.IsKnown() && !text.IsNull() {
+					message = fmt.Sprintf("%s: %s", message, text.AsString())
+				}
+			}
+
+			if err := runner.EmitIssue(r, message, defaultAttr.Range); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// variableDefault evaluates a variable's default, converted to its type constraint like Terraform does.
+// It reports false when the default isn't a constant or doesn't convert, which are other rules' business.
+func variableDefault(variable *hclext.Block) (cty.Value, bool) {
+	value, diags := variable.Body.Attributes["default"].Expr.Value(nil)
+	if diags.HasErrors() {
+		return cty.NilVal, false
+	}
+
+	typeAttr, ok := variable.Body.Attributes["type"]
+	if !ok {
+		return value, true
+	}
+
+	// Types this version of HCL can't parse, e.g. objects with optional attributes, are checked unconverted
+	ty, diags := typeexpr.TypeConstraint(typeAttr.Expr)
+	if diags.HasErrors() {
+		return value, true
+	}
+
+	converted, err := convert.Convert(value, ty)
+	if err != nil {
+		return cty.NilVal, false
+	}
+	return converted, true
+}
+
+// refersOnlyTo reports whether var.<name> is the only value the expression refers to
+func refersOnlyTo(expr hcl.Expression, name string) bool {
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "var" || len(traversal) < 2 {
+			return false
+		}
+		if attr, ok := traversal[1].(hcl.TraverseAttr); !ok || attr.Name != name {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,159 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4VariableDefaultValidationRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "default passes",
+			Content: `
+variable "environment" {
+  type    = string
+  default = "dev"
+
+  validation {
+    condition     = contains(["dev", "staging", "prod"], var.environment)
+    error_message = "Environment must be dev, staging or prod."
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "default fails",
+			Content: `
+variable "environment" {
+  type    = string
+  default = "development"
+
+  validation {
+    condition     = contains(["dev", "staging", "prod"], var.environment)
+    error_message = "Environment must be dev, staging or prod."
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableDefaultValidationRule(),
+					Message: "The default value of `environment` fails its validation: Environment must be dev, staging or prod.",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 26},
+					},
+				},
+			},
+		},
+		{
+			Name: "default is converted to the type",
+			Content: `
+variable "replicas" {
+  type    = number
+  default = "0"
+
+  validation {
+    condition     = var.replicas > 0
+    error_message = "At least one replica is required."
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableDefaultValidationRule(),
+					Message: "The default value of `replicas` fails its validation: At least one replica is required.",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 16},
+					},
+				},
+			},
+		},
+		{
+			Name: "only failing validations are reported",
+			Content: `
+variable "name" {
+  default = "ab"
+
+  validation {
+    condition     = length(var.name) > 0
+    error_message = "Name must not be empty."
+  }
+
+  validation {
+    condition     = can(regex("^[a-z]{3,}$", var.name))
+    error_message = "Name must be at least 3 lowercase letters."
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableDefaultValidationRule(),
+					Message: "The default value of `name` fails its validation: Name must be at least 3 lowercase letters.",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 17},
+					},
+				},
+			},
+		},
+		{
+			Name: "unsupported functions are skipped",
+			Content: `
+variable "cidr" {
+  default = "10.0.0.0/99"
+
+  validation {
+    condition     = can(cidrhost(var.cidr, 0)) && false
+    error_message = "Must be a CIDR block."
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "other references are skipped",
+			Content: `
+variable "name" {
+  default = "ab"
+
+  validation {
+    condition     = can(local.names[var.name])
+    error_message = "Name must be known."
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "no default",
+			Content: `
+variable "name" {
+  validation {
+    condition     = length(var.name) > 3
+    error_message = "Name is too short."
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewKb4VariableDefaultValidationRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4VariableDeniedNamesRuleConfig is the rule's .tflint.hcl config
+type Kb4VariableDeniedNamesRuleConfig struct {
+	// Denied maps each banned name to the name to use instead, or to "" when there is no single replacement
+	Denied map[string]string `hclext:"denied,optional" doc:"Banned variable names, each mapped to its replacement or to an empty string. Setting it replaces the defaults."`
+}
+
+func newKb4VariableDeniedNamesRuleConfig() *Kb4VariableDeniedNamesRuleConfig {
+	return &Kb4VariableDeniedNamesRuleConfig{
+		Denied: map[string]string{
+			"env":   "environment",
+			"stage": "environment",
+			"data":  "",
+			"count": "",
+		},
+	}
+}
+
+// Kb4VariableDeniedNamesRule checks that variables don't use banned names
+type Kb4VariableDeniedNamesRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4VariableDeniedNamesRule())
+}
+
+// NewKb4VariableDeniedNamesRule returns a new rule
+func NewKb4VariableDeniedNamesRule() *Kb4VariableDeniedNamesRule {
+	return &Kb4VariableDeniedNamesRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4VariableDeniedNamesRule) Name() string {
+	return "kb4_variable_denied_names"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4VariableDeniedNamesRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4VariableDeniedNamesRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4VariableDeniedNamesRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4VariableDeniedNamesRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Variables must not use names from the `denied` list. Abbreviations like `env` have a standard spelling, and generic names like `data` say nothing about the input.",
+		Categories:  []string{CategoryStyle},
+		Anchor:      "variables",
+		Example: `
+variable "env" {
+  type = string
+}`,
+		Config: newKb4VariableDeniedNamesRuleConfig(),
+	}
+}
+
+// Check emits issues for variables with denied names
+func (r *Kb4VariableDeniedNamesRule) Check(runner tflint.Runner) error {
+	config := newKb4VariableDeniedNamesRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, variable := range content.Blocks {
+		name := variable.Labels[0]
+		replacement, denied := config.Denied[name]
+		if !denied {
+			continue
+		}
+
+		message := fmt.Sprintf("`%s` is not an allowed variable name; use a more specific name", name)
+		if replacement != "" {
+			message = fmt.Sprintf("`%s` is not an allowed variable name; rename it to `%s`", name, replacement)
+		}
+		if err := runner.EmitIssue(r, message, variable.DefRange); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4VariableDeniedNamesRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "allowed names",
+			Content: `
+variable "environment" {}
+
+variable "bucket_data" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "default denied names",
+			Content: `
+variable "env" {}
+
+variable "data" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableDeniedNamesRule(),
+					Message: "`env` is not an allowed variable name; rename it to `environment`",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 15},
+					},
+				},
+				{
+					Rule:    NewKb4VariableDeniedNamesRule(),
+					Message: "`data` is not an allowed variable name; use a more specific name",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 4, Column: 1},
+						End:      hcl.Pos{Line: 4, Column: 16},
+					},
+				},
+			},
+		},
+		{
+			Name: "configured denied names",
+			Content: `
+variable "env" {}
+
+variable "acct" {}`,
+			Config: `
+rule "kb4_variable_denied_names" {
+  enabled = true
+  denied  = { acct = "account_id" }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableDeniedNamesRule(),
+					Message: "`acct` is not an allowed variable name; rename it to `account_id`",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 4, Column: 1},
+						End:      hcl.Pos{Line: 4, Column: 16},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4VariableDeniedNamesRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"variables.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
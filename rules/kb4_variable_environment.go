@@ -0,0 +1,200 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kb4VariableEnvironmentRuleConfig is the rule's .tflint.hcl config
+type Kb4VariableEnvironmentRuleConfig struct {
+	// Aliases are other names modules use for the environment. env and stage are left to kb4_variable_denied_names.
+	Aliases []string `hclext:"aliases,optional" doc:"Variable names that should be renamed to environment."`
+}
+
+func newKb4VariableEnvironmentRuleConfig() *Kb4VariableEnvironmentRuleConfig {
+	return &Kb4VariableEnvironmentRuleConfig{
+		Aliases: []string{"env_name", "environment_name", "deploy_env", "deployment_environment"},
+	}
+}
+
+// Kb4VariableEnvironmentRule checks that modules take the environment as a validated `environment` string
+type Kb4VariableEnvironmentRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4VariableEnvironmentRule())
+}
+
+// NewKb4VariableEnvironmentRule returns a new rule
+func NewKb4VariableEnvironmentRule() *Kb4VariableEnvironmentRule {
+	return &Kb4VariableEnvironmentRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4VariableEnvironmentRule) Name() string {
+	return "kb4_variable_environment"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4VariableEnvironmentRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4VariableEnvironmentRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4VariableEnvironmentRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4VariableEnvironmentRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Modules that take an environment must take it as a `string` variable named `environment`, with a validation that accepts exactly the canonical environments. They come from the org policy's `environments`, or the plugin block's.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "variables",
+		Example: `
+variable "environment" {
+  type = string
+
+  validation {
+    condition     = contains(["dev", "prod"], var.environment)
+    error_message = "Environment must be dev or prod."
+  }
+}`,
+		Config: newKb4VariableEnvironmentRuleConfig(),
+	}
+}
+
+// Check emits issues for environment variables under another name, of another type or without a matching validation
+func (r *Kb4VariableEnvironmentRule) Check(runner tflint.Runner) error {
+	config := newKb4VariableEnvironmentRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "type"}},
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "validation",
+							Body: &hclext.BodySchema{
+								Attributes: []hclext.AttributeSchema{{Name: "condition"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	envs := environments(runner)
+
+	for _, variable := range content.Blocks {
+		name := variable.Labels[0]
+
+		if containsString(config.Aliases, name) {
+			if err := runner.EmitIssue(r, fmt.Sprintf("`%s` variable should be named `environment`", name), variable.DefRange); err != nil {
+				return err
+			}
+			continue
+		}
+		if name != "environment" {
+			continue
+		}
+
+		typeAttr, ok := variable.Body.Attributes["type"]
+		if !ok {
+			if err := runner.EmitIssue(r, "`environment` variable should set type = string", variable.DefRange); err != nil {
+				return err
+			}
+		} else if ty, err := parseVariableType(typeAttr.Expr); err != nil || ty.Name != "string" {
+			if err := runner.EmitIssue(r, "`environment` variable should be a string", typeAttr.Expr.Range()); err != nil {
+				return err
+			}
+		}
+
+		if !restrictsToEnvironments(variable.Body.Blocks, envs) {
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("`environment` variable should have a validation that only accepts %s", strings.Join(envs, ", ")),
+				variable.DefRange,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// restrictsToEnvironments reports whether the validations accept every environment and reject other values.
+// The conditions are tried against the environments, the other strings they mention and a value nobody would allow.
+func restrictsToEnvironments(validations []*hclext.Block, envs []string) bool {
+	conditions := []hcl.Expression{}
+	candidates := append([]string{"kb4-not-an-environment"}, envs...)
+
+	for _, validation := range validations {
+		condition, ok := validation.Body.Attributes["condition"]
+		if !ok || !refersOnlyTo(condition.Expr, "environment") || !callsOnlyKnownFunctions(condition.Expr) {
+			continue
+		}
+		conditions = append(conditions, condition.Expr)
+
+		if expr, ok := condition.Expr.(hclsyntax.Expression); ok {
+			hclsyntax.VisitAll(expr, func(node hclsyntax.Node) hcl.Diagnostics {
+				if lit, ok := node.(*hclsyntax.LiteralValueExpr); ok && lit.Val.Type() == cty.String && lit.Val.IsKnown() && !lit.Val.IsNull() {
+					candidates = append(candidates, lit.Val.AsString())
+				}
+				return nil
+			})
+		}
+	}
+
+	if len(conditions) == 0 {
+		return false
+	}
+
+	for _, candidate := range candidates {
+		if acceptsEnvironment(conditions, candidate) != containsString(envs, candidate) {
+			return false
+		}
+	}
+	return true
+}
+
+// acceptsEnvironment reports whether every condition holds with var.environment set to the value
+func acceptsEnvironment(conditions []hcl.Expression, value string) bool {
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{"var": cty.ObjectVal(map[string]cty.Value{"environment": cty.StringVal(value)})},
+		Functions: terraformFunctions,
+	}
+
+	for _, condition := range conditions {
+		result, diags := condition.Value(ctx)
+		if diags.HasErrors() || !result.IsKnown() || result.IsNull() || result.Type() != cty.Bool || result.False() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,171 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_Kb4VariableEnvironmentRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Policy   *Policy
+		Expected helper.Issues
+	}{
+		{
+			Name: "validated environment",
+			Content: `
+variable "environment" {
+  type = string
+
+  validation {
+    condition     = contains(["dev", "staging", "prod"], var.environment)
+    error_message = "Environment must be dev, staging or prod."
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "validation split across blocks",
+			Content: `
+variable "environment" {
+  type = string
+
+  validation {
+    condition     = var.environment != "qa"
+    error_message = "qa is retired."
+  }
+
+  validation {
+    condition     = can(regex("^(dev|staging|prod)$", var.environment))
+    error_message = "Environment must be dev, staging or prod."
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "alias",
+			Content: `
+variable "env_name" {
+  type = string
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableEnvironmentRule(),
+					Message: "`env_name` variable should be named `environment`",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 20},
+					},
+				},
+			},
+		},
+		{
+			Name: "untyped and unvalidated",
+			Content: `
+variable "environment" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableEnvironmentRule(),
+					Message: "`environment` variable should set type = string",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 23},
+					},
+				},
+				{
+					Rule:    NewKb4VariableEnvironmentRule(),
+					Message: "`environment` variable should have a validation that only accepts dev, staging, prod",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 23},
+					},
+				},
+			},
+		},
+		{
+			Name: "wrong type and extra value allowed",
+			Content: `
+variable "environment" {
+  type = list(string)
+
+  validation {
+    condition     = contains(["dev", "qa", "staging", "prod"], var.environment)
+    error_message = "Unknown environment."
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableEnvironmentRule(),
+					Message: "`environment` variable should be a string",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 3, Column: 22},
+					},
+				},
+				{
+					Rule:    NewKb4VariableEnvironmentRule(),
+					Message: "`environment` variable should have a validation that only accepts dev, staging, prod",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 23},
+					},
+				},
+			},
+		},
+		{
+			Name: "environments from the org policy",
+			Content: `
+variable "environment" {
+  type = string
+
+  validation {
+    condition     = contains(["dev", "staging", "prod"], var.environment)
+    error_message = "Environment must be dev, staging or prod."
+  }
+}`,
+			Policy: &Policy{Environments: []string{"dev", "prod"}},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableEnvironmentRule(),
+					Message: "`environment` variable should have a validation that only accepts dev, prod",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 23},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4VariableEnvironmentRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			testRunner := helper.TestRunner(t, map[string]string{"variables.tf": tc.Content})
+
+			var runner tflint.Runner = testRunner
+			if tc.Policy != nil {
+				config := DefaultConfig()
+				config.policy = tc.Policy
+				runner = NewRunner(testRunner, config)
+			}
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, testRunner.Issues)
+		})
+	}
+}
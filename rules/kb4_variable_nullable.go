@@ -0,0 +1,128 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kb4VariableNullableRuleConfig is the rule's .tflint.hcl config
+type Kb4VariableNullableRuleConfig struct {
+	// RequireNonNullable requires `nullable = false` rather than only an explicit choice
+	RequireNonNullable bool `hclext:"require_non_nullable,optional" doc:"Require complex variables to set nullable = false."`
+}
+
+func newKb4VariableNullableRuleConfig() *Kb4VariableNullableRuleConfig {
+	return &Kb4VariableNullableRuleConfig{}
+}
+
+// Kb4VariableNullableRule checks that object and collection variables decide whether they accept null
+type Kb4VariableNullableRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4VariableNullableRule())
+}
+
+// NewKb4VariableNullableRule returns a new rule
+func NewKb4VariableNullableRule() *Kb4VariableNullableRule {
+	return &Kb4VariableNullableRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4VariableNullableRule) Name() string {
+	return "kb4_variable_nullable"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4VariableNullableRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4VariableNullableRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4VariableNullableRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4VariableNullableRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Object and collection variables must set `nullable`, since a null passed by accident crashes the `for_each` and `length()` calls that consume them.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "variables",
+		Example: `
+variable "subnets" {
+  type = map(string)
+}`,
+		Config: newKb4VariableNullableRuleConfig(),
+	}
+}
+
+// Check emits issues for complex variables without an explicit nullable
+func (r *Kb4VariableNullableRule) Check(runner tflint.Runner) error {
+	config := newKb4VariableNullableRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "type"}, {Name: "nullable"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, variable := range content.Blocks {
+		typeAttr, ok := variable.Body.Attributes["type"]
+		if !ok {
+			continue
+		}
+		ty, err := parseVariableType(typeAttr.Expr)
+		if err != nil || !ty.isComplex() {
+			continue
+		}
+
+		name := variable.Labels[0]
+		nullable, ok := variable.Body.Attributes["nullable"]
+		if !ok {
+			message := fmt.Sprintf("`%s` variable has a %s type and should set nullable explicitly", name, ty.Name)
+			if config.RequireNonNullable {
+				message = fmt.Sprintf("`%s` variable has a %s type and should set nullable = false", name, ty.Name)
+			}
+			if err := runner.EmitIssue(r, message, variable.DefRange); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !config.RequireNonNullable {
+			continue
+		}
+		if value, diags := nullable.Expr.Value(nil); !diags.HasErrors() && value.Type() == cty.Bool && value.IsKnown() && !value.IsNull() && value.False() {
+			continue
+		}
+		if err := runner.EmitIssue(r, fmt.Sprintf("`%s` variable should set nullable = false", name), nullable.Range); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
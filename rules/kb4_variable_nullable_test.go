@@ -0,0 +1,129 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4VariableNullableRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "primitive and untyped variables",
+			Content: `
+variable "name" {
+  type = string
+}
+
+variable "anything" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "explicit nullable",
+			Content: `
+variable "subnets" {
+  type     = map(string)
+  nullable = true
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "missing nullable",
+			Content: `
+variable "subnets" {
+  type = map(string)
+}
+
+variable "rules" {
+  type = list(object({ port = number }))
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableNullableRule(),
+					Message: "`subnets` variable has a map type and should set nullable explicitly",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 19},
+					},
+				},
+				{
+					Rule:    NewKb4VariableNullableRule(),
+					Message: "`rules` variable has a list type and should set nullable explicitly",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 17},
+					},
+				},
+			},
+		},
+		{
+			Name: "non-nullable required",
+			Config: `
+rule "kb4_variable_nullable" {
+  enabled              = true
+  require_non_nullable = true
+}`,
+			Content: `
+variable "subnets" {
+  type     = map(string)
+  nullable = true
+}
+
+variable "tags" {
+  type     = map(string)
+  nullable = false
+}
+
+variable "rules" {
+  type = set(string)
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableNullableRule(),
+					Message: "`subnets` variable should set nullable = false",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 18},
+					},
+				},
+				{
+					Rule:    NewKb4VariableNullableRule(),
+					Message: "`rules` variable has a set type and should set nullable = false",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 12, Column: 1},
+						End:      hcl.Pos{Line: 12, Column: 17},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4VariableNullableRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"variables.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
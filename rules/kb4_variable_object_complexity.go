@@ -0,0 +1,158 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4VariableObjectComplexityRuleConfig is the rule's .tflint.hcl config
+type Kb4VariableObjectComplexityRuleConfig struct {
+	MaxAttributes int `hclext:"max_attributes,optional" doc:"Most attributes a single object type may declare."`
+	MaxDepth      int `hclext:"max_depth,optional" doc:"Most levels objects may be nested, counting the outermost object as 1."`
+}
+
+func newKb4VariableObjectComplexityRuleConfig() *Kb4VariableObjectComplexityRuleConfig {
+	return &Kb4VariableObjectComplexityRuleConfig{
+		MaxAttributes: 15,
+		MaxDepth:      3,
+	}
+}
+
+// Validate rejects limits below one
+func (c *Kb4VariableObjectComplexityRuleConfig) Validate() error {
+	if c.MaxAttributes < 1 || c.MaxDepth < 1 {
+		return fmt.Errorf("max_attributes and max_depth must be at least 1")
+	}
+	return nil
+}
+
+// Kb4VariableObjectComplexityRule checks that object variables stay small and shallow enough to validate and document
+type Kb4VariableObjectComplexityRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4VariableObjectComplexityRule())
+}
+
+// NewKb4VariableObjectComplexityRule returns a new rule
+func NewKb4VariableObjectComplexityRule() *Kb4VariableObjectComplexityRule {
+	return &Kb4VariableObjectComplexityRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4VariableObjectComplexityRule) Name() string {
+	return "kb4_variable_object_complexity"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4VariableObjectComplexityRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4VariableObjectComplexityRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4VariableObjectComplexityRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4VariableObjectComplexityRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Object types in variables may declare at most `max_attributes` attributes and nest at most `max_depth` objects deep. Larger inputs can't be validated or documented sensibly and should be split into several variables.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "variables",
+		Example: `
+variable "service" {
+  type = object({
+    network = object({
+      subnets = map(object({
+        routes = list(object({ cidr = string }))
+      }))
+    })
+  })
+}`,
+		Config: newKb4VariableObjectComplexityRuleConfig(),
+	}
+}
+
+// Check emits issues for the first object in each variable that is too large and the first that is nested too deep
+func (r *Kb4VariableObjectComplexityRule) Check(runner tflint.Runner) error {
+	config := newKb4VariableObjectComplexityRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "type"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, variable := range content.Blocks {
+		typeAttr, ok := variable.Body.Attributes["type"]
+		if !ok {
+			continue
+		}
+		ty, err := parseVariableType(typeAttr.Expr)
+		if err != nil {
+			continue
+		}
+
+		var wide, deep *variableType
+		deepest := 0
+		ty.walk(func(t *variableType, depth int) {
+			if t.Name != "object" {
+				return
+			}
+			if wide == nil && len(t.Attributes) > config.MaxAttributes {
+				wide = t
+			}
+			if depth+1 > deepest {
+				deepest = depth + 1
+			}
+			if deep == nil && depth+1 > config.MaxDepth {
+				deep = t
+			}
+		})
+
+		name := variable.Labels[0]
+		if wide != nil {
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s` variable has an object type with %d attributes, more than the %d allowed", name, len(wide.Attributes), config.MaxAttributes),
+				wide.Range,
+			); err != nil {
+				return err
+			}
+		}
+		if deep != nil {
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s` variable nests objects %d levels deep, more than the %d allowed", name, deepest, config.MaxDepth),
+				deep.Range,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
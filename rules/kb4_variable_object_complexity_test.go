@@ -0,0 +1,103 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4VariableObjectComplexityRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "within the limits",
+			Content: `
+variable "service" {
+  type = object({
+    name    = string
+    network = object({
+      subnets = map(object({ cidr = string }))
+    })
+  })
+}
+
+variable "name" {
+  type = string
+}
+
+variable "untyped" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "nested too deep",
+			Content: `
+variable "service" {
+  type = object({
+    network = object({
+      subnets = map(object({
+        routes = list(object({ cidr = string }))
+      }))
+    })
+  })
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableObjectComplexityRule(),
+					Message: "`service` variable nests objects 4 levels deep, more than the 3 allowed",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 6, Column: 23},
+						End:      hcl.Pos{Line: 6, Column: 48},
+					},
+				},
+			},
+		},
+		{
+			Name: "too many attributes",
+			Content: `
+variable "service" {
+  type = list(object({ a = string, b = string, c = string }))
+}`,
+			Config: `
+rule "kb4_variable_object_complexity" {
+  enabled        = true
+  max_attributes = 2
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableObjectComplexityRule(),
+					Message: "`service` variable has an object type with 3 attributes, more than the 2 allowed",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 3, Column: 15},
+						End:      hcl.Pos{Line: 3, Column: 61},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4VariableObjectComplexityRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"variables.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
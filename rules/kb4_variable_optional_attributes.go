@@ -0,0 +1,253 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kb4VariableOptionalAttributesRule checks that object attributes the module treats as optional are declared with optional()
+type Kb4VariableOptionalAttributesRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4VariableOptionalAttributesRule())
+}
+
+// NewKb4VariableOptionalAttributesRule returns a new rule
+func NewKb4VariableOptionalAttributesRule() *Kb4VariableOptionalAttributesRule {
+	return &Kb4VariableOptionalAttributesRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4VariableOptionalAttributesRule) Name() string {
+	return "kb4_variable_optional_attributes"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4VariableOptionalAttributesRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4VariableOptionalAttributesRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *Kb4VariableOptionalAttributesRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4VariableOptionalAttributesRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Object variable attributes that the module fills in itself, with `merge()` over defaults, `lookup()` with a default or `try()`, must be declared as `optional(type, default)` instead (Terraform 1.3+).",
+		Categories:  []string{CategoryStyle},
+		Anchor:      "variables",
+		Example: `
+variable "logging" {
+  type = object({
+    bucket    = string
+    retention = number
+  })
+}
+
+locals {
+  logging = merge({ retention = 30 }, var.logging)
+}`,
+	}
+}
+
+// Check emits an issue for each object variable whose required attributes are defaulted by the module
+func (r *Kb4VariableOptionalAttributesRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "type"}},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	// Required attributes of each object variable
+	required := map[string]map[string]bool{}
+	typeRanges := map[string]hcl.Range{}
+	for _, variable := range content.Blocks {
+		typeAttr, ok := variable.Body.Attributes["type"]
+		if !ok {
+			continue
+		}
+		ty, err := parseVariableType(typeAttr.Expr)
+		if err != nil || ty.Name != "object" {
+			continue
+		}
+
+		attrs := map[string]bool{}
+		for _, attr := range ty.Attributes {
+			if !attr.Optional {
+				attrs[attr.Name] = true
+			}
+		}
+		required[variable.Labels[0]] = attrs
+		typeRanges[variable.Labels[0]] = typeAttr.Range
+	}
+	if len(required) == 0 {
+		return nil
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+
+	defaulted := map[string][]string{}
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+			call, ok := node.(*hclsyntax.FunctionCallExpr)
+			if !ok {
+				return nil
+			}
+			for name, attrs := range defaultedAttributes(call) {
+				for _, attr := range attrs {
+					if required[name][attr] && !containsString(defaulted[name], attr) {
+						defaulted[name] = append(defaulted[name], attr)
+					}
+				}
+			}
+			return nil
+		})
+	}
+
+	for _, variable := range content.Blocks {
+		name := variable.Labels[0]
+		attrs, ok := defaulted[name]
+		if !ok {
+			continue
+		}
+
+		// Report in declaration order
+		ordered := []string{}
+		ty, _ := parseVariableType(variable.Body.Attributes["type"].Expr)
+		for _, attr := range ty.Attributes {
+			if containsString(attrs, attr.Name) {
+				ordered = append(ordered, fmt.Sprintf("`%s`", attr.Name))
+			}
+		}
+
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("`%s` variable defaults %s in the module; declare them with optional(type, default) in its type", name, strings.Join(ordered, ", ")),
+			typeRanges[name],
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// defaultedAttributes returns the variable attributes a function call supplies a fallback for, by variable name:
+//
+//	merge({ retention = 30 }, var.logging)   // retention
+//	lookup(var.logging, "retention", 30)     // retention
+//	try(var.logging.retention, 30)           // retention
+func defaultedAttributes(call *hclsyntax.FunctionCallExpr) map[string][]string {
+	found := map[string][]string{}
+
+	switch call.Name {
+	case "merge":
+		defaults := []string{}
+		for _, arg := range call.Args {
+			if obj, ok := arg.(*hclsyntax.ObjectConsExpr); ok {
+				for _, item := range obj.Items {
+					if key := objectKey(item.KeyExpr); key != "" {
+						defaults = append(defaults, key)
+					}
+				}
+				continue
+			}
+			if name, attr := variableAttribute(arg); name != "" && attr == "" {
+				found[name] = append(found[name], defaults...)
+			}
+		}
+
+	case "lookup":
+		if len(call.Args) != 3 {
+			break
+		}
+		name, attr := variableAttribute(call.Args[0])
+		if name == "" || attr != "" {
+			break
+		}
+		if key, diags := call.Args[1].Value(nil); !diags.HasErrors() && key.Type() == cty.String && !key.IsNull() {
+			found[name] = append(found[name], key.AsString())
+		}
+
+	case "try":
+		if len(call.Args) < 2 {
+			break
+		}
+		if name, attr := variableAttribute(call.Args[0]); name != "" && attr != "" {
+			found[name] = append(found[name], attr)
+		}
+	}
+
+	return found
+}
+
+// variableAttribute returns the variable name and attribute of var.<name> or var.<name>.<attr> expressions
+func variableAttribute(expr hclsyntax.Expression) (string, string) {
+	traversal, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok || traversal.Traversal.RootName() != "var" || len(traversal.Traversal) < 2 || len(traversal.Traversal) > 3 {
+		return "", ""
+	}
+
+	name, ok := traversal.Traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return "", ""
+	}
+	if len(traversal.Traversal) == 2 {
+		return name.Name, ""
+	}
+
+	attr, ok := traversal.Traversal[2].(hcl.TraverseAttr)
+	if !ok {
+		return "", ""
+	}
+	return name.Name, attr.Name
+}
+
+// objectKey returns the literal key of an object constructor item, or "" when it is computed
+func objectKey(expr hclsyntax.Expression) string {
+	if key := hcl.ExprAsKeyword(expr); key != "" {
+		return key
+	}
+	if value, diags := expr.Value(nil); !diags.HasErrors() && value.Type() == cty.String && !value.IsNull() {
+		return value.AsString()
+	}
+	return ""
+}
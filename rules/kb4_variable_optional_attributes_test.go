@@ -0,0 +1,92 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4VariableOptionalAttributesRule(t *testing.T) {
+	variables := `
+variable "logging" {
+  type = object({
+    bucket    = string
+    retention = number
+    prefix    = optional(string, "logs/")
+    tier      = string
+  })
+}
+`
+	typeRange := hcl.Range{
+		Filename: "variables.tf",
+		Start:    hcl.Pos{Line: 3, Column: 3},
+		End:      hcl.Pos{Line: 8, Column: 5},
+	}
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name:     "attributes used directly",
+			Content:  `locals { bucket = var.logging.bucket }`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:     "optional attributes defaulted",
+			Content:  `locals { prefix = try(var.logging.prefix, "logs/") }`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "merged defaults",
+			Content: `
+locals {
+  logging = merge({ retention = 30, "tier" = "standard", prefix = "x/" }, var.logging)
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableOptionalAttributesRule(),
+					Message: "`logging` variable defaults `retention`, `tier` in the module; declare them with optional(type, default) in its type",
+					Range:   typeRange,
+				},
+			},
+		},
+		{
+			Name: "lookup and try",
+			Content: `
+resource "aws_s3_bucket_lifecycle_configuration" "this" {
+  bucket = lookup(var.logging, "bucket", "default")
+  days   = try(var.logging.retention, 30)
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableOptionalAttributesRule(),
+					Message: "`logging` variable defaults `bucket`, `retention` in the module; declare them with optional(type, default) in its type",
+					Range:   typeRange,
+				},
+			},
+		},
+		{
+			Name:     "other variables",
+			Content:  `locals { tags = merge({ tier = "x" }, var.tags) }`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewKb4VariableOptionalAttributesRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": variables, "main.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// reservedVariableNames are the module block arguments and meta-arguments a variable name would collide with
+var reservedVariableNames = []string{"source", "version", "providers", "count", "for_each", "depends_on", "lifecycle"}
+
+// Kb4VariableReservedNamesRule checks that variables don't shadow module block arguments
+type Kb4VariableReservedNamesRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4VariableReservedNamesRule())
+}
+
+// NewKb4VariableReservedNamesRule returns a new rule
+func NewKb4VariableReservedNamesRule() *Kb4VariableReservedNamesRule {
+	return &Kb4VariableReservedNamesRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4VariableReservedNamesRule) Name() string {
+	return "kb4_variable_reserved_names"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4VariableReservedNamesRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4VariableReservedNamesRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4VariableReservedNamesRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4VariableReservedNamesRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Variables must not be named `source`, `version`, `providers`, `count`, `for_each`, `depends_on` or `lifecycle`. Those are arguments of the `module` block, so callers can't set the variable and get a confusing error instead.",
+		Categories:  []string{CategoryNaming},
+		Anchor:      "variables",
+		Example: `
+variable "version" {
+  type = string
+}`,
+	}
+}
+
+// Check emits issues for variables with reserved names
+func (r *Kb4VariableReservedNamesRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, variable := range content.Blocks {
+		name := variable.Labels[0]
+		if !containsString(reservedVariableNames, name) {
+			continue
+		}
+
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("`%s` is reserved by the module block and can't be set by callers; rename the variable", name),
+			variable.DefRange,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
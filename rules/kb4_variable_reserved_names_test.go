@@ -0,0 +1,67 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4VariableReservedNamesRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "ordinary names",
+			Content: `
+variable "source_bucket" {}
+
+variable "app_version" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "reserved names",
+			Content: `
+variable "version" {}
+
+variable "for_each" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VariableReservedNamesRule(),
+					Message: "`version` is reserved by the module block and can't be set by callers; rename the variable",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 19},
+					},
+				},
+				{
+					Rule:    NewKb4VariableReservedNamesRule(),
+					Message: "`for_each` is reserved by the module block and can't be set by callers; rename the variable",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 4, Column: 1},
+						End:      hcl.Pos{Line: 4, Column: 20},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4VariableReservedNamesRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kb4VpcFlowLogsRule checks that every VPC a module declares ships flow logs
+type Kb4VpcFlowLogsRule struct {
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewKb4VpcFlowLogsRule())
+}
+
+// NewKb4VpcFlowLogsRule returns a new rule
+func NewKb4VpcFlowLogsRule() *Kb4VpcFlowLogsRule {
+	return &Kb4VpcFlowLogsRule{}
+}
+
+// Name returns the rule name
+func (r *Kb4VpcFlowLogsRule) Name() string {
+	return "kb4_vpc_flow_logs"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Kb4VpcFlowLogsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *Kb4VpcFlowLogsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *Kb4VpcFlowLogsRule) Link() string {
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *Kb4VpcFlowLogsRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Every `aws_vpc` resource must have an `aws_flow_log` in the same module whose `vpc_id` references it.",
+		Categories:  []string{CategorySecurity},
+		Anchor:      "logging",
+		Example: `
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}`,
+	}
+}
+
+// Check emits an issue for every VPC that no flow log references
+func (r *Kb4VpcFlowLogsRule) Check(runner tflint.Runner) error {
+	if err := r.decodeConfig(runner, r, nil); err != nil {
+		return err
+	}
+
+	vpcs, err := runner.GetResourceContent("aws_vpc", &hclext.BodySchema{}, nil)
+	if err != nil {
+		return err
+	}
+	if len(vpcs.Blocks) == 0 {
+		return nil
+	}
+
+	logs, err := runner.GetResourceContent("aws_flow_log", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "vpc_id"}},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	logged := map[string]bool{}
+	for _, log := range logs.Blocks {
+		if attr, ok := log.Body.Attributes["vpc_id"]; ok {
+			for _, name := range referencedResources(attr.Expr, "aws_vpc") {
+				logged[name] = true
+			}
+		}
+	}
+
+	for _, vpc := range vpcs.Blocks {
+		name := vpc.Labels[1]
+		if logged[name] {
+			continue
+		}
+
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s has no flow logs; add an aws_flow_log with vpc_id = aws_vpc.%s.id", describeBlock(vpc), name),
+			vpc.DefRange,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// referencedResources returns the names of the resources of the given type that the expression references
+func referencedResources(expr hcl.Expression, resourceType string) []string {
+	names := []string{}
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() == resourceType {
+			if name := traversalAttr(traversal); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,75 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Kb4VpcFlowLogsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "flow logs",
+			Files: map[string]string{
+				"vpc.tf": `
+resource "aws_vpc" "main" {}
+
+resource "aws_vpc" "shared" {
+  count = 2
+}`,
+				"flow_logs.tf": `
+resource "aws_flow_log" "main" {
+  vpc_id = aws_vpc.main.id
+}
+
+resource "aws_flow_log" "shared" {
+  count  = 2
+  vpc_id = aws_vpc.shared[count.index].id
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "missing flow logs",
+			Files: map[string]string{
+				"vpc.tf": `
+resource "aws_vpc" "main" {}
+
+resource "aws_flow_log" "other" {
+  vpc_id = var.vpc_id
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewKb4VpcFlowLogsRule(),
+					Message: "resource \"aws_vpc\" \"main\" has no flow logs; add an aws_flow_log with vpc_id = aws_vpc.main.id",
+					Range: hcl.Range{
+						Filename: "vpc.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 26},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewKb4VpcFlowLogsRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.Files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package rules
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// localValues returns the attributes of every locals block in the native syntax files of the module, sorted by file and position.
+// Locals have no fixed schema, so they are read from the files rather than with GetModuleContent. JSON files are skipped.
+func localValues(runner tflint.Runner) ([]*hclsyntax.Attribute, error) {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	found := []*hclsyntax.Attribute{}
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "locals" {
+				continue
+			}
+			for _, attr := range block.Body.Attributes {
+				found = append(found, attr)
+			}
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return rangeLess(found[i].SrcRange, found[j].SrcRange)
+	})
+
+	return found, nil
+}
@@ -0,0 +1,48 @@
+package rules
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// logDebug logs an event at DEBUG level with alternating keys and values rendered as key=value,
+// so timings can be grepped out of `TFLINT_LOG=debug tflint` on slow CI runs
+func logDebug(event string, fields ...interface{}) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[DEBUG] kb4: %s", event)
+	for i := 0; i+1 < len(fields); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", fields[i], fields[i+1])
+	}
+	log.Print(b.String())
+}
+
+// logWarn logs a message at WARN level, for config that still works but should be changed and for checks skipped on lookup failures
+func logWarn(format string, args ...interface{}) {
+	log.Printf("[WARN] kb4: "+format, args...)
+}
+
+// timeCheck starts timing one named check within a rule. Call the returned func when the check is done
+// to log its duration and, on the ruleset's runner, how many issues it reported:
+//
+//	done := timeCheck(runner, r, "variables")
+func timeCheck(runner tflint.Runner, rule tflint.Rule, check string) func() {
+	start := time.Now()
+	counted, ok := runner.(*Runner)
+
+	before := 0
+	if ok {
+		before = counted.issueCount(rule)
+	}
+
+	return func() {
+		fields := []interface{}{"rule", rule.Name(), "check", check, "duration", time.Since(start)}
+		if ok {
+			fields = append(fields, "issues", counted.issueCount(rule)-before)
+		}
+		logDebug("check", fields...)
+	}
+}
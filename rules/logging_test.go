@@ -0,0 +1,37 @@
+package rules
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_RuleSet_Check_debugLog(t *testing.T) {
+	var out bytes.Buffer
+	log.SetOutput(&out)
+	defer log.SetOutput(os.Stderr)
+
+	ruleset := &RuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{Rules: []tflint.Rule{NewTerraformKb4FileStructureRule()}}}
+	if err := ruleset.ApplyGlobalConfig(&tflint.Config{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ruleset.Check(helper.TestRunner(t, map[string]string{"main.tf": `variable "name" {}`})); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	for _, pattern := range []string{
+		`\[DEBUG\] kb4: check rule=terraform_kb4_file_structure check=files duration=\S+ issues=3`,
+		`\[DEBUG\] kb4: check rule=terraform_kb4_file_structure check=variables duration=\S+ issues=1`,
+		`\[DEBUG\] kb4: rule rule=terraform_kb4_file_structure duration=\S+ issues=4 failed=false`,
+		`\[DEBUG\] kb4: checked rules=1 duration=\S+ issues=4 duplicates=0 host_requests=7 cache_hits=1`,
+	} {
+		if !regexp.MustCompile(pattern).Match(out.Bytes()) {
+			t.Errorf("Expected a log line matching %q in:\n%s", pattern, out.String())
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Manifest is the machine-readable listing of a ruleset printed by `-print-rules`.
+// Platform tooling consumes it to track rule coverage across repos, so fields are only ever added.
+type Manifest struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// TFLintVersion is the range of tflint versions that can load the plugin
+	TFLintVersion string        `json:"tflint_version"`
+	Config        []configField `json:"config"`
+	// RuleConfig lists the options every rule accepts on top of its own
+	RuleConfig []configField  `json:"rule_config"`
+	Rules      []RuleManifest `json:"rules"`
+}
+
+// RuleManifest describes a single rule in the Manifest
+type RuleManifest struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Categories  []string      `json:"categories"`
+	Severity    string        `json:"severity"`
+	Enabled     bool          `json:"enabled"`
+	Link        string        `json:"link"`
+	Aliases     []string      `json:"aliases,omitempty"`
+	Config      []configField `json:"config"`
+}
+
+// NewManifest builds the manifest of every rule in the ruleset
+func NewManifest(ruleset *RuleSet) *Manifest {
+	manifest := &Manifest{
+		Name:          ruleset.RuleSetName(),
+		Version:       strings.TrimSpace(ruleset.RuleSetVersion()),
+		TFLintVersion: TFLintVersionConstraint,
+		Config:        configFields(DefaultConfig()),
+		RuleConfig:    configFields(&CommonRuleConfig{}),
+		Rules:         []RuleManifest{},
+	}
+
+	for _, rule := range ruleset.Rules {
+		manifest.Rules = append(manifest.Rules, newRuleManifest(rule))
+	}
+
+	return manifest
+}
+
+func newRuleManifest(rule tflint.Rule) RuleManifest {
+	metadata := ruleMetadata(rule)
+
+	config := configFields(metadata.Config)
+	if config == nil {
+		config = []configField{}
+	}
+
+	return RuleManifest{
+		Name:        rule.Name(),
+		Description: metadata.Description,
+		Categories:  metadata.Categories,
+		Severity:    strings.ToUpper(rule.Severity().String()),
+		Enabled:     rule.Enabled(),
+		Link:        rule.Link(),
+		Aliases:     metadata.Aliases,
+		Config:      config,
+	}
+}
@@ -0,0 +1,51 @@
+package rules
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_NewManifest(t *testing.T) {
+	ruleset := &RuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{
+		Name:    "kb4",
+		Version: "1.2.3\n",
+		Rules:   []tflint.Rule{NewTerraformValidatedVariablesRule()},
+	}}
+
+	manifest := NewManifest(ruleset)
+
+	if manifest.Name != "kb4" || manifest.Version != "1.2.3" || manifest.TFLintVersion != TFLintVersionConstraint {
+		t.Fatalf("Unexpected ruleset identity: %s %s %s", manifest.Name, manifest.Version, manifest.TFLintVersion)
+	}
+	if len(manifest.Config) != len(configFields(DefaultConfig())) {
+		t.Fatalf("Expected the plugin config schema, got %#v", manifest.Config)
+	}
+	if len(manifest.Rules) != 1 {
+		t.Fatalf("Expected 1 rule, got %d", len(manifest.Rules))
+	}
+
+	rule := manifest.Rules[0]
+	if rule.Name != "terraform_validated_variables" || rule.Severity != "ERROR" || !rule.Enabled || rule.Description == "" {
+		t.Fatalf("Unexpected rule manifest: %#v", rule)
+	}
+
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	// Rules without options still list an empty config so consumers don't need null checks
+	var decoded struct {
+		Rules []struct {
+			Config []interface{} `json:"config"`
+		} `json:"rules"`
+	}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if decoded.Rules[0].Config == nil {
+		t.Fatalf("Expected an empty config list, got %s", raw)
+	}
+}
@@ -0,0 +1,53 @@
+package rules
+
+import (
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Rule categories, used to enable or disable groups of rules from the plugin block
+const (
+	CategoryStructure = "structure"
+	CategoryNaming    = "naming"
+	CategorySecurity  = "security"
+	CategoryCost      = "cost"
+	CategoryStyle     = "style"
+)
+
+// Categories lists every rule category
+var Categories = []string{CategoryStructure, CategoryNaming, CategorySecurity, CategoryCost, CategoryStyle}
+
+// Metadata is the structured description every rule returns from its Metadata() method.
+// The generated docs under docs/rules are rendered from it.
+type Metadata struct {
+	// Description is a short summary of what the rule enforces
+	Description string
+	// Categories are the groups the rule belongs to, from Categories
+	Categories []string
+	// Anchor is the section of the style guide the rule enforces, without the #.
+	// The rule links to it on the configured style guide, so the guide can move without touching the rule.
+	Anchor string
+	// Aliases are former names of the rule. Rule blocks and exemption annotations using them keep working,
+	// and the ruleset logs a deprecation warning for rule blocks.
+	Aliases []string
+	// Example is Terraform configuration the rule reports on
+	Example string
+	// ConfigExample is a rule block shown in place of the generated one, for rules configured with nested blocks
+	ConfigExample string
+	// Config is the rule's config struct populated with its defaults, or nil when the rule takes no options.
+	// Fields document themselves with a `doc` struct tag.
+	Config interface{}
+}
+
+// ruleMetadata returns the rule's Metadata, or an empty one for rules that don't provide it
+func ruleMetadata(rule tflint.Rule) *Metadata {
+	if metadata, ok := rule.Metadata().(*Metadata); ok && metadata != nil {
+		return metadata
+	}
+	return &Metadata{}
+}
+
+// styleGuideLink returns the link to the rule's Anchor in the default style guide, for rules to return from Link().
+// The ruleset rebases it onto the style guide in its config when it reports an issue.
+func styleGuideLink(rule tflint.Rule) string {
+	return DefaultConfig().styleGuideLink(ruleMetadata(rule).Anchor)
+}
@@ -0,0 +1,72 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+)
+
+// Metrics is the summary of a run written to the metrics file, for dashboards tracking style guide adoption across repos
+type Metrics struct {
+	RuleSet    string                 `json:"ruleset"`
+	Version    string                 `json:"version"`
+	DurationMs float64                `json:"duration_ms"`
+	Issues     int                    `json:"issues"`
+	Baselined  int                    `json:"baselined"`
+	Rules      map[string]RuleMetrics `json:"rules"`
+	Files      map[string]int         `json:"files"`
+}
+
+// RuleMetrics is the part of the summary for a single rule that ran
+type RuleMetrics struct {
+	Issues     int     `json:"issues"`
+	DurationMs float64 `json:"duration_ms"`
+}
+
+// metrics summarizes the run so far. Every rule that ran is listed, including the ones that reported nothing,
+// and only issues sent to the host are counted, so baselined, duplicate and out of scope issues are left out.
+func (r *Runner) metrics(version string, duration time.Duration) *Metrics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	metrics := &Metrics{
+		RuleSet:    RuleSetName,
+		Version:    version,
+		DurationMs: milliseconds(duration),
+		Baselined:  r.stats.baselined,
+		Rules:      map[string]RuleMetrics{},
+		Files:      map[string]int{},
+	}
+
+	for name, duration := range r.stats.durations {
+		metrics.Rules[name] = RuleMetrics{Issues: r.stats.issues[name], DurationMs: milliseconds(duration)}
+	}
+	for _, count := range r.stats.issues {
+		metrics.Issues += count
+	}
+	for filename, count := range r.stats.files {
+		metrics.Files[filepath.ToSlash(filename)] += count
+	}
+
+	return metrics
+}
+
+// Write saves the summary. Maps are written with sorted keys, so runs can be diffed.
+func (m *Metrics) Write(path string) error {
+	raw, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(path, append(raw, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write metrics: %s", err)
+	}
+	return nil
+}
+
+// milliseconds returns the duration in fractional milliseconds, as most rules take well under one
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
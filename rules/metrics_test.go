@@ -0,0 +1,60 @@
+package rules
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_RuleSet_Check_metrics(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	config := DefaultConfig()
+	config.MetricsFile = path
+
+	ruleset := &RuleSet{
+		BuiltinRuleSet: tflint.BuiltinRuleSet{
+			Name:    RuleSetName,
+			Version: "1.2.3",
+			Rules:   []tflint.Rule{NewTerraformKb4FileStructureRule(), NewKb4IamAccessKeysRule()},
+		},
+		config: config,
+	}
+	if err := ruleset.ApplyGlobalConfig(&tflint.Config{}); err != nil {
+		t.Fatal(err)
+	}
+
+	runner := helper.TestRunner(t, map[string]string{
+		"main.tf": `
+variable "name" {}
+output "name" {}`,
+	})
+	if err := ruleset.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	var metrics Metrics
+	if err := json.Unmarshal(raw, &metrics); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	if metrics.RuleSet != "kb4" || metrics.Version != "1.2.3" || metrics.Issues != len(runner.Issues) || metrics.Issues != 5 {
+		t.Fatalf("Unexpected summary: %s", raw)
+	}
+	if len(metrics.Rules) != 2 || metrics.Rules["terraform_kb4_file_structure"].Issues != 5 {
+		t.Fatalf("Unexpected rule metrics: %s", raw)
+	}
+	if rule, ok := metrics.Rules["kb4_iam_access_keys"]; !ok || rule.Issues != 0 {
+		t.Fatalf("Expected a rule without issues to be listed: %s", raw)
+	}
+	if len(metrics.Files) != 4 || metrics.Files["main.tf"] != 2 || metrics.Files["_init.tf"] != 1 {
+		t.Fatalf("Unexpected file metrics: %s", raw)
+	}
+}
@@ -0,0 +1,274 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// moduleRegistryClient queries module registries for the versions of a module
+var moduleRegistryClient = &http.Client{Timeout: 10 * time.Second}
+
+// moduleGitTimeout bounds how long listing the tags of a git remote may take, like the registry client's timeout
+const moduleGitTimeout = 10 * time.Second
+
+// moduleGitTags lists the tags of a git remote. Tests swap it out to avoid the network.
+// Git never prompts for credentials, so a remote that needs them fails instead of hanging the run.
+var moduleGitTags = func(remote string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), moduleGitTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "ls-remote", "--tags", "--refs", remote)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_SSH_COMMAND=ssh -o BatchMode=yes")
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("failed to list tags of %s: timed out after %s", remote, moduleGitTimeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags of %s: %s", remote, err)
+	}
+
+	tags := []string{}
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			tags = append(tags, strings.TrimPrefix(fields[1], "refs/tags/"))
+		}
+	}
+	return tags, nil
+}
+
+// registrySourcePattern matches a registry module source, e.g. app.terraform.io/knowbe4/vpc/aws.
+// The host is optional and defaults to the public registry.
+var registrySourcePattern = regexp.MustCompile(`^(?:([0-9A-Za-z.-]+\.[0-9A-Za-z-]+(?::[0-9]+)?|[0-9A-Za-z-]+:[0-9]+)/)?([0-9A-Za-z_-]+)/([0-9A-Za-z_-]+)/([0-9a-z]+)(?://.*)?$`)
+
+// moduleSource is a module source whose released versions can be looked up, in a registry or as git tags
+type moduleSource struct {
+	// Host, Namespace, Name and Provider address a registry module
+	Host, Namespace, Name, Provider string
+	// Remote is the git remote of a git module, and Ref the ref the source pins
+	Remote, Ref string
+}
+
+// String returns the registry address or git remote the versions are looked up in
+func (s moduleSource) String() string {
+	if s.Remote != "" {
+		return s.Remote
+	}
+	return strings.Join([]string{s.Host, s.Namespace, s.Name, s.Provider}, "/")
+}
+
+// parseModuleSource recognizes registry sources and git sources with a ref, either git:: sources or the github.com shorthand.
+// Local paths and the other source types have no versions to look up.
+func parseModuleSource(source string) (moduleSource, bool) {
+	if strings.HasPrefix(source, "git::") || strings.HasPrefix(source, "github.com/") {
+		remote, ref := parseGitSource(source)
+		return moduleSource{Remote: remote, Ref: ref}, ref != ""
+	}
+
+	match := registrySourcePattern.FindStringSubmatch(source)
+	if match == nil || match[1] == "github.com" || match[1] == "bitbucket.org" {
+		return moduleSource{}, false
+	}
+
+	host := match[1]
+	if host == "" {
+		host = "registry.terraform.io"
+	}
+	return moduleSource{Host: host, Namespace: match[2], Name: match[3], Provider: match[4]}, true
+}
+
+// parseGitSource splits a git source into its remote, without the subdirectory, and the ref it pins
+func parseGitSource(source string) (remote, ref string) {
+	remote = strings.TrimPrefix(source, "git::")
+	if strings.HasPrefix(remote, "github.com/") {
+		remote = "https://" + remote
+	}
+
+	if i := strings.Index(remote, "?"); i >= 0 {
+		if query, err := url.ParseQuery(remote[i+1:]); err == nil {
+			ref = query.Get("ref")
+		}
+		remote = remote[:i]
+	}
+
+	start := 0
+	if i := strings.Index(remote, "://"); i >= 0 {
+		start = i + len("://")
+	}
+	if i := strings.Index(remote[start:], "//"); i >= 0 {
+		remote = remote[:start+i]
+	}
+
+	return remote, ref
+}
+
+// moduleVersions returns every version released for the source: the registry's versions, or the git remote's tags
+func moduleVersions(source moduleSource) ([]string, error) {
+	if source.Remote != "" {
+		return moduleGitTags(source.Remote)
+	}
+	return registryModuleVersions(source)
+}
+
+// registryModuleVersions asks the source's registry for its versions with the module registry protocol.
+// The registry is found by service discovery, and a TF_TOKEN_<host> credential is sent the way Terraform sends it.
+func registryModuleVersions(source moduleSource) ([]string, error) {
+	base, err := discoverModulesService(source.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := base.Parse(fmt.Sprintf("%s/%s/%s/versions", source.Namespace, source.Name, source.Provider))
+	if err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		Modules []struct {
+			Versions []struct {
+				Version string `json:"version"`
+			} `json:"versions"`
+		} `json:"modules"`
+	}
+	if err := getRegistryJSON(source.Host, endpoint.String(), &body); err != nil {
+		return nil, err
+	}
+
+	versions := []string{}
+	for _, module := range body.Modules {
+		for _, version := range module.Versions {
+			versions = append(versions, version.Version)
+		}
+	}
+	return versions, nil
+}
+
+// discoverModulesService returns the base URL of the host's modules.v1 service
+func discoverModulesService(host string) (*url.URL, error) {
+	root := &url.URL{Scheme: "https", Host: host, Path: "/"}
+
+	var services struct {
+		Modules string `json:"modules.v1"`
+	}
+	if err := getRegistryJSON(host, root.String()+".well-known/terraform.json", &services); err != nil {
+		return nil, err
+	}
+	if services.Modules == "" {
+		return nil, fmt.Errorf("%s does not serve a module registry", host)
+	}
+
+	base, err := root.Parse(services.Modules)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	return base, nil
+}
+
+// getRegistryJSON fetches and decodes a registry response
+func getRegistryJSON(host, target string, into interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	if token := registryToken(host); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := moduleRegistryClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query %s: %s", target, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to query %s: %s", target, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(into); err != nil {
+		return fmt.Errorf("failed to decode %s: %s", target, err)
+	}
+	return nil
+}
+
+// registryToken returns the TF_TOKEN_ credential for the host, named the way Terraform names it:
+// dots become underscores and dashes double underscores, e.g. TF_TOKEN_app_terraform_io
+func registryToken(host string) string {
+	name := strings.NewReplacer(".", "_", "-", "__").Replace(host)
+	return os.Getenv("TF_TOKEN_" + name)
+}
+
+// moduleVersion is a parsed semantic version
+type moduleVersion struct {
+	Major, Minor, Patch int
+	Prerelease          string
+}
+
+// parseModuleVersion parses a semantic version, with or without a leading v as git tags often have
+func parseModuleVersion(raw string) (moduleVersion, bool) {
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), "v")
+	match := semverPattern.FindStringSubmatch(raw)
+	if match == nil {
+		return moduleVersion{}, false
+	}
+
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	patch, _ := strconv.Atoi(match[3])
+	return moduleVersion{Major: major, Minor: minor, Patch: patch, Prerelease: strings.TrimPrefix(match[4], "-")}, true
+}
+
+// newerThan reports whether v is a newer version than other. A prerelease is older than its release,
+// and prereleases of the same version are ordered by their identifiers as strings.
+func (v moduleVersion) newerThan(other moduleVersion) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+	if v.Patch != other.Patch {
+		return v.Patch > other.Patch
+	}
+	if v.Prerelease == "" || other.Prerelease == "" {
+		return v.Prerelease == "" && other.Prerelease != ""
+	}
+	return v.Prerelease > other.Prerelease
+}
+
+// String returns the version without a leading v
+func (v moduleVersion) String() string {
+	version := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		version += "-" + v.Prerelease
+	}
+	return version
+}
+
+// releasesSince returns the releases newer than pinned, newest first. Prereleases and tags that aren't versions are ignored.
+func releasesSince(pinned moduleVersion, versions []string) []moduleVersion {
+	seen := map[moduleVersion]bool{}
+	newer := []moduleVersion{}
+	for _, raw := range versions {
+		version, ok := parseModuleVersion(raw)
+		if !ok || version.Prerelease != "" || seen[version] || !version.newerThan(pinned) {
+			continue
+		}
+		seen[version] = true
+		newer = append(newer, version)
+	}
+
+	sort.Slice(newer, func(i, j int) bool { return newer[i].newerThan(newer[j]) })
+	return newer
+}
@@ -0,0 +1,100 @@
+package rules
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseModuleSource(t *testing.T) {
+	cases := []struct {
+		Source   string
+		Expected moduleSource
+		Ok       bool
+	}{
+		{Source: "app.terraform.io/knowbe4/vpc/aws", Expected: moduleSource{Host: "app.terraform.io", Namespace: "knowbe4", Name: "vpc", Provider: "aws"}, Ok: true},
+		{Source: "terraform-aws-modules/vpc/aws//modules/vpc-endpoints", Expected: moduleSource{Host: "registry.terraform.io", Namespace: "terraform-aws-modules", Name: "vpc", Provider: "aws"}, Ok: true},
+		{Source: "localhost:8443/knowbe4/vpc/aws", Expected: moduleSource{Host: "localhost:8443", Namespace: "knowbe4", Name: "vpc", Provider: "aws"}, Ok: true},
+		{Source: "git::https://github.com/knowbe4/terraform-aws-vpc.git//modules/subnets?ref=v1.2.0", Expected: moduleSource{Remote: "https://github.com/knowbe4/terraform-aws-vpc.git", Ref: "v1.2.0"}, Ok: true},
+		{Source: "git::ssh://git@github.com/knowbe4/terraform-aws-vpc.git?ref=1.2.0", Expected: moduleSource{Remote: "ssh://git@github.com/knowbe4/terraform-aws-vpc.git", Ref: "1.2.0"}, Ok: true},
+		{Source: "git::git@github.com:knowbe4/terraform-aws-vpc.git?depth=1&ref=v2.0.0", Expected: moduleSource{Remote: "git@github.com:knowbe4/terraform-aws-vpc.git", Ref: "v2.0.0"}, Ok: true},
+		{Source: "github.com/knowbe4/terraform-aws-vpc?ref=v1.0.0", Expected: moduleSource{Remote: "https://github.com/knowbe4/terraform-aws-vpc", Ref: "v1.0.0"}, Ok: true},
+		{Source: "git::https://github.com/knowbe4/terraform-aws-vpc.git", Expected: moduleSource{Remote: "https://github.com/knowbe4/terraform-aws-vpc.git"}, Ok: false},
+		{Source: "github.com/knowbe4/terraform-aws-vpc/aws", Ok: false},
+		{Source: "./modules/vpc", Ok: false},
+		{Source: "s3::https://s3.amazonaws.com/kb4-modules/vpc.zip", Ok: false},
+	}
+
+	for _, tc := range cases {
+		got, ok := parseModuleSource(tc.Source)
+		if ok != tc.Ok {
+			t.Errorf("parseModuleSource(%q) ok = %t, expected %t", tc.Source, ok, tc.Ok)
+			continue
+		}
+		if ok && got != tc.Expected {
+			t.Errorf("parseModuleSource(%q) = %+v, expected %+v", tc.Source, got, tc.Expected)
+		}
+	}
+}
+
+func Test_releasesSince(t *testing.T) {
+	pinned, _ := parseModuleVersion("v1.2.0")
+	versions := []string{"v1.1.0", "v1.2.0", "v1.2.1", "v1.3.0-rc1", "v1.10.0", "1.10.0", "v2.0.0", "latest"}
+
+	got := []string{}
+	for _, version := range releasesSince(pinned, versions) {
+		got = append(got, version.String())
+	}
+
+	if diff := cmp.Diff([]string{"2.0.0", "1.10.0", "1.2.1"}, got); diff != "" {
+		t.Fatalf("Unexpected releases: %s", diff)
+	}
+}
+
+func Test_registryModuleVersions(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/terraform.json":
+			w.Write([]byte(`{"modules.v1": "/api/registry/v1/modules"}`))
+		case "/api/registry/v1/modules/knowbe4/vpc/aws/versions":
+			w.Write([]byte(`{"modules": [{"versions": [{"version": "1.0.0"}, {"version": "1.1.0"}]}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := moduleRegistryClient
+	moduleRegistryClient = server.Client()
+	defer func() { moduleRegistryClient = client }()
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	versions, err := registryModuleVersions(moduleSource{Host: host, Namespace: "knowbe4", Name: "vpc", Provider: "aws"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if diff := cmp.Diff([]string{"1.0.0", "1.1.0"}, versions); diff != "" {
+		t.Fatalf("Unexpected versions: %s", diff)
+	}
+
+	_, err = registryModuleVersions(moduleSource{Host: host, Namespace: "knowbe4", Name: "missing", Provider: "aws"})
+	if expected := "failed to query " + server.URL + "/api/registry/v1/modules/knowbe4/missing/aws/versions: 404 Not Found"; err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
+
+func Test_registryToken(t *testing.T) {
+	os.Setenv("TF_TOKEN_terraform__registry_example_com", "secret")
+	defer os.Unsetenv("TF_TOKEN_terraform__registry_example_com")
+
+	if got := registryToken("terraform-registry.example.com"); got != "secret" {
+		t.Fatalf("Expected the TF_TOKEN_ credential, got %q", got)
+	}
+	if got := registryToken("app.terraform.io"); got != "" {
+		t.Fatalf("Expected no credential, got %q", got)
+	}
+}
@@ -0,0 +1,180 @@
+package rules
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// isRootModule reports whether the module being checked is a root module, i.e. one that configures a backend or Terraform Cloud,
+// or configures a provider, which roots using local state or a backend passed to `terraform init` still do.
+// The plugin SDK doesn't say how a module is used, and child modules can't keep state and take their providers from the caller,
+// so modules with neither are treated as children. Provider blocks setting only alias are how children declare the providers they take.
+func isRootModule(runner tflint.Runner) (bool, error) {
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "terraform",
+				Body: &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{
+						{Type: "backend", LabelNames: []string{"type"}},
+						{Type: "cloud"},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return false, err
+	}
+
+	for _, block := range content.Blocks {
+		if len(block.Body.Blocks) > 0 {
+			return true, nil
+		}
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return false, err
+	}
+	for _, file := range files {
+		providers, _, _ := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "provider", LabelNames: []string{"name"}}},
+		})
+		for _, provider := range providers.Blocks {
+			if configuresProvider(provider.Body) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// configuresProvider reports whether a provider block sets anything besides alias
+func configuresProvider(body hcl.Body) bool {
+	if b, ok := body.(*hclsyntax.Body); ok {
+		if len(b.Blocks) > 0 {
+			return true
+		}
+		for name := range b.Attributes {
+			if name != "alias" {
+				return true
+			}
+		}
+		return false
+	}
+
+	// JSON bodies list nested blocks as attributes too
+	attrs, diags := body.JustAttributes()
+	if diags.HasErrors() {
+		return false
+	}
+	for name := range attrs {
+		if name != "alias" {
+			return true
+		}
+	}
+	return false
+}
+
+// installedModule is an entry of the module manifest `terraform init` writes to .terraform/modules/modules.json
+type installedModule struct {
+	Key    string `json:"Key"`
+	Source string `json:"Source"`
+	Dir    string `json:"Dir"`
+}
+
+// calledModuleDir returns the directory holding the source of the named module call.
+// Local sources are resolved against the calling file, and other sources are looked up in the module manifest,
+// so remote modules are only found once `terraform init` has installed them.
+func calledModuleDir(filename, name, source string) (string, bool) {
+	if strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") {
+		return filepath.Join(filepath.Dir(filename), source), true
+	}
+
+	dataDir := os.Getenv("TF_DATA_DIR")
+	if dataDir == "" {
+		dataDir = ".terraform"
+	}
+	raw, err := ioutil.ReadFile(filepath.Join(dataDir, "modules", "modules.json"))
+	if err != nil {
+		return "", false
+	}
+
+	var manifest struct {
+		Modules []installedModule `json:"Modules"`
+	}
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return "", false
+	}
+	for _, module := range manifest.Modules {
+		if module.Key == name {
+			return module.Dir, true
+		}
+	}
+	return "", false
+}
+
+// moduleVariableDefaults reads the variables of the module in dir and returns the defaults that are literal values.
+// Variables without a default, and defaults that can't be evaluated on their own, are left out.
+func moduleVariableDefaults(dir string) (map[string]cty.Value, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+	jsonFiles, err := filepath.Glob(filepath.Join(dir, "*.tf.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	parser := hclparse.NewParser()
+	defaults := map[string]cty.Value{}
+	for _, filename := range append(files, jsonFiles...) {
+		var file *hcl.File
+		var diags hcl.Diagnostics
+		if strings.HasSuffix(filename, ".json") {
+			file, diags = parser.ParseJSONFile(filename)
+		} else {
+			file, diags = parser.ParseHCLFile(filename)
+		}
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "variable", LabelNames: []string{"name"}}},
+		})
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		for _, variable := range content.Blocks {
+			attrs, _, diags := variable.Body.PartialContent(&hcl.BodySchema{
+				Attributes: []hcl.AttributeSchema{{Name: "default"}},
+			})
+			if diags.HasErrors() {
+				continue
+			}
+			attr, ok := attrs.Attributes["default"]
+			if !ok {
+				continue
+			}
+			if value, diags := attr.Expr.Value(nil); !diags.HasErrors() {
+				defaults[variable.Labels[0]] = value
+			}
+		}
+	}
+
+	return defaults, nil
+}
@@ -0,0 +1,89 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_isRootModule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected bool
+	}{
+		{
+			Name:     "no terraform block",
+			Content:  `resource "aws_sqs_queue" "this" {}`,
+			Expected: false,
+		},
+		{
+			Name: "no backend",
+			Content: `
+terraform {
+  required_version = ">= 1.0"
+}`,
+			Expected: false,
+		},
+		{
+			Name: "backend",
+			Content: `
+terraform {
+  backend "s3" {}
+}`,
+			Expected: true,
+		},
+		{
+			Name: "terraform cloud",
+			Content: `
+terraform {
+  cloud {
+    organization = "knowbe4"
+  }
+}`,
+			Expected: true,
+		},
+		{
+			Name: "provider configuration",
+			Content: `
+terraform {
+  required_version = ">= 1.0"
+}
+
+provider "aws" {
+  region = var.region
+}`,
+			Expected: true,
+		},
+		{
+			Name: "provider with only nested blocks",
+			Content: `
+provider "aws" {
+  assume_role {
+    role_arn = var.role_arn
+  }
+}`,
+			Expected: true,
+		},
+		{
+			Name: "provider alias only",
+			Content: `
+provider "aws" {
+  alias = "replica"
+}`,
+			Expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"_init.tf": tc.Content})
+
+		got, err := isRootModule(runner)
+		if err != nil {
+			t.Fatalf("%s: unexpected error occurred: %s", tc.Name, err)
+		}
+		if got != tc.Expected {
+			t.Errorf("%s: expected %t, got %t", tc.Name, tc.Expected, got)
+		}
+	}
+}
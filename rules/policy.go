@@ -0,0 +1,136 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"gopkg.in/yaml.v3"
+)
+
+// Policy is org-wide policy data that the SRE team maintains in one shared file,
+// so updating it doesn't require editing every repo's .tflint.hcl.
+// It is read from the plugin block's policy_file, which may also be a URL. YAML and JSON are both accepted.
+type Policy struct {
+	// RequiredTags are tag keys every taggable resource must carry
+	RequiredTags []string `yaml:"required_tags"`
+	// Naming maps a naming target, one of namingTargets, to the regular expression its names must match
+	Naming map[string]string `yaml:"naming"`
+	// ApprovedModuleSources are the module source prefixes that module blocks may use
+	ApprovedModuleSources []string `yaml:"approved_module_sources"`
+	// AllowedRuntimes maps a runtime kind, one of runtimeKinds, to the runtimes that may be deployed
+	AllowedRuntimes map[string][]string `yaml:"allowed_runtimes"`
+	// Environments are the canonical environment names, overriding the plugin block's environments
+	Environments []string `yaml:"environments"`
+	// ServiceUsers are the IAM user names approved for services that can't assume a role
+	ServiceUsers []string `yaml:"service_users"`
+	// InstanceTypes are the EC2 instance types, or glob patterns like "m6i.*", that may be deployed
+	InstanceTypes []string `yaml:"instance_types"`
+	// DeniedResourceTypes maps a resource type, or a glob pattern like "aws_lightsail_*", that may not be deployed to the approved alternative
+	DeniedResourceTypes map[string]string `yaml:"denied_resource_types"`
+}
+
+// NewPolicy returns an empty policy, which places no org-wide constraints
+func NewPolicy() *Policy {
+	return &Policy{
+		RequiredTags:          []string{},
+		Naming:                map[string]string{},
+		ApprovedModuleSources: []string{},
+		AllowedRuntimes:       map[string][]string{},
+		Environments:          []string{},
+		ServiceUsers:          []string{},
+		InstanceTypes:         []string{},
+		DeniedResourceTypes:   map[string]string{},
+	}
+}
+
+// namingTargets are the names the policy's naming patterns can apply to
+var namingTargets = []string{"aws_s3_bucket"}
+
+// runtimeKinds are the kinds of runtime the policy's allowed runtimes can apply to
+var runtimeKinds = []string{"lambda"}
+
+// LoadPolicy reads a policy from a local file or an https:// URL.
+// Remote policies are cached on disk and refetched once the cached copy is older than ttl.
+func LoadPolicy(path string, ttl time.Duration) (*Policy, error) {
+	if isRemotePolicy(path) {
+		return fetchPolicy(path, ttl)
+	}
+
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %s", err)
+	}
+
+	policy, err := ParsePolicy(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy %s: %s", path, err)
+	}
+	return policy, nil
+}
+
+// ParsePolicy decodes a YAML or JSON policy document. Unknown keys are errors so typos don't silently disable policy.
+func ParsePolicy(raw []byte) (*Policy, error) {
+	policy := NewPolicy()
+
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(policy); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// Validate checks the policy for values that decode fine but can't be used
+func (p *Policy) Validate() error {
+	for target, pattern := range p.Naming {
+		if !containsString(namingTargets, target) {
+			return fmt.Errorf("naming target %q must be one of %s", target, strings.Join(namingTargets, ", "))
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("naming pattern for %q is invalid: %s", target, err)
+		}
+	}
+	for kind := range p.AllowedRuntimes {
+		if !containsString(runtimeKinds, kind) {
+			return fmt.Errorf("runtime kind %q must be one of %s", kind, strings.Join(runtimeKinds, ", "))
+		}
+	}
+	for _, pattern := range p.InstanceTypes {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("instance type pattern %q is invalid: %s", pattern, err)
+		}
+	}
+	for pattern := range p.DeniedResourceTypes {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("denied resource type pattern %q is invalid: %s", pattern, err)
+		}
+	}
+	return nil
+}
+
+// orgPolicy returns the policy carried by the runner, or an empty policy when none is configured
+func orgPolicy(runner tflint.Runner) *Policy {
+	if policy := ruleSetConfig(runner).policy; policy != nil {
+		return policy
+	}
+	return NewPolicy()
+}
+
+// environments returns the canonical environment names: the org policy's when it lists any, otherwise the plugin block's
+func environments(runner tflint.Runner) []string {
+	if envs := orgPolicy(runner).Environments; len(envs) > 0 {
+		return envs
+	}
+	return ruleSetConfig(runner).Environments
+}
@@ -0,0 +1,125 @@
+package rules
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PolicyCacheDirEnv is the environment variable that overrides where fetched policies are cached.
+// The default is a directory under the user cache dir.
+const PolicyCacheDirEnv = "KB4_POLICY_CACHE_DIR"
+
+// policyHTTPClient fetches remote policies
+var policyHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// isRemotePolicy reports whether the policy source is a URL rather than a local path
+func isRemotePolicy(source string) bool {
+	return strings.Contains(source, "://")
+}
+
+// policyURL returns the HTTPS URL a remote policy is fetched from.
+// Policies are fetched without credentials, so s3:// URLs, which would need signed requests, aren't supported.
+func policyURL(source string) (string, error) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme != "https" {
+		return "", fmt.Errorf("%q must be a local path or an https:// URL", source)
+	}
+	return u.String(), nil
+}
+
+// fetchPolicy returns the policy at source, served from the on-disk cache while the cached copy is younger than ttl.
+// When the fetch fails, a stale cached copy is used instead so an outage of the policy host doesn't fail every run.
+func fetchPolicy(source string, ttl time.Duration) (*Policy, error) {
+	cache, err := policyCachePath(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if info, err := os.Stat(cache); err == nil && time.Since(info.ModTime()) < ttl {
+		if raw, err := ioutil.ReadFile(cache); err == nil {
+			if policy, err := ParsePolicy(raw); err == nil {
+				return policy, nil
+			}
+		}
+	}
+
+	raw, fetchErr := downloadPolicy(source)
+	if fetchErr != nil {
+		raw, err := ioutil.ReadFile(cache)
+		if err != nil {
+			return nil, fetchErr
+		}
+
+		logWarn("%s, using the cached copy", fetchErr)
+		policy, err := ParsePolicy(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cached policy %s: %s", source, err)
+		}
+		return policy, nil
+	}
+
+	policy, err := ParsePolicy(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy %s: %s", source, err)
+	}
+
+	// A cache that can't be written only costs a fetch on the next run
+	if err := os.MkdirAll(filepath.Dir(cache), 0755); err != nil {
+		logWarn("failed to cache policy %s: %s", source, err)
+	} else if err := ioutil.WriteFile(cache, raw, 0644); err != nil {
+		logWarn("failed to cache policy %s: %s", source, err)
+	}
+
+	return policy, nil
+}
+
+// downloadPolicy fetches the raw policy document
+func downloadPolicy(source string) ([]byte, error) {
+	target, err := policyURL(source)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := policyHTTPClient.Get(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch policy %s: %s", source, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch policy %s: %s", source, resp.Status)
+	}
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch policy %s: %s", source, err)
+	}
+	return raw, nil
+}
+
+// policyCachePath returns the cache file for a remote policy, one per source URL
+func policyCachePath(source string) (string, error) {
+	dir := os.Getenv(PolicyCacheDirEnv)
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate the policy cache: %s", err)
+		}
+		dir = filepath.Join(base, "tflint-ruleset-kb4", "policy")
+	}
+
+	sum := sha256.Sum256([]byte(source))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".policy"), nil
+}
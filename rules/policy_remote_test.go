@@ -0,0 +1,92 @@
+package rules
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func Test_LoadPolicy_remote(t *testing.T) {
+	os.Setenv(PolicyCacheDirEnv, t.TempDir())
+	defer os.Unsetenv(PolicyCacheDirEnv)
+
+	requests := 0
+	healthy := true
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("required_tags: [Team]\n"))
+	}))
+	defer server.Close()
+
+	client := policyHTTPClient
+	policyHTTPClient = server.Client()
+	defer func() { policyHTTPClient = client }()
+
+	source := server.URL + "/terraform.yaml"
+	load := func(ttl time.Duration) *Policy {
+		t.Helper()
+		policy, err := LoadPolicy(source, ttl)
+		if err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+		if len(policy.RequiredTags) != 1 || policy.RequiredTags[0] != "Team" {
+			t.Fatalf("Unexpected policy: %#v", policy)
+		}
+		return policy
+	}
+
+	load(time.Hour)
+	load(time.Hour)
+	if requests != 1 {
+		t.Fatalf("Expected the second load to be served from the cache, got %d requests", requests)
+	}
+
+	load(0)
+	if requests != 2 {
+		t.Fatalf("Expected an expired cache to be refetched, got %d requests", requests)
+	}
+
+	// An unreachable policy host falls back to the stale cached copy
+	healthy = false
+	load(0)
+	if requests != 3 {
+		t.Fatalf("Expected a refetch attempt, got %d requests", requests)
+	}
+
+	// Without a cached copy the fetch failure is an error
+	_, err := LoadPolicy(server.URL+"/other.yaml", time.Hour)
+	if expected := "failed to fetch policy " + server.URL + "/other.yaml: 503 Service Unavailable"; err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
+
+func Test_policyURL(t *testing.T) {
+	cases := []struct {
+		Source   string
+		Expected string
+		Error    string
+	}{
+		{Source: "https://policy.example.com/terraform.yaml", Expected: "https://policy.example.com/terraform.yaml"},
+		{Source: "s3://kb4-policy/terraform/policy.yaml", Error: `"s3://kb4-policy/terraform/policy.yaml" must be a local path or an https:// URL`},
+		{Source: "http://policy.example.com/terraform.yaml", Error: `"http://policy.example.com/terraform.yaml" must be a local path or an https:// URL`},
+	}
+
+	for _, tc := range cases {
+		got, err := policyURL(tc.Source)
+		if tc.Error != "" {
+			if err == nil || err.Error() != tc.Error {
+				t.Errorf("policyURL(%q): expected error %q, got %v", tc.Source, tc.Error, err)
+			}
+			continue
+		}
+		if err != nil || got != tc.Expected {
+			t.Errorf("policyURL(%q) = %q, %v, expected %q", tc.Source, got, err, tc.Expected)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package rules
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_ParsePolicy(t *testing.T) {
+	expected := &Policy{
+		RequiredTags:          []string{"Team", "Service"},
+		Naming:                map[string]string{"aws_s3_bucket": "^knowbe4-"},
+		ApprovedModuleSources: []string{"app.terraform.io/knowbe4/"},
+		AllowedRuntimes:       map[string][]string{"lambda": {"python3.9", "nodejs16.x"}},
+		Environments:          []string{"dev", "prod"},
+		ServiceUsers:          []string{"ci-legacy"},
+		InstanceTypes:         []string{"m6i.*"},
+		DeniedResourceTypes:   map[string]string{"aws_lightsail_*": "use ECS"},
+	}
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected *Policy
+		Error    string
+	}{
+		{
+			Name: "yaml",
+			Content: `
+required_tags: [Team, Service]
+naming:
+  aws_s3_bucket: ^knowbe4-
+approved_module_sources:
+  - app.terraform.io/knowbe4/
+allowed_runtimes:
+  lambda: [python3.9, nodejs16.x]
+environments: [dev, prod]
+service_users: [ci-legacy]
+instance_types: [m6i.*]
+denied_resource_types:
+  aws_lightsail_*: use ECS
+`,
+			Expected: expected,
+		},
+		{
+			Name: "json",
+			Content: `{
+  "required_tags": ["Team", "Service"],
+  "naming": {"aws_s3_bucket": "^knowbe4-"},
+  "approved_module_sources": ["app.terraform.io/knowbe4/"],
+  "allowed_runtimes": {"lambda": ["python3.9", "nodejs16.x"]},
+  "environments": ["dev", "prod"],
+  "service_users": ["ci-legacy"],
+  "instance_types": ["m6i.*"],
+  "denied_resource_types": {"aws_lightsail_*": "use ECS"}
+}`,
+			Expected: expected,
+		},
+		{
+			Name:     "empty",
+			Content:  ``,
+			Expected: NewPolicy(),
+		},
+		{
+			Name:    "unknown key",
+			Content: `required_tag: [Team]`,
+			Error:   "yaml: unmarshal errors:\n  line 1: field required_tag not found in type rules.Policy",
+		},
+		{
+			Name: "invalid naming pattern",
+			Content: `
+naming:
+  aws_s3_bucket: "[knowbe4"`,
+			Error: "naming pattern for \"aws_s3_bucket\" is invalid: error parsing regexp: missing closing ]: `[knowbe4`",
+		},
+		{
+			Name: "unknown naming target",
+			Content: `
+naming:
+  aws_lambda_function: ^kb4-`,
+			Error: "naming target \"aws_lambda_function\" must be one of aws_s3_bucket",
+		},
+		{
+			Name: "unknown runtime kind",
+			Content: `
+allowed_runtimes:
+  ecs: [python3.9]`,
+			Error: "runtime kind \"ecs\" must be one of lambda",
+		},
+		{
+			Name:    "invalid instance type pattern",
+			Content: `instance_types: ["m6i.[large"]`,
+			Error:   "instance type pattern \"m6i.[large\" is invalid: syntax error in pattern",
+		},
+		{
+			Name:    "invalid denied resource type pattern",
+			Content: `denied_resource_types: {"aws_[lightsail": ""}`,
+			Error:   "denied resource type pattern \"aws_[lightsail\" is invalid: syntax error in pattern",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			policy, err := ParsePolicy([]byte(tc.Content))
+			if tc.Error != "" {
+				if err == nil || err.Error() != tc.Error {
+					t.Fatalf("Expected error %q, got %v", tc.Error, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.Expected, policy); diff != "" {
+				t.Fatalf("Unexpected policy: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_RuleSet_ApplyConfig_policyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := ioutil.WriteFile(path, []byte("required_tags: [Team]\nenvironments: [qa]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ruleset := &RuleSet{}
+	if err := ruleset.ApplyConfig(pluginContent(t, ruleset, `policy_file = "`+filepath.ToSlash(path)+`"`)); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	runner := NewRunner(helper.TestRunner(t, map[string]string{}), ruleset.config)
+	if tags := orgPolicy(runner).RequiredTags; len(tags) != 1 || tags[0] != "Team" {
+		t.Fatalf("Expected the policy's required tags, got %#v", tags)
+	}
+	if envs := environments(runner); len(envs) != 1 || envs[0] != "qa" {
+		t.Fatalf("Expected the policy's environments, got %#v", envs)
+	}
+
+	// Plain runners and configs without a policy file get an empty policy
+	var plain tflint.Runner = helper.TestRunner(t, map[string]string{})
+	if diff := cmp.Diff(NewPolicy(), orgPolicy(plain)); diff != "" {
+		t.Fatalf("Unexpected policy: %s", diff)
+	}
+	if diff := cmp.Diff(DefaultConfig().Environments, environments(plain)); diff != "" {
+		t.Fatalf("Unexpected environments: %s", diff)
+	}
+
+	err := ruleset.ApplyConfig(pluginContent(t, ruleset, `policy_file = "missing.yaml"`))
+	if err == nil || err.Error() != "failed to read policy: open missing.yaml: no such file or directory" {
+		t.Fatalf("Expected a missing policy error, got %v", err)
+	}
+}
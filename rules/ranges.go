@@ -0,0 +1,13 @@
+package rules
+
+import (
+	"github.com/hashicorp/hcl/v2"
+)
+
+// rangeLess orders ranges by file name, then by where they start, the order rules report issues in
+func rangeLess(a, b hcl.Range) bool {
+	if a.Filename != b.Filename {
+		return a.Filename < b.Filename
+	}
+	return a.Start.Byte < b.Start.Byte
+}
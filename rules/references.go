@@ -0,0 +1,52 @@
+package rules
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// references returns the references to attributes of the named root object, e.g. path.root for "path",
+// in every native syntax file of the module, sorted by file and position.
+// JSON files are skipped since their expressions are only known once their blocks are decoded.
+func references(runner tflint.Runner, root string) ([]hcl.Traversal, error) {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	found := []hcl.Traversal{}
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+			expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+			if ok && len(expr.Traversal) > 1 && expr.Traversal.RootName() == root {
+				found = append(found, expr.Traversal)
+			}
+			return nil
+		})
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return rangeLess(found[i].SourceRange(), found[j].SourceRange())
+	})
+
+	return found, nil
+}
+
+// traversalAttr returns the name of the attribute a reference reads from its root object, e.g. "workspace" for terraform.workspace
+func traversalAttr(traversal hcl.Traversal) string {
+	if len(traversal) < 2 {
+		return ""
+	}
+	if attr, ok := traversal[1].(hcl.TraverseAttr); ok {
+		return attr.Name
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_references(t *testing.T) {
+	runner := testRunner(t, map[string]string{
+		"b.tf": `
+locals {
+  config = file("${path.module}/config.json")
+}`,
+		"a.tf": `
+resource "aws_s3_object" "this" {
+  source = "${path.root}/files/${terraform.workspace}.json"
+  key    = path.cwd
+}`,
+		"c.tf.json": `{"locals": {"dir": "${path.root}"}}`,
+	})
+
+	found, err := references(runner, "path")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	got := []string{}
+	for _, traversal := range found {
+		got = append(got, traversal.SourceRange().Filename+":"+traversalAttr(traversal))
+	}
+
+	expected := []string{"a.tf:root", "a.tf:cwd", "b.tf:module"}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Fatalf("Unexpected references: %s", diff)
+	}
+}
@@ -0,0 +1,35 @@
+package rules
+
+// regionShortCodes are the org's short codes for the AWS regions it deploys to, which region-specific
+// provider aliases use, e.g. use1 for us-east-1. The order is the order defaults list them in.
+var regionShortCodes = []struct {
+	Region string
+	Alias  string
+}{
+	{"us-east-1", "use1"},
+	{"us-east-2", "use2"},
+	{"us-west-1", "usw1"},
+	{"us-west-2", "usw2"},
+	{"eu-west-1", "euw1"},
+	{"eu-central-1", "euc1"},
+	{"ap-southeast-1", "apse1"},
+	{"ap-southeast-2", "apse2"},
+}
+
+// regionAliases returns the region-specific aliases, in regionShortCodes order
+func regionAliases() []string {
+	aliases := make([]string, len(regionShortCodes))
+	for i, code := range regionShortCodes {
+		aliases[i] = code.Alias
+	}
+	return aliases
+}
+
+// regionAliasMap returns the region-specific alias of each region
+func regionAliasMap() map[string]string {
+	aliases := make(map[string]string, len(regionShortCodes))
+	for _, code := range regionShortCodes {
+		aliases[code.Region] = code.Alias
+	}
+	return aliases
+}
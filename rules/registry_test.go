@@ -0,0 +1,104 @@
+package rules
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+)
+
+// Test_RuleFilesRegister guards against rules that are written but never wired into the ruleset.
+// A rule file is any file declaring a type with Name, Severity and Check methods.
+func Test_RuleFilesRegister(t *testing.T) {
+	paths, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	ruleFiles := 0
+
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		methods := map[string]map[string]bool{}
+		registrations := 0
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+
+			if fn.Recv != nil {
+				recv := fn.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				if ident, ok := recv.(*ast.Ident); ok {
+					if methods[ident.Name] == nil {
+						methods[ident.Name] = map[string]bool{}
+					}
+					methods[ident.Name][fn.Name.Name] = true
+				}
+				continue
+			}
+
+			if fn.Name.Name != "init" {
+				continue
+			}
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok {
+					if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "registry" && sel.Sel.Name == "Register" {
+						registrations++
+					}
+				}
+				return true
+			})
+		}
+
+		rules := 0
+		for _, m := range methods {
+			if m["Name"] && m["Severity"] && m["Check"] {
+				rules++
+			}
+		}
+		ruleFiles += rules
+
+		if rules != registrations {
+			t.Errorf("%s declares %d rule(s) but registers %d", path, rules, registrations)
+		}
+		if rules > 1 {
+			t.Errorf("%s declares %d rules, expected one rule per file", path, rules)
+		}
+	}
+
+	if got := len(registry.Rules()); got != ruleFiles {
+		t.Errorf("registry has %d rules but %d rule files were found", got, ruleFiles)
+	}
+}
+
+func Test_RegisteredRulesHaveCategories(t *testing.T) {
+	for _, rule := range registry.Rules() {
+		categories := ruleMetadata(rule).Categories
+		if len(categories) == 0 {
+			t.Errorf("`%s` has no categories", rule.Name())
+		}
+		for _, category := range categories {
+			if !containsString(Categories, category) {
+				t.Errorf("`%s` has unknown category %q", rule.Name(), category)
+			}
+		}
+	}
+}
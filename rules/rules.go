@@ -0,0 +1,27 @@
+// Package rules implements the kb4 Terraform rules and the ruleset that serves them to tflint.
+//
+// The rules can also be embedded in other tools without the plugin protocol. All and ByName return the rules,
+// and every rule's Check accepts any tflint.Runner, so a tool only needs a Runner over its own parsed files.
+// Each rule reads its options from its rule block through Runner.DecodeRuleConfig into the exported config
+// struct documented on the rule, e.g. TerraformKb4FileStructureRuleConfig, with the defaults listed on its docs page.
+package rules
+
+import (
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// All returns every rule in the ruleset, in the order they are documented and run
+func All() []tflint.Rule {
+	return registry.Rules()
+}
+
+// ByName returns the rule with the given name or former name, and false when there is no such rule
+func ByName(name string) (tflint.Rule, bool) {
+	for _, rule := range registry.Rules() {
+		if rule.Name() == name || containsString(ruleMetadata(rule).Aliases, name) {
+			return rule, true
+		}
+	}
+	return nil, false
+}
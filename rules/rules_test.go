@@ -0,0 +1,42 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_ByName(t *testing.T) {
+	for _, rule := range All() {
+		found, ok := ByName(rule.Name())
+		if !ok || found != rule {
+			t.Errorf("ByName(%q) = %v, %t, expected the registered rule", rule.Name(), found, ok)
+		}
+	}
+
+	if rule, ok := ByName("kb4_no_such_rule"); ok || rule != nil {
+		t.Errorf("Expected no rule, got %v", rule)
+	}
+}
+
+func Test_Anchors(t *testing.T) {
+	config := DefaultConfig()
+	config.StyleGuideURL = "https://docs.example.com/style/"
+
+	for _, rule := range All() {
+		anchor := ruleMetadata(rule).Anchor
+		if anchor == "" {
+			if strings.HasPrefix(rule.Link(), DefaultStyleGuideURL) {
+				t.Errorf("%s links to the style guide without an Anchor in its metadata", rule.Name())
+			}
+			continue
+		}
+
+		if link := rule.Link(); link != DefaultStyleGuideURL+"#"+anchor {
+			t.Errorf("%s links to %q, expected its anchor on the default style guide", rule.Name(), link)
+		}
+		reported := &issueRule{Rule: rule, config: config}
+		if link := reported.Link(); link != "https://docs.example.com/style/#"+anchor {
+			t.Errorf("%s reports the link %q, expected its anchor on the configured style guide", rule.Name(), link)
+		}
+	}
+}
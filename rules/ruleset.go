@@ -0,0 +1,246 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// RuleSetName is the plugin name, as used in `plugin "kb4"` blocks
+const RuleSetName = "kb4"
+
+// TFLintVersionConstraint is the range of tflint versions that can load the plugin.
+// tflint 0.35 introduced the plugin protocol that tflint-plugin-sdk v0.10 speaks. The SDK can't enforce it,
+// so it is declared in the README and the rule manifest and pinned in CI.
+const TFLintVersionConstraint = ">= 0.35.0"
+
+// semverPattern matches a semantic version without a leading v
+var semverPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// NewRuleSet returns the kb4 ruleset serving every rule at the given version, the contents of the VERSION file.
+// It fails when the version isn't semver, e.g. when VERSION wasn't embedded, rather than serve a ruleset tflint can't pin.
+func NewRuleSet(version string) (*RuleSet, error) {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return nil, fmt.Errorf("ruleset version is empty; build the plugin from the repository root so VERSION is embedded")
+	}
+	if !semverPattern.MatchString(version) {
+		return nil, fmt.Errorf("ruleset version %q in VERSION is not a semantic version like 1.2.3", version)
+	}
+
+	return &RuleSet{
+		BuiltinRuleSet: tflint.BuiltinRuleSet{
+			Name:    RuleSetName,
+			Version: version,
+			Rules:   All(),
+		},
+	}, nil
+}
+
+// RuleSet is the kb4 ruleset. It behaves like tflint.BuiltinRuleSet, but hands every rule
+// a shared caching Runner so host round-trips are paid once per check instead of once per rule.
+type RuleSet struct {
+	tflint.BuiltinRuleSet
+
+	config       *Config
+	globalConfig *tflint.Config
+
+	// ruleBlocks are the names of the rule blocks the host reported, as written in .tflint.hcl
+	ruleBlocks map[string]bool
+}
+
+// RuleNames lists every rule name along with the rules' former names, so the host accepts rule blocks using either
+func (r *RuleSet) RuleNames() []string {
+	names := r.BuiltinRuleSet.RuleNames()
+	for _, rule := range r.Rules {
+		names = append(names, ruleMetadata(rule).Aliases...)
+	}
+	return names
+}
+
+// ApplyGlobalConfig enables rules from their rule blocks and defaults, and remembers which rules were configured explicitly.
+// A rule block using a former name of a rule configures that rule, with a deprecation warning.
+// The names of the blocks are kept so rules decode their options from the block that exists.
+func (r *RuleSet) ApplyGlobalConfig(config *tflint.Config) error {
+	r.ruleBlocks = map[string]bool{}
+	for name := range config.Rules {
+		r.ruleBlocks[name] = true
+	}
+	r.globalConfig = resolveRuleAliases(r.Rules, config)
+	return r.BuiltinRuleSet.ApplyGlobalConfig(r.globalConfig)
+}
+
+// resolveRuleAliases returns a copy of the config with rule blocks that use a former name moved to the rule's current name.
+// When a module configures both names, the block with the current name wins.
+func resolveRuleAliases(rules []tflint.Rule, config *tflint.Config) *tflint.Config {
+	resolved := &tflint.Config{Rules: map[string]*tflint.RuleConfig{}, DisabledByDefault: config.DisabledByDefault}
+	for name, rule := range config.Rules {
+		resolved.Rules[name] = rule
+	}
+
+	for _, rule := range rules {
+		for _, alias := range ruleMetadata(rule).Aliases {
+			cfg := config.Rules[alias]
+			if cfg == nil {
+				continue
+			}
+
+			if config.Rules[rule.Name()] != nil {
+				logWarn("rule `%s` has been renamed to `%s`, and the `%s` block is ignored in favor of the `%s` block; remove it from .tflint.hcl", alias, rule.Name(), alias, rule.Name())
+				continue
+			}
+			logWarn("rule `%s` has been renamed to `%s`; rename its block in .tflint.hcl", alias, rule.Name())
+			resolved.Rules[rule.Name()] = &tflint.RuleConfig{Name: rule.Name(), Enabled: cfg.Enabled}
+		}
+	}
+
+	return resolved
+}
+
+// ConfigSchema returns the schema of the plugin block
+func (r *RuleSet) ConfigSchema() *hclext.BodySchema {
+	return hclext.ImpliedBodySchema(&Config{})
+}
+
+// ApplyConfig reads the plugin block into the config shared by every rule
+func (r *RuleSet) ApplyConfig(content *hclext.BodyContent) error {
+	config := DefaultConfig()
+
+	if diags := hclext.DecodeBody(content, nil, config); diags.HasErrors() {
+		return diags
+	}
+
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid plugin config: %s", err)
+	}
+
+	if config.PolicyFile != "" {
+		policy, err := LoadPolicy(config.PolicyFile, config.policyCacheTTL())
+		if err != nil {
+			return err
+		}
+		config.policy = policy
+	}
+
+	r.config = config
+	return nil
+}
+
+// Check runs every enabled rule concurrently against a shared caching runner.
+// With a baseline configured, known issues are suppressed, or the baseline is regenerated
+// from every issue when KB4_UPDATE_BASELINE is set. With a metrics file configured, a summary of the run is written to it.
+func (r *RuleSet) Check(runner tflint.Runner) error {
+	wrapped := NewRunner(runner, r.config)
+	wrapped.version = strings.TrimSpace(r.RuleSetVersion())
+	wrapped.ruleBlocks = r.ruleBlocks
+	start := time.Now()
+
+	if err := r.checkWithBaseline(wrapped); err != nil {
+		return err
+	}
+
+	if path := wrapped.config.MetricsFile; path != "" {
+		return wrapped.metrics(r.RuleSetVersion(), time.Since(start)).Write(path)
+	}
+	return nil
+}
+
+// checkWithBaseline checks the rules, suppressing the baselined issues or recording a new baseline
+func (r *RuleSet) checkWithBaseline(wrapped *Runner) error {
+	path := wrapped.config.BaselineFile
+
+	if path == "" {
+		return r.checkRules(wrapped)
+	}
+
+	if baselineUpdateRequested() {
+		wrapped.recording = NewBaseline()
+		if err := r.checkRules(wrapped); err != nil {
+			return err
+		}
+		return wrapped.recording.Write(path)
+	}
+
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		return err
+	}
+	wrapped.baseline = baseline
+
+	return r.checkRules(wrapped)
+}
+
+// checkRules runs the selected rules concurrently over a shared snapshot of the module.
+// The files are fetched up front and module content is cached per schema, so rules read from memory
+// instead of queueing on the host. The reported error is the first failing rule's, in rule order.
+func (r *RuleSet) checkRules(runner *Runner) error {
+	if _, err := runner.GetFiles(); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	rules := r.selectedRules(runner.config)
+	errs := make([]error, len(rules))
+
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < runner.config.workers(len(rules)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				errs[i] = checkRule(runner, rules[i])
+			}
+		}()
+	}
+
+	for i := range rules {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	runner.mu.Lock()
+	issues := 0
+	for _, count := range runner.stats.issues {
+		issues += count
+	}
+	logDebug("checked", "rules", len(rules), "duration", time.Since(start), "issues", issues, "duplicates", runner.stats.duplicates, "host_requests", runner.stats.hostRequests, "cache_hits", runner.stats.cacheHits)
+	runner.mu.Unlock()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("Failed to check `%s` rule: %s", rules[i].Name(), err)
+		}
+	}
+	return nil
+}
+
+// selectedRules narrows the enabled rules down to the configured categories.
+// A rule with its own block in .tflint.hcl keeps the enabled state set there regardless of category.
+func (r *RuleSet) selectedRules(config *Config) []tflint.Rule {
+	rules := []tflint.Rule{}
+
+	for _, rule := range r.EnabledRules {
+		explicit := r.globalConfig != nil && r.globalConfig.Rules[rule.Name()] != nil
+		if explicit || config.selectsRule(rule) {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+// checkRule runs one rule and logs how long it took and how many issues it reported
+func checkRule(runner *Runner, rule tflint.Rule) error {
+	start := time.Now()
+	err := rule.Check(runner)
+	runner.recordDuration(rule, time.Since(start))
+	logDebug("rule", "rule", rule.Name(), "duration", time.Since(start), "issues", runner.issueCount(rule), "failed", err != nil)
+	return err
+}
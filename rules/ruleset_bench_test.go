@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// hostLatency stands in for the gRPC round-trip to tflint that every host request costs outside of tests
+const hostLatency = 2 * time.Millisecond
+
+// latencyRunner delays every host request by hostLatency
+type latencyRunner struct {
+	tflint.Runner
+}
+
+func (r *latencyRunner) GetFiles() (map[string]*hcl.File, error) {
+	time.Sleep(hostLatency)
+	return r.Runner.GetFiles()
+}
+
+func (r *latencyRunner) GetModuleContent(schema *hclext.BodySchema, option *tflint.GetModuleContentOption) (*hclext.BodyContent, error) {
+	time.Sleep(hostLatency)
+	return r.Runner.GetModuleContent(schema, option)
+}
+
+// DecodeRuleConfig answers like a host without any rule blocks in .tflint.hcl
+func (r *latencyRunner) DecodeRuleConfig(name string, ret interface{}) error {
+	time.Sleep(hostLatency)
+	return fmt.Errorf("rule `%s` not found", name)
+}
+
+// largeRoot parses a root module with the given number of files, each holding a few of every block type
+func largeRoot(b *testing.B, files int) map[string]*hcl.File {
+	parser := hclparse.NewParser()
+	root := map[string]*hcl.File{}
+
+	for i := 0; i < files; i++ {
+		var src strings.Builder
+		fmt.Fprintf(&src, "variable \"v%d\" {\n  type = string\n}\n\n", i)
+		fmt.Fprintf(&src, "output \"o%d\" {\n  value = aws_instance.i%d.id\n}\n\n", i, i)
+		fmt.Fprintf(&src, "locals {\n  l%d = \"x\"\n}\n\n", i)
+		fmt.Fprintf(&src, "resource \"aws_instance\" \"i%d\" {\n  ami = \"ami-%d\"\n}\n\n", i, i)
+		fmt.Fprintf(&src, "data \"aws_ami\" \"d%d\" {}\n", i)
+
+		name := fmt.Sprintf("file%03d.tf", i)
+		file, diags := parser.ParseHCL([]byte(src.String()), name)
+		if diags.HasErrors() {
+			b.Fatal(diags)
+		}
+		root[name] = file
+	}
+
+	return root
+}
+
+func BenchmarkRuleSet_Check(b *testing.B) {
+	root := largeRoot(b, 200)
+
+	for _, parallelism := range []int{1, 0} {
+		b.Run(fmt.Sprintf("parallelism=%d", parallelism), func(b *testing.B) {
+			config := DefaultConfig()
+			config.Parallelism = parallelism
+
+			ruleset := &RuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{Rules: All()}, config: config}
+			if err := ruleset.ApplyGlobalConfig(&tflint.Config{}); err != nil {
+				b.Fatal(err)
+			}
+
+			for i := 0; i < b.N; i++ {
+				host := helper.NewLocalRunner(map[string]*hcl.File{}, helper.Issues{})
+				for name, file := range root {
+					host.AddLocalFile(name, file)
+				}
+				runner := &latencyRunner{Runner: host}
+
+				if err := ruleset.Check(runner); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,402 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// emptyConfigHost answers for rule names without a block in .tflint.hcl with empty content instead of an error, as newer hosts do
+type emptyConfigHost struct {
+	*helper.Runner
+
+	blocks map[string]bool
+}
+
+func (h *emptyConfigHost) DecodeRuleConfig(name string, ret interface{}) error {
+	if !h.blocks[name] {
+		return nil
+	}
+	return h.Runner.DecodeRuleConfig(name, ret)
+}
+
+// configCaptureRule records the ruleset config it was checked with and emits one issue
+type configCaptureRule struct {
+	BaseRule
+
+	config *Config
+}
+
+func (r *configCaptureRule) Name() string              { return "config_capture" }
+func (r *configCaptureRule) Enabled() bool             { return true }
+func (r *configCaptureRule) Severity() tflint.Severity { return tflint.ERROR }
+func (r *configCaptureRule) Link() string              { return DefaultStyleGuideURL + "#anchor" }
+
+func (r *configCaptureRule) Check(runner tflint.Runner) error {
+	r.config = ruleSetConfig(runner)
+	return runner.EmitIssue(r, "captured", hcl.Range{Filename: "main.tf", Start: hcl.InitialPos})
+}
+
+// pluginContent decodes a plugin block body the same way the host does before calling ApplyConfig
+func pluginContent(t *testing.T, ruleset *RuleSet, src string) *hclext.BodyContent {
+	file, diags := hclparse.NewParser().ParseHCL([]byte(src), ".tflint.hcl")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	content, diags := hclext.Content(file.Body, ruleset.ConfigSchema())
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	return content
+}
+
+func Test_RuleSet_ApplyConfig(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected *Config
+		Link     string
+		Error    string
+	}{
+		{
+			Name:     "defaults",
+			Config:   ``,
+			Expected: DefaultConfig(),
+			Link:     DefaultStyleGuideURL + "#anchor",
+		},
+		{
+			Name: "overrides",
+			Config: `
+style_guide_url  = "https://docs.example.com/style/"
+organization     = "example"
+environments     = ["qa", "live"]
+default_tag_keys = ["Team"]`,
+			Expected: &Config{
+				StyleGuideURL:  "https://docs.example.com/style/",
+				Organization:   "example",
+				Environments:   []string{"qa", "live"},
+				DefaultTagKeys: []string{"Team"},
+			},
+			Link: "https://docs.example.com/style/#anchor",
+		},
+		{
+			Name:   "relative style guide url",
+			Config: `style_guide_url = "/style/"`,
+			Error:  `invalid plugin config: style_guide_url "/style/" is not an absolute URL`,
+		},
+		{
+			Name:   "no environments",
+			Config: `environments = []`,
+			Error:  "invalid plugin config: environments must list at least one environment",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			rule := &configCaptureRule{}
+			ruleset := &RuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{Rules: []tflint.Rule{rule}}}
+			if err := ruleset.ApplyGlobalConfig(&tflint.Config{}); err != nil {
+				t.Fatal(err)
+			}
+
+			err := ruleset.ApplyConfig(pluginContent(t, ruleset, tc.Config))
+			if tc.Error != "" {
+				if err == nil || err.Error() != tc.Error {
+					t.Fatalf("Expected error %q, got %v", tc.Error, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			runner := helper.TestRunner(t, map[string]string{})
+			if err := ruleset.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			if rule.config.StyleGuideURL != tc.Expected.StyleGuideURL ||
+				rule.config.Organization != tc.Expected.Organization ||
+				len(rule.config.Environments) != len(tc.Expected.Environments) ||
+				len(rule.config.DefaultTagKeys) != len(tc.Expected.DefaultTagKeys) {
+				t.Fatalf("Expected %#v, got %#v", tc.Expected, rule.config)
+			}
+
+			if len(runner.Issues) != 1 {
+				t.Fatalf("Expected 1 issue, got %d", len(runner.Issues))
+			}
+			if link := runner.Issues[0].Rule.Link(); link != tc.Link {
+				t.Fatalf("Expected link %q, got %q", tc.Link, link)
+			}
+		})
+	}
+}
+
+// categoryRule is a rule in the given categories that records whether it ran
+type categoryRule struct {
+	BaseRule
+
+	name       string
+	categories []string
+	ran        bool
+}
+
+func (r *categoryRule) Name() string              { return r.name }
+func (r *categoryRule) Enabled() bool             { return true }
+func (r *categoryRule) Severity() tflint.Severity { return tflint.ERROR }
+func (r *categoryRule) Metadata() interface{}     { return &Metadata{Categories: r.categories} }
+
+func (r *categoryRule) Check(runner tflint.Runner) error {
+	r.ran = true
+	return nil
+}
+
+func Test_RuleSet_categories(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   string
+		Rules    map[string]*tflint.RuleConfig
+		Expected []string
+	}{
+		{
+			Name:     "every category by default",
+			Config:   ``,
+			Expected: []string{"structure_rule", "security_rule", "security_style_rule"},
+		},
+		{
+			Name:     "security only",
+			Config:   `categories = ["security"]`,
+			Expected: []string{"security_rule", "security_style_rule"},
+		},
+		{
+			Name:     "everything except style",
+			Config:   `exclude_categories = ["style"]`,
+			Expected: []string{"structure_rule", "security_rule"},
+		},
+		{
+			Name:     "explicitly enabled rule outside the categories",
+			Config:   `categories = ["security"]`,
+			Rules:    map[string]*tflint.RuleConfig{"structure_rule": {Name: "structure_rule", Enabled: true}},
+			Expected: []string{"structure_rule", "security_rule", "security_style_rule"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			rules := []*categoryRule{
+				{name: "structure_rule", categories: []string{CategoryStructure}},
+				{name: "security_rule", categories: []string{CategorySecurity}},
+				{name: "security_style_rule", categories: []string{CategorySecurity, CategoryStyle}},
+			}
+			ruleset := &RuleSet{}
+			for _, rule := range rules {
+				ruleset.Rules = append(ruleset.Rules, rule)
+			}
+
+			if err := ruleset.ApplyGlobalConfig(&tflint.Config{Rules: tc.Rules}); err != nil {
+				t.Fatal(err)
+			}
+			if err := ruleset.ApplyConfig(pluginContent(t, ruleset, tc.Config)); err != nil {
+				t.Fatal(err)
+			}
+			if err := ruleset.Check(helper.TestRunner(t, map[string]string{})); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			ran := []string{}
+			for _, rule := range rules {
+				if rule.ran {
+					ran = append(ran, rule.name)
+				}
+			}
+			if diff := cmp.Diff(tc.Expected, ran); diff != "" {
+				t.Fatalf("Unexpected rules ran:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_RuleSet_ApplyConfig_unknownCategory(t *testing.T) {
+	ruleset := &RuleSet{}
+	err := ruleset.ApplyConfig(pluginContent(t, ruleset, `categories = ["speed"]`))
+	if err == nil || err.Error() != `invalid plugin config: category "speed" must be one of structure, naming, security, cost, style` {
+		t.Fatalf("Expected an unknown category error, got %v", err)
+	}
+}
+
+// failingRule is a categoryRule whose check fails
+type failingRule struct {
+	categoryRule
+}
+
+func (r *failingRule) Check(runner tflint.Runner) error {
+	r.ran = true
+	return fmt.Errorf("%s failed", r.name)
+}
+
+func Test_RuleSet_Check_concurrent(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		passing := &categoryRule{name: "passing_rule"}
+		first := &failingRule{categoryRule{name: "first_failing_rule"}}
+		second := &failingRule{categoryRule{name: "second_failing_rule"}}
+
+		config := DefaultConfig()
+		config.Parallelism = 3
+		ruleset := &RuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{Rules: []tflint.Rule{passing, first, second}}, config: config}
+		if err := ruleset.ApplyGlobalConfig(&tflint.Config{}); err != nil {
+			t.Fatal(err)
+		}
+
+		err := ruleset.Check(helper.TestRunner(t, map[string]string{}))
+		if err == nil || err.Error() != "Failed to check `first_failing_rule` rule: first_failing_rule failed" {
+			t.Fatalf("Expected the first failing rule's error, got %v", err)
+		}
+		if !passing.ran || !first.ran || !second.ran {
+			t.Fatal("Expected every rule to run")
+		}
+	}
+}
+
+func Test_NewRuleSet(t *testing.T) {
+	cases := []struct {
+		Version  string
+		Expected string
+		Error    string
+	}{
+		{Version: "0.3.8", Expected: "0.3.8"},
+		{Version: "1.0.0-rc.1\n", Expected: "1.0.0-rc.1"},
+		{Version: "", Error: "ruleset version is empty; build the plugin from the repository root so VERSION is embedded"},
+		{Version: "v1.2.3", Error: `ruleset version "v1.2.3" in VERSION is not a semantic version like 1.2.3`},
+		{Version: "1.2", Error: `ruleset version "1.2" in VERSION is not a semantic version like 1.2.3`},
+	}
+
+	for _, tc := range cases {
+		ruleset, err := NewRuleSet(tc.Version)
+		if tc.Error != "" {
+			if err == nil || err.Error() != tc.Error {
+				t.Errorf("NewRuleSet(%q): expected error %q, got %v", tc.Version, tc.Error, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NewRuleSet(%q): unexpected error %s", tc.Version, err)
+			continue
+		}
+
+		if ruleset.RuleSetName() != "kb4" || ruleset.RuleSetVersion() != tc.Expected || len(ruleset.Rules) != len(All()) {
+			t.Errorf("NewRuleSet(%q) = %s %s with %d rules", tc.Version, ruleset.RuleSetName(), ruleset.RuleSetVersion(), len(ruleset.Rules))
+		}
+	}
+}
+
+func Test_RuleSet_aliases(t *testing.T) {
+	ruleset, err := NewRuleSet("1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := ruleset.RuleNames()
+	if !containsString(names, "terraform_kb4_file_structure") || !containsString(names, "terraform_kb4_module_structure") {
+		t.Fatalf("Expected the current and former names in %v", names)
+	}
+
+	cases := []struct {
+		Name     string
+		Rules    map[string]*tflint.RuleConfig
+		Expected bool
+	}{
+		{
+			Name:     "former name",
+			Rules:    map[string]*tflint.RuleConfig{"terraform_kb4_module_structure": {Name: "terraform_kb4_module_structure", Enabled: false}},
+			Expected: false,
+		},
+		{
+			Name: "current name wins",
+			Rules: map[string]*tflint.RuleConfig{
+				"terraform_kb4_module_structure": {Name: "terraform_kb4_module_structure", Enabled: false},
+				"terraform_kb4_file_structure":   {Name: "terraform_kb4_file_structure", Enabled: true},
+			},
+			Expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := ruleset.ApplyGlobalConfig(&tflint.Config{Rules: tc.Rules}); err != nil {
+				t.Fatal(err)
+			}
+
+			enabled := false
+			for _, rule := range ruleset.EnabledRules {
+				if rule.Name() == "terraform_kb4_file_structure" {
+					enabled = true
+				}
+			}
+			if enabled != tc.Expected {
+				t.Fatalf("Expected the rule to be enabled: %t, got %t", tc.Expected, enabled)
+			}
+		})
+	}
+
+	// The rule's options are read from a block using the former name
+	runner := helper.TestRunner(t, map[string]string{
+		".tflint.hcl": `
+rule "terraform_kb4_module_structure" {
+  enabled        = true
+  variables_file = "variables.tf"
+}`,
+		"_init.tf":     `terraform {}`,
+		"variables.tf": `variable "name" {}`,
+		"_outputs.tf":  `output "name" {}`,
+	})
+	if err := NewTerraformKb4FileStructureRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(runner.Issues) != 0 {
+		t.Fatalf("Expected no issues, got %#v", runner.Issues)
+	}
+}
+
+func Test_RuleSet_aliases_emptyConfigHost(t *testing.T) {
+	ruleset := &RuleSet{
+		BuiltinRuleSet: tflint.BuiltinRuleSet{
+			Name:    RuleSetName,
+			Version: "1.0.0",
+			Rules:   []tflint.Rule{NewTerraformKb4FileStructureRule()},
+		},
+		config: DefaultConfig(),
+	}
+	if err := ruleset.ApplyGlobalConfig(&tflint.Config{
+		Rules: map[string]*tflint.RuleConfig{"terraform_kb4_module_structure": {Name: "terraform_kb4_module_structure", Enabled: true}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	runner := helper.TestRunner(t, map[string]string{
+		".tflint.hcl": `
+rule "terraform_kb4_module_structure" {
+  enabled        = true
+  variables_file = "variables.tf"
+}`,
+		"_init.tf":     `terraform {}`,
+		"variables.tf": `variable "name" {}`,
+		"_outputs.tf":  `output "name" {}`,
+	})
+	host := &emptyConfigHost{Runner: runner, blocks: map[string]bool{"terraform_kb4_module_structure": true}}
+
+	if err := ruleset.Check(host); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(runner.Issues) != 0 {
+		t.Fatalf("Expected the options under the former name to be read, got %#v", runner.Issues)
+	}
+}
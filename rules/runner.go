@@ -0,0 +1,379 @@
+package rules
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Runner wraps the tflint.Runner handed to the ruleset. It memoizes the host round-trips
+// that rules repeat, so each added rule doesn't cost another full fetch of the module,
+// and carries the ruleset config to the rules.
+type Runner struct {
+	tflint.Runner
+
+	config *Config
+
+	// version is the version of the ruleset serving the rules, empty outside the RuleSet
+	version string
+
+	// ruleBlocks are the names of the rule blocks in .tflint.hcl, former rule names included, nil outside the RuleSet
+	ruleBlocks map[string]bool
+
+	// baseline suppresses known issues, and recording collects every issue for a new baseline
+	baseline  *Baseline
+	recording *Baseline
+
+	mu         sync.Mutex
+	files      map[string]*hcl.File
+	content    map[string]*contentEntry
+	severities map[string]tflint.Severity
+	messages   map[string]string
+
+	// emitted holds the fingerprint and range of every issue sent, so repeats from overlapping checks are dropped
+	emitted map[string]bool
+
+	// stats counts host requests, cache hits and issues per rule for the debug log and the metrics file
+	stats runnerStats
+
+	// emitMu serializes issues sent to the host, which isn't safe for concurrent use by every runner
+	emitMu sync.Mutex
+}
+
+// runnerStats are the counters a Runner keeps for the debug log and the metrics file
+type runnerStats struct {
+	hostRequests int
+	cacheHits    int
+	duplicates   int
+	baselined    int
+	issues       map[string]int
+	files        map[string]int
+	durations    map[string]time.Duration
+}
+
+// contentEntry is a cached module content request. ready is closed once the host has answered,
+// so concurrent rules asking for the same schema wait on a single request.
+type contentEntry struct {
+	ready   chan struct{}
+	content *hclext.BodyContent
+	err     error
+}
+
+// NewRunner returns a caching runner wrapping the given runner.
+// A nil config means the ruleset defaults.
+func NewRunner(runner tflint.Runner, config *Config) *Runner {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &Runner{
+		Runner:     runner,
+		config:     config,
+		content:    map[string]*contentEntry{},
+		severities: map[string]tflint.Severity{},
+		messages:   map[string]string{},
+		emitted:    map[string]bool{},
+		stats:      runnerStats{issues: map[string]int{}, files: map[string]int{}, durations: map[string]time.Duration{}},
+	}
+}
+
+// GetFiles returns the module files, fetching them from the host only once
+func (r *Runner) GetFiles() (map[string]*hcl.File, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.files != nil {
+		r.stats.cacheHits++
+		return r.files, nil
+	}
+	r.stats.hostRequests++
+
+	files, err := r.Runner.GetFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	r.files = files
+	return files, nil
+}
+
+// GetModuleContent returns the module content for the schema, fetching it from the host only once per schema and option.
+// Other schemas are fetched concurrently while a request is in flight.
+func (r *Runner) GetModuleContent(schema *hclext.BodySchema, option *tflint.GetModuleContentOption) (*hclext.BodyContent, error) {
+	key, err := contentKey(schema, option)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	entry, ok := r.content[key]
+	if ok {
+		r.stats.cacheHits++
+		r.mu.Unlock()
+		<-entry.ready
+		return entry.content, entry.err
+	}
+
+	entry = &contentEntry{ready: make(chan struct{})}
+	r.content[key] = entry
+	r.stats.hostRequests++
+	r.mu.Unlock()
+
+	entry.content, entry.err = r.Runner.GetModuleContent(schema, option)
+	close(entry.ready)
+
+	// Failed requests aren't cached, so a later caller retries them
+	if entry.err != nil {
+		r.mu.Lock()
+		delete(r.content, key)
+		r.mu.Unlock()
+	}
+
+	return entry.content, entry.err
+}
+
+// GetResourceContent is GetModuleContent filtered down to a single resource type.
+// The wrapped runner would otherwise bypass the cache by calling its own GetModuleContent.
+func (r *Runner) GetResourceContent(name string, schema *hclext.BodySchema, option *tflint.GetModuleContentOption) (*hclext.BodyContent, error) {
+	opts := tflint.GetModuleContentOption{}
+	if option != nil {
+		opts = *option
+	}
+	opts.Hint.ResourceType = name
+
+	body, err := r.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{Type: "resource", LabelNames: []string{"type", "name"}, Body: schema},
+		},
+	}, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	content := &hclext.BodyContent{Blocks: []*hclext.Block{}}
+	for _, resource := range body.Blocks {
+		if resource.Labels[0] != name {
+			continue
+		}
+
+		content.Blocks = append(content.Blocks, resource)
+	}
+
+	return content, nil
+}
+
+// issueDetails are what a rule knows about an issue beyond its message, for the placeholders of message templates
+type issueDetails struct {
+	// Expected is the file the block should be in, for rules that place blocks
+	Expected string
+}
+
+// emitIssueWithDetails emits the issue with details for the rule's message template.
+// Runners other than the ruleset's report the message as is.
+func emitIssueWithDetails(runner tflint.Runner, rule tflint.Rule, message string, issueRange hcl.Range, details issueDetails) error {
+	if r, ok := runner.(*Runner); ok {
+		return r.emitIssue(rule, message, issueRange, details)
+	}
+	return runner.EmitIssue(rule, message, issueRange)
+}
+
+// EmitIssue sends the issue to the host with the rule's link rebased onto the configured style guide,
+// its severity overridden when the rule's block sets one and its message rendered from the rule's message template.
+// The rule is passed through untouched when the config doesn't change how it is reported.
+// An issue identical to one already sent, with the same fingerprint and range, is dropped, as are issues in the baseline
+// and issues outside the changed files for rules that changed_files scopes.
+func (r *Runner) EmitIssue(rule tflint.Rule, message string, issueRange hcl.Range) error {
+	return r.emitIssue(rule, message, issueRange, issueDetails{})
+}
+
+// emitIssue is EmitIssue with details for the message template.
+// Fingerprints are taken from the rule's own message, so changing a template doesn't invalidate the baseline.
+func (r *Runner) emitIssue(rule tflint.Rule, message string, issueRange hcl.Range, details issueDetails) error {
+	address := blockAddress(r.issueFiles(), issueRange)
+	fingerprint := issueFingerprint(rule.Name(), address, message)
+
+	r.mu.Lock()
+	key := fingerprint + "@" + issueRange.String()
+	if r.emitted[key] {
+		r.stats.duplicates++
+		r.mu.Unlock()
+		return nil
+	}
+	r.emitted[key] = true
+	r.mu.Unlock()
+
+	if r.recording != nil {
+		r.recording.Record(rule, message, issueRange, address, fingerprint)
+	}
+	if r.baseline != nil && r.baseline.Suppresses(fingerprint) {
+		r.mu.Lock()
+		r.stats.baselined++
+		r.mu.Unlock()
+		return nil
+	}
+	if r.config.outOfScope(rule, issueRange.Filename) {
+		return nil
+	}
+
+	reported := &issueRule{Rule: rule, config: r.config}
+
+	r.mu.Lock()
+	if severity, ok := r.severities[rule.Name()]; ok {
+		reported.severity = &severity
+	}
+	template := r.messages[rule.Name()]
+	r.stats.issues[rule.Name()]++
+	r.stats.files[issueRange.Filename]++
+	r.mu.Unlock()
+
+	if template != "" {
+		message = renderMessage(template, map[string]string{
+			"message":  message,
+			"rule":     rule.Name(),
+			"file":     issueRange.Filename,
+			"line":     strconv.Itoa(issueRange.Start.Line),
+			"name":     address,
+			"expected": details.Expected,
+		})
+	}
+
+	r.emitMu.Lock()
+	defer r.emitMu.Unlock()
+
+	if reported.Link() == rule.Link() && reported.Severity() == rule.Severity() {
+		return r.Runner.EmitIssue(rule, message, issueRange)
+	}
+	return r.Runner.EmitIssue(reported, message, issueRange)
+}
+
+// renderMessage fills in the placeholders of a message template in one pass,
+// so braces in the values, such as an HCL expression quoted in the message, are left alone
+func renderMessage(template string, values map[string]string) string {
+	return messagePlaceholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		if value, ok := values[strings.Trim(placeholder, "{}")]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// rulesetVersion returns the version of the ruleset running the rules,
+// or an empty string on a runner that didn't come from the RuleSet (e.g. in tests)
+func rulesetVersion(runner tflint.Runner) string {
+	if r, ok := runner.(*Runner); ok {
+		return r.version
+	}
+	return ""
+}
+
+// issueFiles returns the module files to address issues with. Files already fetched are reused without counting
+// a cache hit, and a failure to fetch them leaves issues addressed by file name.
+func (r *Runner) issueFiles() map[string]*hcl.File {
+	r.mu.Lock()
+	files := r.files
+	r.mu.Unlock()
+
+	if files == nil {
+		files, _ = r.GetFiles()
+	}
+	return files
+}
+
+// issueCount returns how many issues the rule has reported so far
+func (r *Runner) issueCount(rule tflint.Rule) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats.issues[rule.Name()]
+}
+
+// recordDuration records how long the rule took to check
+func (r *Runner) recordDuration(rule tflint.Rule, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats.durations[rule.Name()] = duration
+}
+
+// overrideSeverity makes issues from the rule report the given severity. A nil severity restores the rule's own.
+func (r *Runner) overrideSeverity(rule tflint.Rule, severity *tflint.Severity) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if severity == nil {
+		delete(r.severities, rule.Name())
+		return
+	}
+	r.severities[rule.Name()] = *severity
+}
+
+// overrideMessage makes issues from the rule report messages rendered from the template. An empty template restores the rule's own.
+func (r *Runner) overrideMessage(rule tflint.Rule, template string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if template == "" {
+		delete(r.messages, rule.Name())
+		return
+	}
+	r.messages[rule.Name()] = template
+}
+
+// issueRule is the view of a rule that the host sees,
+// with the parts the ruleset and rule config can change applied
+type issueRule struct {
+	tflint.Rule
+
+	config   *Config
+	severity *tflint.Severity
+}
+
+// Severity returns the configured severity override, or the rule's own, escalated from WARNING to ERROR in strict mode
+func (r *issueRule) Severity() tflint.Severity {
+	severity := r.Rule.Severity()
+	if r.severity != nil {
+		severity = *r.severity
+	}
+
+	if severity == tflint.WARNING && r.config.strict() {
+		return tflint.ERROR
+	}
+	return severity
+}
+
+// Link returns the rule's anchor on the configured style guide.
+// Rules without an anchor keep their own link, with DefaultStyleGuideURL swapped for the configured style guide.
+func (r *issueRule) Link() string {
+	if anchor := ruleMetadata(r.Rule).Anchor; anchor != "" {
+		return r.config.styleGuideLink(anchor)
+	}
+
+	link := r.Rule.Link()
+
+	if strings.HasPrefix(link, DefaultStyleGuideURL) {
+		return r.config.StyleGuideURL + strings.TrimPrefix(link, DefaultStyleGuideURL)
+	}
+
+	return link
+}
+
+// contentKey hashes the schema and option into a cache key
+func contentKey(schema *hclext.BodySchema, option *tflint.GetModuleContentOption) (string, error) {
+	raw, err := json.Marshal(struct {
+		Schema *hclext.BodySchema
+		Option *tflint.GetModuleContentOption
+	}{schema, option})
+
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
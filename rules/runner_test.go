@@ -0,0 +1,195 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// countingRunner records how many requests reach the host
+type countingRunner struct {
+	tflint.Runner
+
+	getFiles         int
+	getModuleContent int
+}
+
+func (r *countingRunner) GetFiles() (map[string]*hcl.File, error) {
+	r.getFiles++
+	return r.Runner.GetFiles()
+}
+
+func (r *countingRunner) GetModuleContent(schema *hclext.BodySchema, option *tflint.GetModuleContentOption) (*hclext.BodyContent, error) {
+	r.getModuleContent++
+	return r.Runner.GetModuleContent(schema, option)
+}
+
+func Test_Runner(t *testing.T) {
+	host := &countingRunner{Runner: helper.TestRunner(t, map[string]string{
+		"main.tf": `
+variable "v" {}
+output "o" {}
+resource "aws_instance" "web" {}
+resource "aws_s3_bucket" "logs" {}`,
+	})}
+	runner := NewRunner(host, nil)
+
+	variables := &hclext.BodySchema{Blocks: []hclext.BlockSchema{{Type: "variable", LabelNames: []string{"name"}}}}
+	outputs := &hclext.BodySchema{Blocks: []hclext.BlockSchema{{Type: "output", LabelNames: []string{"name"}}}}
+
+	for i := 0; i < 3; i++ {
+		if _, err := runner.GetFiles(); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+		if _, err := runner.GetModuleContent(variables, nil); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+		if _, err := runner.GetModuleContent(outputs, nil); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+	}
+
+	if host.getFiles != 1 {
+		t.Errorf("GetFiles reached the host %d times, expected 1", host.getFiles)
+	}
+	if host.getModuleContent != 2 {
+		t.Errorf("GetModuleContent reached the host %d times, expected 2", host.getModuleContent)
+	}
+
+	// Equivalent schemas built separately share a cache entry
+	if _, err := runner.GetModuleContent(&hclext.BodySchema{Blocks: []hclext.BlockSchema{{Type: "variable", LabelNames: []string{"name"}}}}, nil); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if host.getModuleContent != 2 {
+		t.Errorf("GetModuleContent reached the host %d times, expected 2", host.getModuleContent)
+	}
+
+	instances, err := runner.GetResourceContent("aws_instance", &hclext.BodySchema{}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(instances.Blocks) != 1 || instances.Blocks[0].Labels[1] != "web" {
+		t.Errorf("Unexpected resources: %#v", instances.Blocks)
+	}
+
+	if _, err := runner.GetResourceContent("aws_instance", &hclext.BodySchema{}, nil); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if host.getModuleContent != 3 {
+		t.Errorf("GetModuleContent reached the host %d times, expected 3", host.getModuleContent)
+	}
+}
+
+func Test_Runner_strict(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Rule     tflint.Rule
+		Config   string
+		Strict   bool
+		Env      string
+		Expected tflint.Severity
+	}{
+		{
+			Name:     "warnings stay warnings",
+			Rule:     NewKb4TodoCommentsRule(),
+			Expected: tflint.WARNING,
+		},
+		{
+			Name:     "config",
+			Rule:     NewKb4TodoCommentsRule(),
+			Strict:   true,
+			Expected: tflint.ERROR,
+		},
+		{
+			Name:     "environment",
+			Rule:     NewKb4TodoCommentsRule(),
+			Env:      "1",
+			Expected: tflint.ERROR,
+		},
+		{
+			Name: "severity override",
+			Rule: NewTerraformValidatedVariablesRule(),
+			Config: `
+rule "terraform_validated_variables" {
+  enabled  = true
+  severity = "WARNING"
+}`,
+			Strict:   true,
+			Expected: tflint.ERROR,
+		},
+		{
+			Name: "notices stay notices",
+			Rule: NewKb4TodoCommentsRule(),
+			Config: `
+rule "kb4_todo_comments" {
+  enabled  = true
+  severity = "NOTICE"
+}`,
+			Strict:   true,
+			Expected: tflint.NOTICE,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			if tc.Env != "" {
+				os.Setenv(StrictEnv, tc.Env)
+				defer os.Unsetenv(StrictEnv)
+			}
+
+			host := helper.TestRunner(t, map[string]string{
+				".tflint.hcl": tc.Config,
+				"main.tf": `
+# TODO: document the variable
+variable "name" {}`,
+			})
+			config := DefaultConfig()
+			config.Strict = tc.Strict
+
+			if err := tc.Rule.Check(NewRunner(host, config)); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			if len(host.Issues) != 1 {
+				t.Fatalf("Expected 1 issue, got %d", len(host.Issues))
+			}
+			if got := host.Issues[0].Rule.Severity(); got != tc.Expected {
+				t.Fatalf("Expected severity %s, got %s", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func Test_Runner_changedFiles(t *testing.T) {
+	chdir(t, filepath.Join(testRepository(t, "modules/app"), "modules/app"))
+
+	host := helper.TestRunner(t, map[string]string{
+		"main.tf": `
+# TODO: split the module
+resource "aws_iam_access_key" "ci" {}`,
+		"legacy.tf": `
+# TODO: delete once migrated
+resource "aws_iam_access_key" "legacy" {}`,
+	})
+	config := DefaultConfig()
+	config.ChangedFiles = []string{"modules/app/main.tf"}
+	runner := NewRunner(host, config)
+
+	for _, rule := range []tflint.Rule{NewKb4TodoCommentsRule(), NewKb4IamAccessKeysRule()} {
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+	}
+
+	sortIssues(host.Issues)
+	helper.AssertIssuesWithoutRange(t, helper.Issues{
+		{Rule: NewKb4IamAccessKeysRule(), Message: "resource \"aws_iam_access_key\" \"legacy\" stores a long-lived secret key in state; use IRSA or OIDC federation instead, see " + DefaultStyleGuideURL + "#workload-identity"},
+		{Rule: NewKb4TodoCommentsRule(), Message: "TODO comment should reference a ticket matching \\b[A-Z][A-Z0-9]+-[0-9]+\\b"},
+		{Rule: NewKb4IamAccessKeysRule(), Message: "resource \"aws_iam_access_key\" \"ci\" stores a long-lived secret key in state; use IRSA or OIDC federation instead, see " + DefaultStyleGuideURL + "#workload-identity"},
+	}, host.Issues)
+}
@@ -0,0 +1,54 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PluginSource is the plugin's source address for `tflint --init`
+const PluginSource = "github.com/kb4sre/tflint-ruleset-kb4"
+
+// StarterConfig renders a commented .tflint.hcl that enables the ruleset and
+// every rule with its default options, for bootstrapping new repos
+func StarterConfig(ruleset *RuleSet) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Generated by `tflint-ruleset-kb4 -print-config`.\n")
+	fmt.Fprintf(&b, "# Every value below is the default; edit or delete what you don't need.\n\n")
+
+	fmt.Fprintf(&b, "plugin %q {\n", ruleset.RuleSetName())
+	fmt.Fprintf(&b, "  enabled = true\n")
+	fmt.Fprintf(&b, "  version = %q\n", strings.TrimSpace(ruleset.RuleSetVersion()))
+	fmt.Fprintf(&b, "  source  = %q\n", PluginSource)
+	writeStarterFields(&b, configFields(DefaultConfig()))
+	fmt.Fprintf(&b, "}\n")
+
+	for _, rule := range ruleset.Rules {
+		metadata := ruleMetadata(rule)
+
+		b.WriteString("\n")
+		if metadata.Description != "" {
+			fmt.Fprintf(&b, "# %s\n", metadata.Description)
+		}
+		if link := rule.Link(); link != "" {
+			fmt.Fprintf(&b, "# %s\n", link)
+		}
+		fmt.Fprintf(&b, "rule %q {\n", rule.Name())
+		fmt.Fprintf(&b, "  enabled = %t\n", rule.Enabled())
+		writeStarterFields(&b, configFields(metadata.Config))
+		fmt.Fprintf(&b, "}\n")
+	}
+
+	return b.String()
+}
+
+func writeStarterFields(b *strings.Builder, fields []configField) {
+	for _, field := range fields {
+		b.WriteString("\n")
+		if field.Description != "" {
+			fmt.Fprintf(b, "  # %s\n", field.Description)
+		}
+		fmt.Fprintf(b, "  # Type: %s\n", field.Type)
+		fmt.Fprintf(b, "  %s = %s\n", field.Name, field.Default)
+	}
+}
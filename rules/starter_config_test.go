@@ -0,0 +1,69 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_StarterConfig(t *testing.T) {
+	ruleset := &RuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{
+		Name:    "kb4",
+		Version: "1.2.3\n",
+		Rules:   All(),
+	}}
+
+	src := StarterConfig(ruleset)
+
+	file, diags := hclparse.NewParser().ParseHCL([]byte(src), ".tflint.hcl")
+	if diags.HasErrors() {
+		t.Fatalf("Starter config is not valid HCL: %s\n%s", diags, src)
+	}
+
+	content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "plugin", LabelNames: []string{"name"}},
+			{Type: "rule", LabelNames: []string{"name"}},
+		},
+	})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	rules := map[string]bool{}
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "plugin":
+			if block.Labels[0] != "kb4" {
+				t.Errorf("Unexpected plugin block %q", block.Labels[0])
+			}
+
+			// The plugin block must decode with the ruleset's own schema
+			attrs, diags := block.Body.JustAttributes()
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			delete(attrs, "enabled")
+			delete(attrs, "version")
+			delete(attrs, "source")
+			body := &hclext.BodyContent{Attributes: hclext.Attributes{}}
+			for name, attr := range attrs {
+				body.Attributes[name] = &hclext.Attribute{Name: name, Expr: attr.Expr, Range: attr.Range}
+			}
+			if err := ruleset.ApplyConfig(body); err != nil {
+				t.Fatalf("Plugin block doesn't apply: %s", err)
+			}
+		case "rule":
+			rules[block.Labels[0]] = true
+		}
+	}
+
+	for _, rule := range ruleset.Rules {
+		if !rules[rule.Name()] {
+			t.Errorf("Starter config is missing rule `%s`", rule.Name())
+		}
+	}
+}
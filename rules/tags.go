@@ -0,0 +1,109 @@
+package rules
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// taggableResourceTypes are the resource types modules commonly create that take a `tags` map.
+// It doesn't need to be complete: any type that sets tags somewhere in the module counts as taggable too.
+// Types like aws_autoscaling_group that tag through `tag` blocks instead don't belong here.
+var taggableResourceTypes = []string{
+	"aws_acm_certificate",
+	"aws_cloudfront_distribution",
+	"aws_cloudwatch_log_group",
+	"aws_cloudwatch_metric_alarm",
+	"aws_db_instance",
+	"aws_dynamodb_table",
+	"aws_ebs_volume",
+	"aws_ecr_repository",
+	"aws_ecs_cluster",
+	"aws_ecs_service",
+	"aws_ecs_task_definition",
+	"aws_efs_file_system",
+	"aws_eip",
+	"aws_eks_cluster",
+	"aws_eks_node_group",
+	"aws_elasticache_cluster",
+	"aws_elasticache_replication_group",
+	"aws_iam_policy",
+	"aws_iam_role",
+	"aws_instance",
+	"aws_internet_gateway",
+	"aws_kms_key",
+	"aws_lambda_function",
+	"aws_launch_template",
+	"aws_lb",
+	"aws_lb_target_group",
+	"aws_nat_gateway",
+	"aws_rds_cluster",
+	"aws_route53_zone",
+	"aws_route_table",
+	"aws_s3_bucket",
+	"aws_secretsmanager_secret",
+	"aws_security_group",
+	"aws_sns_topic",
+	"aws_sqs_queue",
+	"aws_ssm_parameter",
+	"aws_subnet",
+	"aws_vpc",
+}
+
+// taggableTypes returns the resource types that take tags: the known ones, and every type one of the resources sets tags on
+func taggableTypes(resources []*hclext.Block) map[string]bool {
+	types := map[string]bool{}
+	for _, resourceType := range taggableResourceTypes {
+		types[resourceType] = true
+	}
+	for _, resource := range resources {
+		if _, ok := resource.Body.Attributes["tags"]; ok {
+			types[resource.Labels[0]] = true
+		}
+	}
+	return types
+}
+
+// evaluateTagBlockKeys evaluates the `key` of every `tag` block and calls fn with them when all are known
+func evaluateTagBlockKeys(runner tflint.Runner, blocks hclext.Blocks, fn func(keys []string) error) error {
+	keys := []string{}
+	for _, block := range blocks {
+		attr, ok := block.Body.Attributes["key"]
+		if !ok {
+			return nil
+		}
+
+		known := false
+		if err := evaluateString(runner, attr.Expr, func(key string) error {
+			known = true
+			keys = append(keys, key)
+			return nil
+		}); err != nil {
+			return err
+		}
+		if !known {
+			return nil
+		}
+	}
+	return fn(keys)
+}
+
+// evaluateTagKeys evaluates a tags expression and calls fn with its keys when the map is known.
+// Maps that aren't known yet, e.g. from a variable without a default, are skipped.
+func evaluateTagKeys(runner tflint.Runner, expr hcl.Expression, fn func(keys []string) error) error {
+	var value cty.Value
+	err := runner.EvaluateExpr(expr, &value, nil)
+	return runner.EnsureNoError(err, func() error {
+		if !value.IsKnown() || value.IsNull() || !(value.Type().IsMapType() || value.Type().IsObjectType()) {
+			return nil
+		}
+
+		keys := []string{}
+		for it := value.ElementIterator(); it.Next(); {
+			key, _ := it.Element()
+			keys = append(keys, key.AsString())
+		}
+		return fn(keys)
+	})
+}
@@ -2,18 +2,128 @@ package rules
 
 import (
 	"fmt"
-	"log"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
-var EXPECTED_FILES []string = []string{"_init.tf", "_variables.tf", "_outputs.tf"}
+// TerraformKb4FileStructureRuleConfig is the rule's .tflint.hcl config.
+// Each file also matches its JSON syntax variant, so `_variables.tf` is satisfied by `_variables.tf.json` and vice versa.
+type TerraformKb4FileStructureRuleConfig struct {
+	InitFile      string `hclext:"init_file,optional" doc:"File holding the terraform, provider and terraform_remote_state blocks."`
+	VariablesFile string `hclext:"variables_file,optional" doc:"File holding the variable blocks."`
+	OutputsFile   string `hclext:"outputs_file,optional" doc:"File holding the output blocks."`
+	// ProvidersFile splits the provider blocks out of InitFile when set
+	ProvidersFile string `hclext:"providers_file,optional" doc:"File holding the provider blocks, e.g. _providers.tf. Empty keeps them in init_file."`
+	// Layout picks the file holding the terraform block: init_file, versions_file, or either one as long as the module sticks to it
+	Layout       string `hclext:"layout,optional" doc:"Where the terraform block with required_version and required_providers lives: \"init\" for init_file, \"versions\" for versions_file, or \"either\" to accept both layouts as long as a module follows only one."`
+	VersionsFile string `hclext:"versions_file,optional" doc:"File holding the terraform block in the versions layout."`
+	// AggregateMissingFiles reports all missing files as a single issue instead of one per file
+	AggregateMissingFiles bool `hclext:"aggregate_missing_files,optional" doc:"Report all missing files in one issue on the first line of an existing file."`
+}
+
+func newTerraformKb4FileStructureRuleConfig() *TerraformKb4FileStructureRuleConfig {
+	return &TerraformKb4FileStructureRuleConfig{
+		InitFile:      "_init.tf",
+		VariablesFile: "_variables.tf",
+		OutputsFile:   "_outputs.tf",
+		Layout:        layoutInit,
+		VersionsFile:  "_versions.tf",
+	}
+}
+
+// Layouts of the terraform block
+const (
+	layoutInit     = "init"
+	layoutVersions = "versions"
+	layoutEither   = "either"
+)
+
+// Validate rejects empty file names
+func (c *TerraformKb4FileStructureRuleConfig) Validate() error {
+	if c.InitFile == "" || c.VariablesFile == "" || c.OutputsFile == "" {
+		return fmt.Errorf("init_file, variables_file and outputs_file must not be empty")
+	}
+	if c.Layout != layoutInit && c.Layout != layoutVersions && c.Layout != layoutEither {
+		return fmt.Errorf("layout must be %q, %q or %q, not %q", layoutInit, layoutVersions, layoutEither, c.Layout)
+	}
+	if c.Layout != layoutInit && c.VersionsFile == "" {
+		return fmt.Errorf("versions_file must not be empty in the %s layout", c.Layout)
+	}
+	return nil
+}
+
+// expectedFiles lists the files every module must include
+func (c *TerraformKb4FileStructureRuleConfig) expectedFiles() []string {
+	return []string{c.InitFile, c.VariablesFile, c.OutputsFile}
+}
+
+// standardFiles lists the files reserved for the blocks the rule places, including the providers and versions files when used.
+// Unlike the expected files, a module without providers needn't have a providers file.
+func (c *TerraformKb4FileStructureRuleConfig) standardFiles() []string {
+	files := c.expectedFiles()
+	if c.ProvidersFile != "" {
+		files = append(files, c.ProvidersFile)
+	}
+	if c.Layout != layoutInit {
+		files = append(files, c.VersionsFile)
+	}
+	return files
+}
+
+// terraformFile returns the file the module's terraform blocks belong in.
+// In the either layout it is the file the module already uses, preferring init_file when it uses both.
+func (c *TerraformKb4FileStructureRuleConfig) terraformFile(blocks []*hclext.Block) string {
+	switch c.Layout {
+	case layoutVersions:
+		return c.VersionsFile
+	case layoutEither:
+		inVersions := false
+		for _, block := range blocks {
+			if sameTerraformFile(block.DefRange.Filename, c.InitFile) {
+				return c.InitFile
+			}
+			inVersions = inVersions || sameTerraformFile(block.DefRange.Filename, c.VersionsFile)
+		}
+		if inVersions {
+			return c.VersionsFile
+		}
+	}
+	return c.InitFile
+}
+
+// providersFile returns the file provider blocks belong in
+func (c *TerraformKb4FileStructureRuleConfig) providersFile() string {
+	if c.ProvidersFile != "" {
+		return c.ProvidersFile
+	}
+	return c.InitFile
+}
+
+// fileStructureConfig reads the file structure rule's options, for other rules that need to know where blocks belong
+func fileStructureConfig(runner tflint.Runner) (*TerraformKb4FileStructureRuleConfig, error) {
+	rule := NewTerraformKb4FileStructureRule()
+	config := newTerraformKb4FileStructureRuleConfig()
+	if err := decodeRuleBlock(runner, rule, config, &CommonRuleConfig{}); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid `%s` rule config: %s", rule.Name(), err)
+	}
+	return config, nil
+}
 
 // TerraformKb4FileStructureRule checks whether modules adhere to Terraform's standard module structure
 type TerraformKb4FileStructureRule struct {
-	tflint.DefaultRule
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewTerraformKb4FileStructureRule())
 }
 
 // NewTerraformKb4ModuleStructureRule returns a new rule
@@ -23,7 +133,7 @@ func NewTerraformKb4FileStructureRule() *TerraformKb4FileStructureRule {
 
 // Name returns the rule name
 func (r *TerraformKb4FileStructureRule) Name() string {
-	return "terraform_kb4_module_structure"
+	return "terraform_kb4_file_structure"
 }
 
 // Enabled returns whether the rule is enabled by default
@@ -38,44 +148,117 @@ func (r *TerraformKb4FileStructureRule) Severity() tflint.Severity {
 
 // Link returns the rule reference link
 func (r *TerraformKb4FileStructureRule) Link() string {
-	return "https://engineering.internal.knowbe4.com/tech-stack/terraform/style-guide/#standard-files-names-and-usage"
+	return styleGuideLink(r)
+}
+
+// Metadata returns the rule documentation
+func (r *TerraformKb4FileStructureRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Modules must include the standard `_init.tf`, `_variables.tf` and `_outputs.tf` files. Variables and outputs live in their files, `terraform`, `provider` and `terraform_remote_state` blocks live in `_init.tf`, and locals stay out of all three. Set `providers_file` to move `provider` blocks out of `_init.tf` into a file of their own, such as `_providers.tf`, and `layout` to keep the `terraform` block in `_versions.tf` instead, or in either file as long as a module doesn't split it across both.",
+		Categories:  []string{CategoryStructure},
+		Anchor:      "standard-files-names-and-usage",
+		Aliases:     []string{"terraform_kb4_module_structure"},
+		Example: `
+# main.tf
+variable "name" {}
+
+output "id" {
+  value = aws_instance.this.id
+}`,
+		Config: newTerraformKb4FileStructureRuleConfig(),
+	}
 }
 
 // Check emits errors for any missing files and any block types that are included in the wrong file
 func (r *TerraformKb4FileStructureRule) Check(runner tflint.Runner) error {
-	log.Printf("[TRACE] Check `%s` rule", r.Name())
+	config := newTerraformKb4FileStructureRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
 
-	r.checkFiles(runner)
-	r.checkVariables(runner)
-	r.checkOutputs(runner)
+	checks := []struct {
+		name  string
+		check func(tflint.Runner, *TerraformKb4FileStructureRuleConfig) error
+	}{
+		{"files", r.checkFiles},
+		{"variables", r.checkVariables},
+		{"outputs", r.checkOutputs},
+		{"terraform", r.checkTerraformBlock},
+		{"providers", r.checkProviders},
+		{"terraform_remote_state", r.checkTerraformRemoteState},
+		{"locals", r.checkLocals},
+	}
+
+	for _, c := range checks {
+		done := timeCheck(runner, r, c.name)
+		err := c.check(runner, config)
+		done()
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
-func (r *TerraformKb4FileStructureRule) checkFiles(runner tflint.Runner) error {
+func (r *TerraformKb4FileStructureRule) checkFiles(runner tflint.Runner, config *TerraformKb4FileStructureRuleConfig) error {
 	files, err := runner.GetFiles()
 
 	if err != nil {
 		return err
 	}
 
-	for _, name := range EXPECTED_FILES {
-		if files[name] == nil {
-			runner.EmitIssue(
-				r,
-				fmt.Sprintf("Module should include a %s file.", name),
-				hcl.Range{
-					Filename: name,
-					Start:    hcl.InitialPos,
-				},
-			)
+	missing := []string{}
+	for _, name := range config.expectedFiles() {
+		if !hasTerraformFile(files, name) {
+			missing = append(missing, name)
 		}
 	}
 
+	if config.AggregateMissingFiles && len(missing) > 0 {
+		return r.emitMissingFilesSummary(runner, files, missing)
+	}
+
+	for _, name := range missing {
+		runner.EmitIssue(
+			r,
+			fmt.Sprintf("Module should include a %s file.", name),
+			hcl.Range{
+				Filename: name,
+				Start:    hcl.InitialPos,
+			},
+		)
+	}
+
 	return nil
 }
 
-func (r *TerraformKb4FileStructureRule) checkVariables(runner tflint.Runner) error {
+// emitMissingFilesSummary reports every missing file in one issue anchored to the first line of an existing file,
+// so PR annotations land somewhere real. A module with no files at all falls back to the first missing file.
+func (r *TerraformKb4FileStructureRule) emitMissingFilesSummary(runner tflint.Runner, files map[string]*hcl.File, missing []string) error {
+	anchor := missing[0]
+
+	existing := make([]string, 0, len(files))
+	for name := range files {
+		existing = append(existing, name)
+	}
+	sort.Strings(existing)
+	if len(existing) > 0 {
+		anchor = existing[0]
+	}
+
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("Module should include the %s files.", strings.Join(missing, ", ")),
+		hcl.Range{
+			Filename: anchor,
+			Start:    hcl.InitialPos,
+			End:      hcl.InitialPos,
+		},
+	)
+}
+
+func (r *TerraformKb4FileStructureRule) checkVariables(runner tflint.Runner, config *TerraformKb4FileStructureRuleConfig) error {
 
 	content, err := runner.GetModuleContent(&hclext.BodySchema{
 		Blocks: []hclext.BlockSchema{
@@ -91,11 +274,17 @@ func (r *TerraformKb4FileStructureRule) checkVariables(runner tflint.Runner) err
 	}
 
 	for _, variable := range content.Blocks {
-		if variable.DefRange.Filename != "_variables.tf" {
-			runner.EmitIssue(
+		if ruleSetConfig(runner).excludesFile(variable.DefRange.Filename) {
+			continue
+		}
+
+		if !sameTerraformFile(variable.DefRange.Filename, config.VariablesFile) {
+			emitIssueWithDetails(
+				runner,
 				r,
-				fmt.Sprintf("variable %q should be moved from %s to %s", variable.Labels[0], variable.DefRange.Filename, "_variables.tf"),
+				fmt.Sprintf("%s should be moved from %s to %s", describeBlock(variable), variable.DefRange.Filename, config.VariablesFile),
 				variable.DefRange,
+				issueDetails{Expected: config.VariablesFile},
 			)
 		}
 	}
@@ -103,7 +292,7 @@ func (r *TerraformKb4FileStructureRule) checkVariables(runner tflint.Runner) err
 	return nil
 }
 
-func (r *TerraformKb4FileStructureRule) checkOutputs(runner tflint.Runner) error {
+func (r *TerraformKb4FileStructureRule) checkOutputs(runner tflint.Runner, config *TerraformKb4FileStructureRuleConfig) error {
 
 	content, err := runner.GetModuleContent(&hclext.BodySchema{
 		Blocks: []hclext.BlockSchema{
@@ -118,15 +307,158 @@ func (r *TerraformKb4FileStructureRule) checkOutputs(runner tflint.Runner) error
 		return err
 	}
 
-	for _, variable := range content.Blocks {
-		if variable.DefRange.Filename != "_outputs.tf" {
-			runner.EmitIssue(
+	for _, output := range content.Blocks {
+		if ruleSetConfig(runner).excludesFile(output.DefRange.Filename) {
+			continue
+		}
+
+		if !sameTerraformFile(output.DefRange.Filename, config.OutputsFile) {
+			emitIssueWithDetails(
+				runner,
 				r,
-				fmt.Sprintf("variable %q should be moved from %s to %s", variable.Labels[0], variable.DefRange.Filename, "_outputs.tf"),
-				variable.DefRange,
+				fmt.Sprintf("%s should be moved from %s to %s", describeBlock(output), output.DefRange.Filename, config.OutputsFile),
+				output.DefRange,
+				issueDetails{Expected: config.OutputsFile},
 			)
 		}
 	}
 
 	return nil
 }
+
+func (r *TerraformKb4FileStructureRule) checkTerraformBlock(runner tflint.Runner, config *TerraformKb4FileStructureRuleConfig) error {
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "terraform",
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	target := config.terraformFile(content.Blocks)
+	for _, block := range content.Blocks {
+		if ruleSetConfig(runner).excludesFile(block.DefRange.Filename) {
+			continue
+		}
+
+		if !sameTerraformFile(block.DefRange.Filename, target) {
+			emitIssueWithDetails(
+				runner,
+				r,
+				fmt.Sprintf("%s should be moved from %s to %s", describeBlock(block), block.DefRange.Filename, target),
+				block.DefRange,
+				issueDetails{Expected: target},
+			)
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformKb4FileStructureRule) checkProviders(runner tflint.Runner, config *TerraformKb4FileStructureRuleConfig) error {
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "provider",
+				LabelNames: []string{"name"},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, provider := range content.Blocks {
+		if ruleSetConfig(runner).excludesFile(provider.DefRange.Filename) {
+			continue
+		}
+
+		if !sameTerraformFile(provider.DefRange.Filename, config.providersFile()) {
+			emitIssueWithDetails(
+				runner,
+				r,
+				fmt.Sprintf("%s should be moved from %s to %s", describeBlock(provider), provider.DefRange.Filename, config.providersFile()),
+				provider.DefRange,
+				issueDetails{Expected: config.providersFile()},
+			)
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformKb4FileStructureRule) checkTerraformRemoteState(runner tflint.Runner, config *TerraformKb4FileStructureRuleConfig) error {
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "data",
+				LabelNames: []string{"type", "name"},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, data := range content.Blocks {
+		if data.Labels[0] != "terraform_remote_state" || ruleSetConfig(runner).excludesFile(data.DefRange.Filename) {
+			continue
+		}
+
+		if !sameTerraformFile(data.DefRange.Filename, config.InitFile) {
+			emitIssueWithDetails(
+				runner,
+				r,
+				fmt.Sprintf("%s should be moved from %s to %s", describeBlock(data), data.DefRange.Filename, config.InitFile),
+				data.DefRange,
+				issueDetails{Expected: config.InitFile},
+			)
+		}
+	}
+
+	return nil
+}
+
+// checkLocals keeps locals out of the standard files, which are reserved for the blocks checked above, the providers file included.
+// Locals may otherwise live next to the resources that use them.
+func (r *TerraformKb4FileStructureRule) checkLocals(runner tflint.Runner, config *TerraformKb4FileStructureRuleConfig) error {
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "locals",
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, block := range content.Blocks {
+		if ruleSetConfig(runner).excludesFile(block.DefRange.Filename) {
+			continue
+		}
+
+		for _, name := range config.standardFiles() {
+			if sameTerraformFile(block.DefRange.Filename, name) {
+				runner.EmitIssue(
+					r,
+					fmt.Sprintf("%s should be moved out of %s", describeBlock(block), block.DefRange.Filename),
+					block.DefRange,
+				)
+			}
+		}
+	}
+
+	return nil
+}
@@ -132,6 +132,336 @@ func Test_TerraformKb4ModuleStructureRule(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "move output",
+			Content: map[string]string{
+				"_init.tf":      `terraform {}`,
+				"_variables.tf": `variable "some_variable" {}`,
+				"_outputs.tf":   `output "some_output" {}`,
+				"main.tf":       `output "misplaced_output" {}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: `output "misplaced_output" should be moved from main.tf to _outputs.tf`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 26},
+					},
+				},
+			},
+		},
+		{
+			Name: "move terraform block",
+			Content: map[string]string{
+				"_init.tf":      `provider "aws" {}`,
+				"_variables.tf": `variable "some_variable" {}`,
+				"_outputs.tf":   `output "some_output" {}`,
+				"main.tf": `
+terraform {
+  required_version = ">= 1.0"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: "terraform block on line 2 should be moved from main.tf to _init.tf",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 10},
+					},
+				},
+			},
+		},
+		{
+			Name: "versions layout",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "terraform_kb4_file_structure" {
+  enabled = true
+  layout  = "versions"
+}`,
+				"_init.tf": `
+provider "aws" {}
+terraform {}`,
+				"_versions.tf": `
+locals {}`,
+				"_variables.tf": `variable "some_variable" {}`,
+				"_outputs.tf":   `output "some_output" {}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: "terraform block on line 3 should be moved from _init.tf to _versions.tf",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 10},
+					},
+				},
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: "locals block on line 2 should be moved out of _versions.tf",
+					Range: hcl.Range{
+						Filename: "_versions.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 7},
+					},
+				},
+			},
+		},
+		{
+			Name: "either layout",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "terraform_kb4_file_structure" {
+  enabled = true
+  layout  = "either"
+}`,
+				"_init.tf":      `provider "aws" {}`,
+				"_versions.tf":  `terraform {}`,
+				"_variables.tf": `variable "some_variable" {}`,
+				"_outputs.tf":   `output "some_output" {}`,
+				"main.tf":       `terraform {}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: "terraform block on line 1 should be moved from main.tf to _versions.tf",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 10},
+					},
+				},
+			},
+		},
+		{
+			Name: "either layout split across both files",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "terraform_kb4_file_structure" {
+  enabled = true
+  layout  = "either"
+}`,
+				"_init.tf":      `terraform {}`,
+				"_versions.tf":  `terraform {}`,
+				"_variables.tf": `variable "some_variable" {}`,
+				"_outputs.tf":   `output "some_output" {}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: "terraform block on line 1 should be moved from _versions.tf to _init.tf",
+					Range: hcl.Range{
+						Filename: "_versions.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 10},
+					},
+				},
+			},
+		},
+		{
+			Name: "move provider",
+			Content: map[string]string{
+				"_init.tf":      `terraform {}`,
+				"_variables.tf": `variable "some_variable" {}`,
+				"_outputs.tf":   `output "some_output" {}`,
+				"main.tf":       `provider "aws" {}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: `provider "aws" should be moved from main.tf to _init.tf`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 15},
+					},
+				},
+			},
+		},
+		{
+			Name: "providers file",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "terraform_kb4_file_structure" {
+  enabled        = true
+  providers_file = "_providers.tf"
+}`,
+				"_init.tf": `
+terraform {}
+provider "aws" {}`,
+				"_providers.tf": `
+provider "aws" {
+  alias = "us_west_2"
+}
+data "terraform_remote_state" "vpc" {}
+locals {}`,
+				"_variables.tf": `variable "some_variable" {}`,
+				"_outputs.tf":   `output "some_output" {}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: `provider "aws" should be moved from _init.tf to _providers.tf`,
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 15},
+					},
+				},
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: `data "terraform_remote_state" "vpc" should be moved from _providers.tf to _init.tf`,
+					Range: hcl.Range{
+						Filename: "_providers.tf",
+						Start:    hcl.Pos{Line: 5, Column: 1},
+						End:      hcl.Pos{Line: 5, Column: 36},
+					},
+				},
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: "locals block on line 6 should be moved out of _providers.tf",
+					Range: hcl.Range{
+						Filename: "_providers.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 7},
+					},
+				},
+			},
+		},
+		{
+			Name: "move terraform_remote_state",
+			Content: map[string]string{
+				"_init.tf":      `terraform {}`,
+				"_variables.tf": `variable "some_variable" {}`,
+				"_outputs.tf":   `output "some_output" {}`,
+				"main.tf": `
+data "aws_ami" "ubuntu" {}
+data "terraform_remote_state" "network" {}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: `data "terraform_remote_state" "network" should be moved from main.tf to _init.tf`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 40},
+					},
+				},
+			},
+		},
+		{
+			Name: "locals in a standard file",
+			Content: map[string]string{
+				"_init.tf": `terraform {}`,
+				"_variables.tf": `
+variable "some_variable" {}
+
+locals {
+  name = var.some_variable
+}`,
+				"_outputs.tf": `output "some_output" {}`,
+				"main.tf": `
+locals {
+  id = "x"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: "locals block on line 4 should be moved out of _variables.tf",
+					Range: hcl.Range{
+						Filename: "_variables.tf",
+						Start:    hcl.Pos{Line: 4, Column: 1},
+						End:      hcl.Pos{Line: 4, Column: 7},
+					},
+				},
+			},
+		},
+		{
+			// Regression: unlabeled blocks used to risk formatting a label they don't have
+			Name: "two misplaced terraform blocks in one file",
+			Content: map[string]string{
+				"_init.tf":      `provider "aws" {}`,
+				"_variables.tf": `variable "some_variable" {}`,
+				"_outputs.tf":   `output "some_output" {}`,
+				"main.tf": `terraform {}
+
+terraform {}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: "terraform block on line 1 should be moved from main.tf to _init.tf",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 10},
+					},
+				},
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: "terraform block on line 3 should be moved from main.tf to _init.tf",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 10},
+					},
+				},
+			},
+		},
+		{
+			Name: "two locals blocks in standard files",
+			Content: map[string]string{
+				"_init.tf": `
+terraform {}
+locals {}`,
+				"_variables.tf": `variable "some_variable" {}`,
+				"_outputs.tf": `
+output "some_output" {}
+locals {}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: "locals block on line 3 should be moved out of _init.tf",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 7},
+					},
+				},
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: "locals block on line 3 should be moved out of _outputs.tf",
+					Range: hcl.Range{
+						Filename: "_outputs.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 7},
+					},
+				},
+			},
+		},
+		{
+			Name: "blocks in their standard files",
+			Content: map[string]string{
+				"_init.tf": `
+terraform {}
+provider "aws" {}
+data "terraform_remote_state" "network" {}`,
+				"_variables.tf": `variable "some_variable" {}`,
+				"_outputs.tf":   `output "some_output" {}`,
+				"main.tf": `
+locals {}
+data "aws_ami" "ubuntu" {}`,
+			},
+			Expected: helper.Issues{},
+		},
 	}
 
 	rule := NewTerraformKb4FileStructureRule()
@@ -145,6 +475,228 @@ func Test_TerraformKb4ModuleStructureRule(t *testing.T) {
 				t.Fatalf("Unexpected error occurred: %s", err)
 			}
 
+			assertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_TerraformKb4ModuleStructureRule_JSON(t *testing.T) {
+	runner := testRunner(t, map[string]string{
+		"_init.tf":      `terraform {}`,
+		"_variables.tf": `variable "some_variable" {}`,
+		"_outputs.tf":   `output "some_output" {}`,
+		"main.tf.json": `{
+  "terraform": {"required_version": ">= 1.0"},
+  "provider": {"aws": {"region": "us-east-1"}},
+  "variable": {"misplaced_variable": {}},
+  "output": {"misplaced_output": {"value": "x"}},
+  "data": {"terraform_remote_state": {"network": {}}},
+  "locals": {"name": "x"}
+}`,
+	})
+
+	rule := NewTerraformKb4FileStructureRule()
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssuesWithoutRange(t, helper.Issues{
+		{Rule: rule, Message: `variable "misplaced_variable" should be moved from main.tf.json to _variables.tf`},
+		{Rule: rule, Message: `output "misplaced_output" should be moved from main.tf.json to _outputs.tf`},
+		{Rule: rule, Message: "terraform block on line 2 should be moved from main.tf.json to _init.tf"},
+		{Rule: rule, Message: `provider "aws" should be moved from main.tf.json to _init.tf`},
+		{Rule: rule, Message: `data "terraform_remote_state" "network" should be moved from main.tf.json to _init.tf`},
+	}, runner.Issues)
+}
+
+func Test_TerraformKb4ModuleStructureRule_JSONModule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "compliant JSON module",
+			Content: map[string]string{
+				"_init.tf.json":      `{"terraform": {}, "provider": {"aws": {}}, "data": {"terraform_remote_state": {"network": {}}}}`,
+				"_variables.tf.json": `{"variable": {"name": {}}}`,
+				"_outputs.tf.json":   `{"output": {"name": {"value": "x"}}}`,
+				"main.tf.json":       `{"locals": {"id": "x"}}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "mixed HCL and JSON module",
+			Content: map[string]string{
+				"_init.tf":           `terraform {}`,
+				"_variables.tf.json": `{"variable": {"name": {}}}`,
+				"_outputs.tf":        `output "name" {}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "misplaced blocks in a JSON module",
+			Content: map[string]string{
+				"_init.tf.json":      `{"variable": {"name": {}}}`,
+				"_variables.tf.json": `{"locals": {"id": "x"}}`,
+			},
+			Expected: helper.Issues{
+				{Rule: NewTerraformKb4FileStructureRule(), Message: "Module should include a _outputs.tf file."},
+				{Rule: NewTerraformKb4FileStructureRule(), Message: `variable "name" should be moved from _init.tf.json to _variables.tf`},
+				{Rule: NewTerraformKb4FileStructureRule(), Message: "locals block on line 1 should be moved out of _variables.tf.json"},
+			},
+		},
+		{
+			Name: "configured JSON file names",
+			Content: map[string]string{
+				".tflint.hcl": `
+rule "terraform_kb4_file_structure" {
+  enabled        = true
+  init_file      = "_init.tf.json"
+  variables_file = "_variables.tf.json"
+  outputs_file   = "outputs.tf.json"
+}`,
+				"_init.tf.json":      `{"terraform": {}}`,
+				"_variables.tf.json": `{"variable": {"name": {}}}`,
+				"_outputs.tf.json":   `{"output": {"name": {"value": "x"}}}`,
+			},
+			Expected: helper.Issues{
+				{Rule: NewTerraformKb4FileStructureRule(), Message: "Module should include a outputs.tf.json file."},
+				{Rule: NewTerraformKb4FileStructureRule(), Message: `output "name" should be moved from _outputs.tf.json to outputs.tf.json`},
+			},
+		},
+	}
+
+	rule := NewTerraformKb4FileStructureRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := testRunner(t, tc.Content)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssuesWithoutRange(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_TerraformKb4ModuleStructureRule_ExcludeFiles(t *testing.T) {
+	host := testRunner(t, map[string]string{
+		"_init.tf":         `terraform {}`,
+		"_variables.tf":    `variable "name" {}`,
+		"_outputs.tf":      `output "name" {}`,
+		"main_override.tf": `variable "name" {}`,
+		"generated_iam.tf": `output "role" {}`,
+		"main.tf":          `provider "aws" {}`,
+	})
+	config := DefaultConfig()
+	config.ExcludeFiles = append(config.ExcludeFiles, "generated_*.tf")
+
+	rule := NewTerraformKb4FileStructureRule()
+	if err := rule.Check(NewRunner(host, config)); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssuesWithoutRange(t, helper.Issues{
+		{Rule: rule, Message: `provider "aws" should be moved from main.tf to _init.tf`},
+	}, host.Issues)
+}
+
+func Test_TerraformKb4ModuleStructureRule_MessageTemplate(t *testing.T) {
+	host := testRunner(t, map[string]string{
+		".tflint.hcl": `
+rule "terraform_kb4_file_structure" {
+  enabled = true
+  message = "Move {name} to {expected}: https://runbooks.example.com/terraform/file-structure"
+}`,
+		"_init.tf":      `terraform {}`,
+		"_variables.tf": `variable "name" {}`,
+		"_outputs.tf":   `output "name" {}`,
+		"main.tf":       `output "id" {}`,
+	})
+
+	rule := NewTerraformKb4FileStructureRule()
+	if err := rule.Check(NewRunner(host, nil)); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssuesWithoutRange(t, helper.Issues{
+		{Rule: rule, Message: "Move output.id to _outputs.tf: https://runbooks.example.com/terraform/file-structure"},
+	}, host.Issues)
+}
+
+func Test_TerraformKb4ModuleStructureRule_AggregateMissingFiles(t *testing.T) {
+	config := `
+rule "terraform_kb4_file_structure" {
+  enabled                 = true
+  aggregate_missing_files = true
+}`
+
+	cases := []struct {
+		Name     string
+		Content  map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "anchored to the first existing file",
+			Content: map[string]string{
+				".tflint.hcl":   config,
+				"main.tf":       `resource "aws_instance" "this" {}`,
+				"_variables.tf": `variable "name" {}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: "Module should include the _init.tf, _outputs.tf files.",
+					Range: hcl.Range{
+						Filename: "_variables.tf",
+						Start:    hcl.InitialPos,
+						End:      hcl.InitialPos,
+					},
+				},
+			},
+		},
+		{
+			Name:    "empty module",
+			Content: map[string]string{".tflint.hcl": config},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformKb4FileStructureRule(),
+					Message: "Module should include the _init.tf, _variables.tf, _outputs.tf files.",
+					Range: hcl.Range{
+						Filename: "_init.tf",
+						Start:    hcl.InitialPos,
+						End:      hcl.InitialPos,
+					},
+				},
+			},
+		},
+		{
+			Name: "no missing files",
+			Content: map[string]string{
+				".tflint.hcl":   config,
+				"_init.tf":      `terraform {}`,
+				"_variables.tf": `variable "name" {}`,
+				"_outputs.tf":   `output "name" {}`,
+			},
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewTerraformKb4FileStructureRule()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := testRunner(t, tc.Content)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
 			helper.AssertIssues(t, tc.Expected, runner.Issues)
 		})
 	}
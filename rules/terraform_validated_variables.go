@@ -2,14 +2,49 @@ package rules
 
 import (
 	"fmt"
+	"path"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/knowbe4/tflint-ruleset-kb4/registry"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
-// TerraformDocumentedVariablesRule checks whether variables have descriptions
+// TerraformValidatedVariablesRuleConfig is the rule's .tflint.hcl config
+type TerraformValidatedVariablesRuleConfig struct {
+	Exempt []string `hclext:"exempt,optional" doc:"Variable names, or globs of names, that need no validation block."`
+}
+
+func newTerraformValidatedVariablesRuleConfig() *TerraformValidatedVariablesRuleConfig {
+	return &TerraformValidatedVariablesRuleConfig{Exempt: []string{}}
+}
+
+// Validate rejects malformed globs
+func (c *TerraformValidatedVariablesRuleConfig) Validate() error {
+	for _, pattern := range c.Exempt {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("exempt pattern %q is invalid: %s", pattern, err)
+		}
+	}
+	return nil
+}
+
+// exempts reports whether the variable name matches one of the exempt names or globs
+func (c *TerraformValidatedVariablesRuleConfig) exempts(name string) bool {
+	for _, pattern := range c.Exempt {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// TerraformValidatedVariablesRule checks whether variables have validations
 type TerraformValidatedVariablesRule struct {
-	tflint.DefaultRule
+	BaseRule
+}
+
+func init() {
+	registry.Register(NewTerraformValidatedVariablesRule())
 }
 
 // NewTerraformValidatedVariablesRule returns a new rule
@@ -34,16 +69,34 @@ func (r *TerraformValidatedVariablesRule) Severity() tflint.Severity {
 
 // Link returns the rule reference link
 func (r *TerraformValidatedVariablesRule) Link() string {
-	return "https://engineering.internal.knowbe4.com/tech-stack/terraform/style-guide/#validation"
+	return styleGuideLink(r)
 }
 
-// Check checks whether variables have descriptions
+// Metadata returns the rule documentation
+func (r *TerraformValidatedVariablesRule) Metadata() interface{} {
+	return &Metadata{
+		Description: "Variables must declare at least one `validation` block, unless they are bools, `krn` or listed in `exempt`.",
+		Categories:  []string{CategoryStyle},
+		Anchor:      "validation",
+		Example: `
+variable "name" {
+  type = string
+}`,
+		Config: newTerraformValidatedVariablesRuleConfig(),
+	}
+}
+
+// Check checks whether variables have validations
 func (r *TerraformValidatedVariablesRule) Check(runner tflint.Runner) error {
+	config := newTerraformValidatedVariablesRuleConfig()
+	if err := r.decodeConfig(runner, r, config); err != nil {
+		return err
+	}
 
 	files, _ := runner.GetFiles()
 
 	for filename := range files {
-		r.checkFileSchema(runner, files[filename])
+		r.checkFileSchema(runner, files[filename], config)
 	}
 
 	return nil
@@ -81,7 +134,7 @@ func (r *TerraformValidatedVariablesRule) isIgnoredType(block *hcl.Block) bool {
 	return false
 }
 
-func (r *TerraformValidatedVariablesRule) checkFileSchema(runner tflint.Runner, file *hcl.File) error {
+func (r *TerraformValidatedVariablesRule) checkFileSchema(runner tflint.Runner, file *hcl.File, config *TerraformValidatedVariablesRuleConfig) error {
 
 	content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
 		Blocks: []hcl.BlockHeaderSchema{
@@ -97,7 +150,7 @@ func (r *TerraformValidatedVariablesRule) checkFileSchema(runner tflint.Runner,
 	}
 
 	for _, block := range content.Blocks.OfType("variable") {
-		if r.isIgnoredType(block) {
+		if config.exempts(block.Labels[0]) || r.isIgnoredType(block) {
 			continue
 		}
 
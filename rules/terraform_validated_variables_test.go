@@ -11,6 +11,7 @@ func Test_TerraformDocumentedVariablesRule(t *testing.T) {
 	cases := []struct {
 		Name     string
 		Content  string
+		Config   string
 		Expected helper.Issues
 	}{
 		{
@@ -52,12 +53,41 @@ variable "no_validation_ignore" {
 }`,
 			Expected: helper.Issues{},
 		},
+		{
+			Name: "exempt names and patterns",
+			Content: `
+variable "tags" {}
+
+variable "role_arn" {}
+
+variable "name" {}`,
+			Config: `
+rule "terraform_validated_variables" {
+  enabled = true
+  exempt  = ["tags", "*_arn"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformValidatedVariablesRule(),
+					Message: "`name` variable has no validations. Please include at least 1 validation for types that are not a bool.",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 16},
+					},
+				},
+			},
+		},
 	}
 
 	rule := NewTerraformValidatedVariablesRule()
 
 	for _, tc := range cases {
-		runner := helper.TestRunner(t, map[string]string{"variables.tf": tc.Content})
+		files := map[string]string{"variables.tf": tc.Content}
+		if tc.Config != "" {
+			files[".tflint.hcl"] = tc.Config
+		}
+		runner := helper.TestRunner(t, files)
 
 		if err := rule.Check(runner); err != nil {
 			t.Fatalf("Unexpected error occurred: %s", err)
@@ -0,0 +1,33 @@
+package rules
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// evaluateBool evaluates expr and calls fn with the result when it is a known, non-null bool.
+// The SDK can't evaluate into a bool, and expressions depending on unknown values are skipped as usual.
+func evaluateBool(runner tflint.Runner, expr hcl.Expression, fn func(value bool) error) error {
+	var value cty.Value
+	err := runner.EvaluateExpr(expr, &value, nil)
+	return runner.EnsureNoError(err, func() error {
+		if value.Type() != cty.Bool || !value.IsKnown() || value.IsNull() {
+			return nil
+		}
+		return fn(value.True())
+	})
+}
+
+// evaluateString evaluates expr and calls fn with the result when it is a known, non-null string.
+// Unlike evaluating into a string, values that aren't known yet are skipped instead of failing the check.
+func evaluateString(runner tflint.Runner, expr hcl.Expression, fn func(value string) error) error {
+	var value cty.Value
+	err := runner.EvaluateExpr(expr, &value, nil)
+	return runner.EnsureNoError(err, func() error {
+		if value.Type() != cty.String || !value.IsKnown() || value.IsNull() {
+			return nil
+		}
+		return fn(value.AsString())
+	})
+}
@@ -0,0 +1,142 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_evaluateBool(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Called   bool
+		Expected bool
+	}{
+		{
+			Name:     "true",
+			Content:  `resource "aws_sqs_queue" "this" { enabled = true }`,
+			Called:   true,
+			Expected: true,
+		},
+		{
+			Name: "false variable",
+			Content: `
+variable "enabled" {
+  default = false
+}
+
+resource "aws_sqs_queue" "this" { enabled = var.enabled }`,
+			Called:   true,
+			Expected: false,
+		},
+		{
+			Name: "unknown",
+			Content: `
+variable "enabled" {}
+
+resource "aws_sqs_queue" "this" { enabled = var.enabled }`,
+			Called: false,
+		},
+		{
+			Name:    "not a bool",
+			Content: `resource "aws_sqs_queue" "this" { enabled = "yes" }`,
+			Called:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			content, err := runner.GetResourceContent("aws_sqs_queue", &hclext.BodySchema{
+				Attributes: []hclext.AttributeSchema{{Name: "enabled"}},
+			}, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			called, got := false, false
+			err = evaluateBool(runner, content.Blocks[0].Body.Attributes["enabled"].Expr, func(value bool) error {
+				called, got = true, value
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			if called != tc.Called || got != tc.Expected {
+				t.Fatalf("Expected called=%t value=%t, got called=%t value=%t", tc.Called, tc.Expected, called, got)
+			}
+		})
+	}
+}
+
+func Test_evaluateString(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Called   bool
+		Expected string
+	}{
+		{
+			Name:     "string",
+			Content:  `resource "aws_sqs_queue" "this" { name = "yes" }`,
+			Called:   true,
+			Expected: "yes",
+		},
+		{
+			Name: "variable",
+			Content: `
+variable "name" {
+  default = "no"
+}
+
+resource "aws_sqs_queue" "this" { name = var.name }`,
+			Called:   true,
+			Expected: "no",
+		},
+		{
+			Name: "unknown",
+			Content: `
+variable "name" {}
+
+resource "aws_sqs_queue" "this" { name = var.name }`,
+			Called: false,
+		},
+		{
+			Name:    "not a string",
+			Content: `resource "aws_sqs_queue" "this" { name = [] }`,
+			Called:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			content, err := runner.GetResourceContent("aws_sqs_queue", &hclext.BodySchema{
+				Attributes: []hclext.AttributeSchema{{Name: "name"}},
+			}, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			called, got := false, ""
+			err = evaluateString(runner, content.Blocks[0].Body.Attributes["name"].Expr, func(value string) error {
+				called, got = true, value
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			if called != tc.Called || got != tc.Expected {
+				t.Fatalf("Expected called=%t value=%q, got called=%t value=%q", tc.Called, tc.Expected, called, got)
+			}
+		})
+	}
+}
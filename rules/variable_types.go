@@ -0,0 +1,177 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// variableType is a parsed variable type constraint. Unlike typeexpr it keeps the syntax the rules care about,
+// e.g. bare collection keywords and optional() attributes with their defaults.
+type variableType struct {
+	// Name is the type keyword: string, number, bool, any, list, set, map, object or tuple
+	Name string
+	// Element is the element type of a list, set or map, nil when the keyword is used bare
+	Element *variableType
+	// Attributes are the attributes of an object, in declaration order
+	Attributes []objectAttribute
+	// Elements are the element types of a tuple
+	Elements []*variableType
+	Range    hcl.Range
+}
+
+// objectAttribute is one attribute of an object type
+type objectAttribute struct {
+	Name     string
+	Type     *variableType
+	Optional bool
+	// Default is the optional() default, nil when there is none
+	Default hcl.Expression
+}
+
+// isComplex reports whether the type is an object or a collection rather than a primitive
+func (t *variableType) isComplex() bool {
+	switch t.Name {
+	case "list", "set", "map", "object", "tuple":
+		return true
+	}
+	return false
+}
+
+// walk calls fn for the type and every type nested in it, with the depth of objects enclosing it
+func (t *variableType) walk(fn func(ty *variableType, depth int)) {
+	t.walkDepth(0, fn)
+}
+
+func (t *variableType) walkDepth(depth int, fn func(ty *variableType, depth int)) {
+	fn(t, depth)
+
+	if t.Name == "object" {
+		depth++
+	}
+	if t.Element != nil {
+		t.Element.walkDepth(depth, fn)
+	}
+	for _, attr := range t.Attributes {
+		attr.Type.walkDepth(depth, fn)
+	}
+	for _, elem := range t.Elements {
+		elem.walkDepth(depth, fn)
+	}
+}
+
+// parseVariableType parses a variable's type expression. JSON files hold the type as a string of native syntax.
+func parseVariableType(expr hcl.Expression) (*variableType, error) {
+	if _, ok := expr.(hclsyntax.Expression); !ok {
+		value, diags := expr.Value(nil)
+		if diags.HasErrors() || value.Type() != cty.String || value.IsNull() {
+			return nil, fmt.Errorf("type must be a type expression")
+		}
+
+		native, diags := hclsyntax.ParseExpression([]byte(value.AsString()), expr.Range().Filename, expr.Range().Start)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		expr = native
+	}
+
+	return parseTypeSyntax(expr.(hclsyntax.Expression))
+}
+
+func parseTypeSyntax(expr hclsyntax.Expression) (*variableType, error) {
+	switch e := expr.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		if len(e.Traversal) != 1 {
+			return nil, fmt.Errorf("%s: invalid type", e.SrcRange)
+		}
+		name := e.Traversal.RootName()
+		switch name {
+		case "string", "number", "bool", "any", "list", "set", "map":
+			return &variableType{Name: name, Range: e.SrcRange}, nil
+		}
+		return nil, fmt.Errorf("%s: unknown type %q", e.SrcRange, name)
+
+	case *hclsyntax.FunctionCallExpr:
+		return parseTypeCall(e)
+	}
+
+	return nil, fmt.Errorf("%s: invalid type", expr.Range())
+}
+
+func parseTypeCall(call *hclsyntax.FunctionCallExpr) (*variableType, error) {
+	ty := &variableType{Name: call.Name, Range: call.Range()}
+	if len(call.Args) != 1 {
+		return nil, fmt.Errorf("%s: %s takes exactly one argument", call.Range(), call.Name)
+	}
+	arg := call.Args[0]
+
+	switch call.Name {
+	case "list", "set", "map":
+		elem, err := parseTypeSyntax(arg)
+		if err != nil {
+			return nil, err
+		}
+		ty.Element = elem
+
+	case "object":
+		attrs, ok := arg.(*hclsyntax.ObjectConsExpr)
+		if !ok {
+			return nil, fmt.Errorf("%s: object takes an object of attribute types", call.Range())
+		}
+		for _, item := range attrs.Items {
+			name := hcl.ExprAsKeyword(item.KeyExpr)
+			if name == "" {
+				return nil, fmt.Errorf("%s: object attribute names must be plain identifiers", item.KeyExpr.Range())
+			}
+
+			attr, err := parseObjectAttribute(name, item.ValueExpr)
+			if err != nil {
+				return nil, err
+			}
+			ty.Attributes = append(ty.Attributes, attr)
+		}
+
+	case "tuple":
+		elems, ok := arg.(*hclsyntax.TupleConsExpr)
+		if !ok {
+			return nil, fmt.Errorf("%s: tuple takes a list of element types", call.Range())
+		}
+		for _, expr := range elems.Exprs {
+			elem, err := parseTypeSyntax(expr)
+			if err != nil {
+				return nil, err
+			}
+			ty.Elements = append(ty.Elements, elem)
+		}
+
+	default:
+		return nil, fmt.Errorf("%s: unknown type %q", call.Range(), call.Name)
+	}
+
+	return ty, nil
+}
+
+func parseObjectAttribute(name string, expr hclsyntax.Expression) (objectAttribute, error) {
+	call, ok := expr.(*hclsyntax.FunctionCallExpr)
+	if !ok || call.Name != "optional" {
+		ty, err := parseTypeSyntax(expr)
+		return objectAttribute{Name: name, Type: ty}, err
+	}
+
+	if len(call.Args) < 1 || len(call.Args) > 2 {
+		return objectAttribute{}, fmt.Errorf("%s: optional takes a type and an optional default", call.Range())
+	}
+
+	ty, err := parseTypeSyntax(call.Args[0])
+	if err != nil {
+		return objectAttribute{}, err
+	}
+
+	attr := objectAttribute{Name: name, Type: ty, Optional: true}
+	if len(call.Args) == 2 {
+		attr.Default = call.Args[1]
+	}
+	return attr, nil
+}
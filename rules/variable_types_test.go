@@ -0,0 +1,74 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+func Test_parseVariableType(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Type    string
+		JSON    bool
+		Complex bool
+		Depth   int
+		Error   string
+	}{
+		{Name: "primitive", Type: `string`},
+		{Name: "bare collection", Type: `list`, Complex: true},
+		{Name: "collection", Type: `map(list(string))`, Complex: true},
+		{Name: "object", Type: `object({ name = string, tags = optional(map(string), {}) })`, Complex: true, Depth: 1},
+		{Name: "nested object", Type: `list(object({ rules = list(object({ port = number })) }))`, Complex: true, Depth: 2},
+		{Name: "tuple", Type: `tuple([string, number])`, Complex: true},
+		{Name: "json", Type: `"set(string)"`, JSON: true, Complex: true},
+		{Name: "unknown type", Type: `text`, Error: `variables.tf:1,8-12: unknown type "text"`},
+		{Name: "unknown type constructor", Type: `array(string)`, Error: `variables.tf:1,8-21: unknown type "array"`},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			var file *hcl.File
+			var diags hcl.Diagnostics
+			if tc.JSON {
+				file, diags = hclparse.NewParser().ParseJSON([]byte(`{"type": `+tc.Type+`}`), "variables.tf.json")
+			} else {
+				file, diags = hclparse.NewParser().ParseHCL([]byte(`type = `+tc.Type), "variables.tf")
+			}
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			attrs, diags := file.Body.JustAttributes()
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			ty, err := parseVariableType(attrs["type"].Expr)
+			if tc.Error != "" {
+				if err == nil || err.Error() != tc.Error {
+					t.Fatalf("Expected error %q, got %v", tc.Error, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			if ty.isComplex() != tc.Complex {
+				t.Errorf("isComplex() = %t, expected %t", ty.isComplex(), tc.Complex)
+			}
+
+			depth := 0
+			ty.walk(func(_ *variableType, d int) {
+				if d > depth {
+					depth = d
+				}
+			})
+			if depth != tc.Depth {
+				t.Errorf("Expected object depth %d, got %d", tc.Depth, depth)
+			}
+		})
+	}
+}